@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/catalogue"
+	"film_library/internal/storage/postgres"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var errExportUsage = errors.New("usage: film_library export <file.csv|file.ndjson|file.jsonl|file.json>")
+
+func runExport(log *slog.Logger, dbUrl string, args []string) error {
+	if len(args) != 1 {
+		return errExportUsage
+	}
+
+	format, ok := extensionFormats[strings.ToLower(filepath.Ext(args[0]))]
+	if !ok {
+		return errExportUsage
+	}
+
+	storage, err := postgres.New(dbUrl)
+	if err != nil {
+		return err
+	}
+	defer storage.Db.Close()
+
+	f, err := os.Create(args[0])
+	if err != nil {
+		return err
+	}
+
+	err = catalogue.Export(context.Background(), f, format, storage)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		// a partial export must not pass for a complete one
+		_ = os.Remove(args[0])
+		return err
+	}
+
+	log.Info("export finished", slog.String("file", args[0]))
+
+	return nil
+}
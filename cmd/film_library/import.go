@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/catalogue"
+	"film_library/internal/storage/postgres"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var errImportUsage = errors.New("usage: film_library import <file.csv|file.ndjson|file.jsonl|file.json>")
+
+var extensionFormats = map[string]string{
+	".csv":    catalogue.FormatCSV,
+	".ndjson": catalogue.FormatNDJSON,
+	".jsonl":  catalogue.FormatNDJSON,
+	".json":   catalogue.FormatJSON,
+}
+
+func runImport(log *slog.Logger, dbUrl string, args []string) error {
+	if len(args) != 1 {
+		return errImportUsage
+	}
+
+	format, ok := extensionFormats[strings.ToLower(filepath.Ext(args[0]))]
+	if !ok {
+		return errImportUsage
+	}
+
+	f, err := os.Open(args[0])
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	storage, err := postgres.New(dbUrl)
+	if err != nil {
+		return err
+	}
+	defer storage.Db.Close()
+
+	report, err := catalogue.Import(context.Background(), f, format, storage, catalogue.DefaultBatchSize)
+	for _, rowErr := range report.Errors {
+		log.Warn("row not imported", slog.Int("row", rowErr.Row), slog.String("field", rowErr.Field), slog.String("error", rowErr.Message))
+	}
+	log.Info("import finished",
+		slog.Int("created", report.Created),
+		slog.Int("updated", report.Updated),
+		slog.Int("unchanged", report.Unchanged),
+		slog.Int("failed", report.Failed),
+	)
+
+	return err
+}
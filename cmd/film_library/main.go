@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	_ "film_library/docs" // docs is generated by Swag CLI, you have to import it.
 	"film_library/internal/config"
 	deleteActorMovie "film_library/internal/http-server/handlers/actor-movie/delete"
@@ -10,18 +11,52 @@ import (
 	saveActor "film_library/internal/http-server/handlers/actor/save"
 	searchActor "film_library/internal/http-server/handlers/actor/search"
 	updateActor "film_library/internal/http-server/handlers/actor/update"
+	listAuditEvents "film_library/internal/http-server/handlers/admin/audit/list"
+	listAllReviews "film_library/internal/http-server/handlers/admin/review/list"
+	moderateReview "film_library/internal/http-server/handlers/admin/review/moderate"
+	listTrash "film_library/internal/http-server/handlers/admin/trash/list"
+	restoreTrash "film_library/internal/http-server/handlers/admin/trash/restore"
+	disableUser "film_library/internal/http-server/handlers/admin/user/disable"
+	forcePasswordReset "film_library/internal/http-server/handlers/admin/user/force_password_reset"
+	grantRole "film_library/internal/http-server/handlers/admin/user/grant_role"
+	listUsers "film_library/internal/http-server/handlers/admin/user/list"
+	revokeRole "film_library/internal/http-server/handlers/admin/user/revoke_role"
+	bulkImport "film_library/internal/http-server/handlers/catalogue/bulk_import"
+	exportCatalogue "film_library/internal/http-server/handlers/catalogue/export"
+	addListItem "film_library/internal/http-server/handlers/list/add_item"
+	allLists "film_library/internal/http-server/handlers/list/all"
+	deleteList "film_library/internal/http-server/handlers/list/delete"
+	listItems "film_library/internal/http-server/handlers/list/items"
+	removeListItem "film_library/internal/http-server/handlers/list/remove_item"
+	reorderList "film_library/internal/http-server/handlers/list/reorder"
+	saveList "film_library/internal/http-server/handlers/list/save"
+	shareList "film_library/internal/http-server/handlers/list/share"
+	sharedList "film_library/internal/http-server/handlers/list/shared"
+	unshareList "film_library/internal/http-server/handlers/list/unshare"
 	allMovies "film_library/internal/http-server/handlers/movie/all"
 	deleteMovie "film_library/internal/http-server/handlers/movie/delete"
 	saveMovie "film_library/internal/http-server/handlers/movie/save"
 	searchMovieById "film_library/internal/http-server/handlers/movie/search_by_id"
 	searchMovieByPart "film_library/internal/http-server/handlers/movie/search_by_part"
 	updateMovie "film_library/internal/http-server/handlers/movie/update"
+	deleteReview "film_library/internal/http-server/handlers/review/delete"
+	listReviews "film_library/internal/http-server/handlers/review/list"
+	saveReview "film_library/internal/http-server/handlers/review/save"
+	updateReview "film_library/internal/http-server/handlers/review/update"
+	"film_library/internal/http-server/handlers/token/refresh"
+	changePassword "film_library/internal/http-server/handlers/user/change_password"
 	"film_library/internal/http-server/handlers/user/signin"
+	"film_library/internal/http-server/handlers/user/signout"
 	"film_library/internal/http-server/handlers/user/signup"
-	mwAdminAuthenticator "film_library/internal/http-server/middleware/admin_authenticator"
+	mwAudit "film_library/internal/http-server/middleware/audit"
+	mwDeprecation "film_library/internal/http-server/middleware/deprecation"
 	mwLogger "film_library/internal/http-server/middleware/logger"
+	mwPermission "film_library/internal/http-server/middleware/permission"
+	mwTokenDenylist "film_library/internal/http-server/middleware/token_denylist"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
 	"film_library/internal/storage/postgres"
+	"film_library/internal/storage/purger"
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/jwtauth/v5"
@@ -29,6 +64,7 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"time"
 )
 
 const (
@@ -53,6 +89,30 @@ func main() {
 
 	log := setupLogger(cfg.Env)
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(log, cfg.Storage, os.Args[2:]); err != nil {
+			log.Error("migrate failed", sl.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "import" {
+		if err := runImport(log, cfg.Storage, os.Args[2:]); err != nil {
+			log.Error("import failed", sl.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+
+	if len(os.Args) > 1 && os.Args[1] == "export" {
+		if err := runExport(log, cfg.Storage, os.Args[2:]); err != nil {
+			log.Error("export failed", sl.Err(err))
+			os.Exit(1)
+		}
+		return
+	}
+
 	log.Info("starting film_library api", slog.String("env", cfg.Env))
 	log.Debug("debug messages are enabled")
 
@@ -62,9 +122,35 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err := storage.EnsureDefaultRoles(); err != nil {
+		log.Error("failed to seed roles", sl.Err(err))
+		os.Exit(1)
+	}
+
+	if err := storage.SetSearchLanguage(cfg.Search.Language); err != nil {
+		log.Error("failed to set search language", sl.Err(err), slog.String("language", cfg.Search.Language))
+		os.Exit(1)
+	}
+
+	if cfg.Admin.Username != "" && cfg.Admin.Password == "" {
+		log.Warn("ADMIN_PASSWORD is not set, admin is not seeded", slog.String("username", cfg.Admin.Username))
+	} else if cfg.Admin.Username != "" {
+		if err := storage.EnsureAdmin(context.Background(), cfg.Admin.Username, cfg.Admin.Password); err != nil {
+			log.Error("failed to seed admin", sl.Err(err))
+			os.Exit(1)
+		}
+	}
+
+	if cfg.Trash.RetentionDays > 0 && cfg.Trash.PurgeInterval > 0 {
+		retention := time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour
+		go purger.Run(context.Background(), log, storage, retention, cfg.Trash.PurgeInterval)
+	}
+
 	router := chi.NewRouter()
 
-	tokenAuth = jwtauth.New("HS256", []byte(cfg.HTTPServer.JWTSecret), nil)
+	tokens := token.New(cfg.HTTPServer.JWTSecret, cfg.HTTPServer.JWTIssuer,
+		cfg.HTTPServer.AccessTokenTTL, cfg.HTTPServer.RefreshTokenTTL)
+	tokenAuth = tokens.JWTAuth()
 
 	router.Use(middleware.RequestID)
 	router.Use(mwLogger.New(log))
@@ -73,31 +159,110 @@ func main() {
 
 	router.Post("/signup", signup.New(log, storage))
 
-	router.Post("/signin", signin.New(log, storage, cfg.HTTPServer.JWTSecret))
+	router.Post("/signin", signin.New(log, storage, storage, tokens))
+
+	router.Post("/token/refresh", refresh.New(log, storage, storage, tokens))
+
+	router.Post("/password/change", changePassword.New(log, storage))
+
+	router.Get("/api/v1/shared/lists/{share_token}", sharedList.New(log, storage))
 
 	router.Group(func(r chi.Router) {
 		r.Use(jwtauth.Verifier(tokenAuth))
-		r.Use(mwAdminAuthenticator.New(tokenAuth, storage))
-
-		r.Post("/actor/save", saveActor.New(log, storage))
-		r.Post("/movie/save", saveMovie.New(log, storage))
-		r.Post("/actor/update", updateActor.New(log, storage))
-		r.Post("/movie/update", updateMovie.New(log, storage))
-		r.Post("/actor-movie/save", saveActorMovie.New(log, storage))
-		r.Delete("/actor/delete", deleteActor.New(log, storage))
-		r.Delete("/movie/delete", deleteMovie.New(log, storage))
-		r.Delete("/actor-movie/delete", deleteActorMovie.New(log, storage))
+		r.Use(mwTokenDenylist.New(storage))
+		r.Use(mwAudit.New())
+
+		canWriteActors := mwPermission.RequirePermission(tokenAuth, mwPermission.ActorWrite)
+		canDeleteActors := mwPermission.RequirePermission(tokenAuth, mwPermission.ActorDelete)
+		canWriteMovies := mwPermission.RequirePermission(tokenAuth, mwPermission.MovieWrite)
+		canDeleteMovies := mwPermission.RequirePermission(tokenAuth, mwPermission.MovieDelete)
+		canManageUsers := mwPermission.RequirePermission(tokenAuth, mwPermission.UserManage)
+		canReadAudit := mwPermission.RequirePermission(tokenAuth, mwPermission.AuditRead)
+		canManageTrash := mwPermission.RequirePermission(tokenAuth, mwPermission.TrashManage)
+		canImport := mwPermission.RequirePermission(tokenAuth, mwPermission.CatalogueImport)
+		canExport := mwPermission.RequirePermission(tokenAuth, mwPermission.CatalogueExport)
+		canWriteReviews := mwPermission.RequirePermission(tokenAuth, mwPermission.ReviewWrite)
+		canModerateReviews := mwPermission.RequirePermission(tokenAuth, mwPermission.ReviewModerate)
+
+		r.Group(func(r chi.Router) {
+			r.Use(mwDeprecation.New())
+
+			r.With(canWriteActors).Post("/actor/save", saveActor.New(log, storage))
+			r.With(canWriteMovies).Post("/movie/save", saveMovie.New(log, storage))
+			r.With(canWriteActors).Post("/actor/update", updateActor.New(log, storage))
+			r.With(canWriteMovies).Post("/movie/update", updateMovie.New(log, storage))
+			r.With(canWriteMovies).Post("/actor-movie/save", saveActorMovie.New(log, storage))
+			r.With(canDeleteActors).Delete("/actor/delete", deleteActor.New(log, storage))
+			r.With(canDeleteMovies).Delete("/movie/delete", deleteMovie.New(log, storage))
+			r.With(canWriteMovies).Delete("/actor-movie/delete", deleteActorMovie.New(log, storage))
+		})
+
+		r.With(canWriteMovies).Post("/api/v1/movies", saveMovie.New(log, storage))
+		r.With(canWriteMovies).Patch("/api/v1/movies/{movie_id}", updateMovie.New(log, storage))
+		r.With(canDeleteMovies).Delete("/api/v1/movies/{movie_id}", deleteMovie.New(log, storage))
+		r.With(canWriteMovies).Post("/api/v1/movies/{movie_id}/actors", saveActorMovie.New(log, storage))
+		r.With(canWriteMovies).Delete("/api/v1/movies/{movie_id}/actors", deleteActorMovie.New(log, storage))
+		r.With(canWriteMovies).Put("/api/v1/movies/{movie_id}/actors/{actor_id}", saveActorMovie.New(log, storage))
+		r.With(canWriteMovies).Delete("/api/v1/movies/{movie_id}/actors/{actor_id}", deleteActorMovie.New(log, storage))
+		r.With(canWriteActors).Post("/api/v1/actors", saveActor.New(log, storage))
+		r.With(canWriteActors).Patch("/api/v1/actors/{actor_id}", updateActor.New(log, storage))
+		r.With(canDeleteActors).Delete("/api/v1/actors/{actor_id}", deleteActor.New(log, storage))
+		r.With(canWriteReviews).Post("/api/v1/movies/{movie_id}/reviews", saveReview.New(log, storage))
+		r.With(canWriteReviews).Patch("/api/v1/reviews/{review_id}", updateReview.New(log, storage))
+		r.With(canWriteReviews).Delete("/api/v1/reviews/{review_id}", deleteReview.New(log, storage))
+
+		r.With(canManageUsers).Get("/admin/users", listUsers.New(log, storage))
+		r.With(canManageUsers).Post("/admin/users/roles/grant", grantRole.New(log, storage))
+		r.With(canManageUsers).Post("/admin/users/roles/revoke", revokeRole.New(log, storage))
+		r.With(canManageUsers).Post("/admin/users/disable", disableUser.New(log, storage))
+		r.With(canManageUsers).Post("/admin/users/force_password_reset", forcePasswordReset.New(log, storage))
+
+		r.With(canReadAudit).Get("/admin/audit", listAuditEvents.New(log, storage))
+
+		r.With(canManageTrash).Get("/admin/trash", listTrash.New(log, storage))
+		r.With(canManageTrash).Post("/admin/trash/restore", restoreTrash.New(log, storage))
+
+		r.With(canModerateReviews).Get("/admin/reviews", listAllReviews.New(log, storage))
+		r.With(canModerateReviews).Post("/admin/reviews/moderate", moderateReview.New(log, storage))
+
+		r.With(canImport).Post("/import", bulkImport.New(log, storage))
+		r.With(canExport).Get("/export", exportCatalogue.New(log, storage))
 	})
 
 	router.Group(func(r chi.Router) {
 		r.Use(jwtauth.Verifier(tokenAuth))
+		r.Use(mwTokenDenylist.New(storage))
 		r.Use(jwtauth.Authenticator(tokenAuth))
+		r.Use(mwAudit.New())
+
+		r.Post("/signout", signout.New(log, storage))
+
+		r.Group(func(r chi.Router) {
+			r.Use(mwDeprecation.New())
+
+			r.Get("/actor/search", searchActor.New(log, storage))
+			r.Get("/movie/search_by_id", searchMovieById.New(log, storage, storage))
+			r.Get("/movie/all", allMovies.New(log, storage, storage))
+			r.Get("/actor/all", allActors.New(log, storage))
+			r.Get("/movie/search_by_part", searchMovieByPart.New(log, storage, storage))
+		})
+
+		r.Get("/api/v1/movies", allMovies.New(log, storage, storage))
+		r.Get("/api/v1/movies/search", searchMovieByPart.New(log, storage, storage))
+		r.Get("/api/v1/movies/{movie_id}", searchMovieById.New(log, storage, storage))
+		r.Get("/api/v1/movies/{movie_id}/reviews", listReviews.New(log, storage))
+		r.Get("/api/v1/actors", allActors.New(log, storage))
+		r.Get("/api/v1/actors/{actor_id}", searchActor.New(log, storage))
 
-		r.Get("/actor/search", searchActor.New(log, storage))
-		r.Get("/movie/search_by_id", searchMovieById.New(log, storage))
-		r.Get("/movie/all", allMovies.New(log, storage))
-		r.Get("/actor/all", allActors.New(log, storage))
-		r.Get("/movie/search_by_part", searchMovieByPart.New(log, storage))
+		r.Get("/api/v1/lists", allLists.New(log, storage))
+		r.Post("/api/v1/lists", saveList.New(log, storage))
+		r.Delete("/api/v1/lists/{list_id}", deleteList.New(log, storage))
+		r.Get("/api/v1/lists/{list_id}/items", listItems.New(log, storage))
+		r.Post("/api/v1/lists/{list_id}/items", addListItem.New(log, storage))
+		r.Put("/api/v1/lists/{list_id}/items", reorderList.New(log, storage))
+		r.Delete("/api/v1/lists/{list_id}/items/{movie_id}", removeListItem.New(log, storage))
+		r.Post("/api/v1/lists/{list_id}/share", shareList.New(log, storage))
+		r.Delete("/api/v1/lists/{list_id}/share", unshareList.New(log, storage))
 	})
 
 	router.Get("/swagger/*", httpSwagger.Handler(
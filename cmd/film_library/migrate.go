@@ -0,0 +1,77 @@
+package main
+
+import (
+	"database/sql"
+	"errors"
+	"film_library/internal/storage/migrator"
+	"film_library/internal/storage/postgres/migrations"
+	"fmt"
+	_ "github.com/jackc/pgx/v5/stdlib"
+	"log/slog"
+	"os"
+	"text/tabwriter"
+	"time"
+)
+
+var errMigrateUsage = errors.New("usage: film_library migrate up|down|status")
+
+func runMigrate(log *slog.Logger, dbUrl string, args []string) error {
+	if len(args) != 1 {
+		return errMigrateUsage
+	}
+
+	db, err := sql.Open("pgx", dbUrl)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	m, err := migrator.New(db, migrations.FS)
+	if err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := m.Up()
+		for _, migration := range applied {
+			log.Info("migration applied", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+		}
+		if err != nil {
+			return err
+		}
+		if len(applied) == 0 {
+			log.Info("no pending migrations")
+		}
+	case "down":
+		migration, err := m.Down()
+		if err != nil {
+			return err
+		}
+		if migration == nil {
+			log.Info("no migrations to roll back")
+			return nil
+		}
+		log.Info("migration rolled back", slog.Int64("version", migration.Version), slog.String("name", migration.Name))
+	case "status":
+		statuses, err := m.Status()
+		if err != nil {
+			return err
+		}
+
+		tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(tw, "VERSION\tNAME\tAPPLIED AT")
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.Applied {
+				appliedAt = status.AppliedAt.Format(time.RFC3339)
+			}
+			fmt.Fprintf(tw, "%d\t%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return tw.Flush()
+	default:
+		return errMigrateUsage
+	}
+
+	return nil
+}
@@ -35,22 +35,15 @@ const docTemplate = `{
                 "summary": "Delete actors from movie",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Movie ID",
-                        "name": "movie_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "csv",
                         "description": "Actors IDs",
                         "name": "actors_ids",
-                        "in": "path",
-                        "required": true
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
                     }
                 ],
                 "responses": {
@@ -63,19 +56,25 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
@@ -83,7 +82,7 @@ const docTemplate = `{
         },
         "/actor-movie/save": {
             "post": {
-                "description": "Add actors to movie by movie_id and actors_ids",
+                "description": "Add cast and crew credits to movie by movie_id. actors_ids are added as actors, actor_id with credit_type, character and billing_order. Adding a credit again only changes the given character and billing_order",
                 "consumes": [
                     "application/json"
                 ],
@@ -96,22 +95,50 @@ const docTemplate = `{
                 "summary": "Add actors to movie",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Movie ID",
-                        "name": "movie_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Credit type of actor_id: actor, director, writer, producer or composer",
+                        "name": "credit_type",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
                     },
                     {
-                        "type": "array",
-                        "items": {
+                        "description": "Character played by actor_id",
+                        "name": "character",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Billing order of actor_id, from 1",
+                        "name": "billing_order",
+                        "in": "body",
+                        "schema": {
                             "type": "integer"
-                        },
-                        "collectionFormat": "csv",
+                        }
+                    },
+                    {
                         "description": "Actors IDs",
                         "name": "actors_ids",
-                        "in": "path",
-                        "required": true
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Credits",
+                        "name": "credits",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_http-server_handlers_actor-movie_save.Credit"
+                            }
+                        }
                     }
                 ],
                 "responses": {
@@ -124,19 +151,31 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
@@ -144,7 +183,7 @@ const docTemplate = `{
         },
         "/actor/all": {
             "get": {
-                "description": "Get all actors",
+                "description": "Get all actors. Filters combine with AND. sort takes comma-separated fields among name, birthdate,\nmovie_count and, with a name search, relevance, each prefixed with - for descending, e.g. -movie_count,name.\nActors are by actor_id by default, or by relevance with a name search",
                 "consumes": [
                     "application/json"
                 ],
@@ -155,6 +194,68 @@ const docTemplate = `{
                     "Actor"
                 ],
                 "summary": "Get all actors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sort keys, e.g. -movie_count,name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "male",
+                            "female",
+                            "other",
+                            "unspecified"
+                        ],
+                        "type": "string",
+                        "description": "Gender",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Born in this year or later",
+                        "name": "born_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Born in this year or earlier",
+                        "name": "born_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether the actor is credited in a movie",
+                        "name": "has_movies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of or a misspelling of the name or an alias",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -165,13 +266,19 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
@@ -179,7 +286,7 @@ const docTemplate = `{
         },
         "/actor/delete": {
             "delete": {
-                "description": "Delete an actor by actor_id",
+                "description": "Move an actor to the trash by actor_id. Admins can restore them until they are purged",
                 "consumes": [
                     "application/json"
                 ],
@@ -192,11 +299,10 @@ const docTemplate = `{
                 "summary": "Delete an actor",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Actor ID",
-                        "name": "actor_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "ETag the deletion is based on",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -209,19 +315,37 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
@@ -229,7 +353,7 @@ const docTemplate = `{
         },
         "/actor/save": {
             "post": {
-                "description": "Create a new actor by name, gender and birthdate",
+                "description": "Create a new actor by name, gender (male, female, other or unspecified) and birthdate, optionally with biography, birthplace, nationality (ISO 3166-1 alpha-2), death_date, aliases and external_ids (imdb, wikidata, tmdb)",
                 "consumes": [
                     "application/json"
                 ],
@@ -252,7 +376,7 @@ const docTemplate = `{
                     },
                     {
                         "description": "Gender",
-                        "name": "name",
+                        "name": "gender",
                         "in": "body",
                         "required": true,
                         "schema": {
@@ -267,11 +391,65 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Biography",
+                        "name": "biography",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Birthplace",
+                        "name": "birthplace",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Nationality, e.g. US",
+                        "name": "nationality",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Death date",
+                        "name": "death_date",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Alternative names",
+                        "name": "aliases",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Ids in other databases by source: imdb, wikidata or tmdb",
+                        "name": "external_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
                             "$ref": "#/definitions/internal_http-server_handlers_actor_save.Response"
                         }
@@ -279,19 +457,31 @@ const docTemplate = `{
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
@@ -312,30 +502,50 @@ const docTemplate = `{
                 "summary": "Get an actor",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Actor ID",
-                        "name": "actor_id",
-                        "in": "path",
-                        "required": true
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/search.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_actor_search.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the actor"
+                            }
                         }
                     },
+                    "304": {
+                        "description": "Cached copy is current"
+                    },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
@@ -343,9 +553,10 @@ const docTemplate = `{
         },
         "/actor/update": {
             "post": {
-                "description": "Update an actor by actor_id",
+                "description": "Update an actor by actor_id. Fields left out are kept, all given fields are changed at once. Given aliases and external_ids replace the current ones, empty biography, birthplace, nationality and death_date clear them. PATCH also takes application/merge-patch+json bodies, where null clears any field but name, gender and birthdate",
                 "consumes": [
-                    "application/json"
+                    "application/json",
+                    "application/merge-patch+json"
                 ],
                 "produces": [
                     "application/json"
@@ -355,13 +566,6 @@ const docTemplate = `{
                 ],
                 "summary": "Update an actor",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Actor ID",
-                        "name": "actor_id",
-                        "in": "path",
-                        "required": true
-                    },
                     {
                         "description": "Name",
                         "name": "name",
@@ -371,7 +575,7 @@ const docTemplate = `{
                         }
                     },
                     {
-                        "description": "Gender",
+                        "description": "Gender: male, female, other or unspecified",
                         "name": "gender",
                         "in": "body",
                         "schema": {
@@ -385,6 +589,66 @@ const docTemplate = `{
                         "schema": {
                             "type": "string"
                         }
+                    },
+                    {
+                        "description": "Biography",
+                        "name": "biography",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Birthplace",
+                        "name": "birthplace",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Nationality, e.g. FR",
+                        "name": "nationality",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Death date",
+                        "name": "death_date",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Other names",
+                        "name": "aliases",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Ids by source: imdb, wikidata or tmdb",
+                        "name": "external_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -392,32 +656,62 @@ const docTemplate = `{
                         "description": "OK",
                         "schema": {
                             "$ref": "#/definitions/internal_http-server_handlers_actor_update.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the actor"
+                            }
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
             }
         },
-        "/movie/all": {
+        "/admin/audit": {
             "get": {
-                "description": "Get all movies",
+                "description": "List changes of movies, actors, users and reviews, most recent first. before and after hold the changed fields",
                 "consumes": [
                     "application/json"
                 ],
@@ -425,43 +719,96 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movie"
+                    "Admin"
                 ],
-                "summary": "Get all movies",
+                "summary": "List audit events",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Sort by",
-                        "name": "sort_by",
-                        "in": "query",
-                        "required": true
+                        "description": "movie, actor, user or review",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the entity, with entity_type",
+                        "name": "entity_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the user who made the changes",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, inclusive",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC 3339 time, exclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http-server_handlers_movie_all.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_audit_list.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
-                    }
-                }
-            }
-        },
-        "/movie/delete": {
-            "delete": {
-                "description": "Delete a movie by movie_id",
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/reviews": {
+            "get": {
+                "description": "List reviews of every status for moderation, most recent first",
                 "consumes": [
                     "application/json"
                 ],
@@ -469,49 +816,90 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movie"
+                    "Admin"
                 ],
-                "summary": "Delete a movie",
+                "summary": "List reviews",
                 "parameters": [
                     {
                         "type": "integer",
                         "description": "Movie ID",
                         "name": "movie_id",
-                        "in": "path",
-                        "required": true
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "ID of the author",
+                        "name": "user_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "published or hidden",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http-server_handlers_movie_delete.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_review_list.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
             }
         },
-        "/movie/save": {
+        "/admin/reviews/moderate": {
             "post": {
-                "description": "Save movie by title, description, release_date, rating and actors_ids",
+                "description": "Hide a review of any user, which takes its score out of the community score, or publish it again",
                 "consumes": [
                     "application/json"
                 ],
@@ -519,81 +907,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movie"
+                    "Admin"
                 ],
-                "summary": "Save movie",
+                "summary": "Moderate a review",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Title",
-                        "name": "title",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Description",
-                        "name": "description",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Release Date",
-                        "name": "release_date",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Rating",
-                        "name": "rating",
-                        "in": "path",
-                        "required": true
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "integer"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Actors IDs",
-                        "name": "actors_ids",
-                        "in": "path",
-                        "required": true
+                        "description": "published or hidden",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http-server_handlers_movie_save.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_review_moderate.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
             }
         },
-        "/movie/search_by_id": {
+        "/admin/trash": {
             "get": {
-                "description": "Search a movie by movie_id",
+                "description": "List deleted movies and actors that can still be restored, most recently deleted first",
                 "consumes": [
                     "application/json"
                 ],
@@ -601,43 +980,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movie"
+                    "Admin"
                 ],
-                "summary": "Search a movie by movie_id",
+                "summary": "List trash",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "movie or actor, both by default",
+                        "name": "entity_type",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "Movie ID",
-                        "name": "movie_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/search_by_id.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_trash_list.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
             }
         },
-        "/movie/search_by_part": {
-            "get": {
-                "description": "Search a movie by part",
+        "/admin/trash/restore": {
+            "post": {
+                "description": "Restore a deleted movie with its cast, or a deleted actor back into their casts",
                 "consumes": [
                     "application/json"
                 ],
@@ -645,43 +1053,72 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movie"
+                    "Admin"
                 ],
-                "summary": "Search a movie by part",
+                "summary": "Restore from trash",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Part",
-                        "name": "part",
-                        "in": "query",
-                        "required": true
+                        "description": "movie or actor",
+                        "name": "entity_type",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Movie or actor ID",
+                        "name": "entity_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/search_by_part.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_trash_restore.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
             }
         },
-        "/movie/update": {
-            "post": {
-                "description": "Update movie by movie_id",
+        "/admin/users": {
+            "get": {
+                "description": "List users with their roles, optionally filtered by a part of the username",
                 "consumes": [
                     "application/json"
                 ],
@@ -689,81 +1126,54 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Movie"
+                    "Admin"
                 ],
-                "summary": "Update movie",
+                "summary": "List users",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Movie ID",
-                        "name": "movie_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Title",
-                        "name": "title",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Description",
-                        "name": "description",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Release Date",
-                        "name": "release_date",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Rating",
-                        "name": "rating",
-                        "in": "body",
-                        "schema": {
-                            "type": "integer"
-                        }
+                        "type": "string",
+                        "description": "Part of username",
+                        "name": "query",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/internal_http-server_handlers_movie_update.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_user_list.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
             }
         },
-        "/signin": {
+        "/admin/users/disable": {
             "post": {
-                "description": "Sign in a user by username and password",
+                "description": "Disable or re-enable a user account. Disabling ends the user's sessions at once. The last enabled admin can not be disabled",
                 "consumes": [
                     "application/json"
                 ],
@@ -771,26 +1181,26 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Sign in a user",
+                "summary": "Disable user",
                 "parameters": [
                     {
-                        "description": "Username",
-                        "name": "username",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     },
                     {
-                        "description": "Password",
-                        "name": "password",
+                        "description": "Disable or enable",
+                        "name": "disabled",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "boolean"
                         }
                     }
                 ],
@@ -798,21 +1208,51 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/signin.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_user_disable.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
                     }
                 }
             }
         },
-        "/signup": {
+        "/admin/users/force_password_reset": {
             "post": {
-                "description": "Create a new user by username and password",
+                "description": "Require the user to change the password before the next signin and end the user's sessions",
                 "consumes": [
                     "application/json"
                 ],
@@ -820,26 +1260,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User"
+                    "Admin"
                 ],
-                "summary": "Create a new user",
+                "summary": "Force password reset",
                 "parameters": [
                     {
-                        "description": "Username",
-                        "name": "username",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    {
-                        "description": "Password",
-                        "name": "password",
+                        "description": "User ID",
+                        "name": "user_id",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "type": "string"
+                            "type": "integer"
                         }
                     }
                 ],
@@ -847,49 +1278,4821 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_user_force_password_reset.Response"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/response.Response"
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
                         }
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "internal_http-server_handlers_actor-movie_delete.Response": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "type": "string"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/roles/grant": {
+            "post": {
+                "description": "Grant a role to a user. Takes effect when the user next signs in or refreshes the token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Grant role",
+                "parameters": [
+                    {
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_user_grant_role.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/roles/revoke": {
+            "post": {
+                "description": "Revoke a role from a user. Takes effect at once, the user's access tokens are revoked. The last enabled admin keeps the admin role",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin"
+                ],
+                "summary": "Revoke role",
+                "parameters": [
+                    {
+                        "description": "User ID",
+                        "name": "user_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Role",
+                        "name": "role",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_admin_user_revoke_role.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/actors": {
+            "get": {
+                "description": "Get all actors. Filters combine with AND. sort takes comma-separated fields among name, birthdate,\nmovie_count and, with a name search, relevance, each prefixed with - for descending, e.g. -movie_count,name.\nActors are by actor_id by default, or by relevance with a name search",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor"
+                ],
+                "summary": "Get all actors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sort keys, e.g. -movie_count,name",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "male",
+                            "female",
+                            "other",
+                            "unspecified"
+                        ],
+                        "type": "string",
+                        "description": "Gender",
+                        "name": "gender",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Born in this year or later",
+                        "name": "born_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Born in this year or earlier",
+                        "name": "born_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether the actor is credited in a movie",
+                        "name": "has_movies",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start of or a misspelling of the name or an alias",
+                        "name": "name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor_all.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new actor by name, gender (male, female, other or unspecified) and birthdate, optionally with biography, birthplace, nationality (ISO 3166-1 alpha-2), death_date, aliases and external_ids (imdb, wikidata, tmdb)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor"
+                ],
+                "summary": "Create a new actor",
+                "parameters": [
+                    {
+                        "description": "Name",
+                        "name": "name",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Gender",
+                        "name": "gender",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Birthdate",
+                        "name": "birthdate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Biography",
+                        "name": "biography",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Birthplace",
+                        "name": "birthplace",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Nationality, e.g. US",
+                        "name": "nationality",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Death date",
+                        "name": "death_date",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Alternative names",
+                        "name": "aliases",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Ids in other databases by source: imdb, wikidata or tmdb",
+                        "name": "external_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor_save.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/actors/{actor_id}": {
+            "get": {
+                "description": "Get an actor by actor_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor"
+                ],
+                "summary": "Get an actor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "actor_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor_search.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the actor"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cached copy is current"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move an actor to the trash by actor_id. Admins can restore them until they are purged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor"
+                ],
+                "summary": "Delete an actor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "actor_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the deletion is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor_delete.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update an actor by actor_id. Fields left out are kept, all given fields are changed at once. Given aliases and external_ids replace the current ones, empty biography, birthplace, nationality and death_date clear them. PATCH also takes application/merge-patch+json bodies, where null clears any field but name, gender and birthdate",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor"
+                ],
+                "summary": "Update an actor",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "actor_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Name",
+                        "name": "name",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Gender: male, female, other or unspecified",
+                        "name": "gender",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Birthdate",
+                        "name": "birthdate",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Biography",
+                        "name": "biography",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Birthplace",
+                        "name": "birthplace",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Nationality, e.g. FR",
+                        "name": "nationality",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Death date",
+                        "name": "death_date",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Other names",
+                        "name": "aliases",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Ids by source: imdb, wikidata or tmdb",
+                        "name": "external_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor_update.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the actor"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lists": {
+            "get": {
+                "description": "Get the watchlist, favourites, watched and custom lists of the caller",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Get own lists",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_all.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a custom list. List names are unique per user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Create a list",
+                "parameters": [
+                    {
+                        "description": "Name of the list",
+                        "name": "name",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_save.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lists/{list_id}": {
+            "delete": {
+                "description": "Delete an own custom list with its items. The watchlist, favourites and watched lists cannot be deleted",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Delete a list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_delete.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lists/{list_id}/items": {
+            "get": {
+                "description": "Get the movies of an own list in list order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Get list items",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_items.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Put the movies of an own list in the given order. movie_ids must name every movie of the list once",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Reorder a list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Movie IDs in the new order",
+                        "name": "movie_ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_reorder.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Append a movie to an own list. A movie already on the list keeps its position. watched_at is accepted by the watched list only and defaults to today",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Add a movie to a list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Day the movie was watched, YYYY-MM-DD",
+                        "name": "watched_at",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_add_item.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lists/{list_id}/items/{movie_id}": {
+            "delete": {
+                "description": "Remove a movie from an own list",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Remove a movie from a list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_remove_item.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/lists/{list_id}/share": {
+            "post": {
+                "description": "Share an own list read-only with anyone who has the link /api/v1/shared/lists/{share_token}. Sharing a shared list returns its token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Share a list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_share.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Revoke the share link of an own list. Sharing it again gives a new link",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Stop sharing a list",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "List ID",
+                        "name": "list_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_unshare.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/movies": {
+            "get": {
+                "description": "Get all movies. Repeated or comma-separated genre, country and tag values must all match.\nFilters combine with AND. sort takes comma-separated fields among title, release_date, rating,\ncommunity_score and runtime, each prefixed with - for descending, e.g. -rating,title.\nsort_by is the former single-field form and cannot be combined with sort. The default is -rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Get all movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sort keys, e.g. -rating,title",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated, use sort. Sort by: title, release_date, rating or community_score, with _asc or _desc",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Genre, e.g. drama",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Production country, e.g. FR",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Original language, e.g. fr",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Age certification, e.g. PG-13",
+                        "name": "certification",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Released in this year or later",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Released in this year or earlier",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum rating, 0 to 10",
+                        "name": "rating_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum rating, 0 to 10",
+                        "name": "rating_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Actor id",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Whether any (default) or all of the actors must star",
+                        "name": "actor_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text the title or description contains",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_all.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Save movie by title, description, release_date, rating and actors_ids, optionally with runtime in minutes, original_language (ISO 639-1), certification, genres, countries (ISO 3166-1 alpha-2) and tags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Save movie",
+                "parameters": [
+                    {
+                        "description": "Runtime, minutes",
+                        "name": "runtime",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Original language, e.g. fr",
+                        "name": "original_language",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Age certification, e.g. PG-13 or 16+",
+                        "name": "certification",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Genres, e.g. drama",
+                        "name": "genres",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Production countries, e.g. FR",
+                        "name": "countries",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Tags",
+                        "name": "tags",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_save.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/movies/search": {
+            "get": {
+                "description": "Full-text search over titles, descriptions and actor names, tolerant to typos, most relevant first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Search a movie by part",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part",
+                        "name": "part",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_search_by_part.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/movies/{movie_id}": {
+            "get": {
+                "description": "Search a movie by movie_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Search a movie by movie_id",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_search_by_id.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version, community score and the caller's list flags of the movie"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cached copy is current"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a movie to the trash by movie_id. Admins can restore it with its cast until it is purged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Delete a movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the deletion is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_delete.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update movie by movie_id. Fields left out are kept, all given fields are changed at once. Given genres, countries and tags replace the current ones, zero runtime, empty original_language and certification clear them. PATCH also takes application/merge-patch+json bodies, where null clears any field but title, release_date and rating",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Update movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Title",
+                        "name": "title",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Description",
+                        "name": "description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Release Date",
+                        "name": "release_date",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Rating",
+                        "name": "rating",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Runtime, minutes",
+                        "name": "runtime",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Original language, e.g. fr",
+                        "name": "original_language",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Age certification, e.g. PG-13 or 16+",
+                        "name": "certification",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Genres, e.g. drama",
+                        "name": "genres",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Production countries, e.g. FR",
+                        "name": "countries",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Tags",
+                        "name": "tags",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_update.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/movies/{movie_id}/actors": {
+            "post": {
+                "description": "Add cast and crew credits to movie by movie_id. actors_ids are added as actors, actor_id with credit_type, character and billing_order. Adding a credit again only changes the given character and billing_order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor-Movie"
+                ],
+                "summary": "Add actors to movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Credit type of actor_id: actor, director, writer, producer or composer",
+                        "name": "credit_type",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Character played by actor_id",
+                        "name": "character",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Billing order of actor_id, from 1",
+                        "name": "billing_order",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Actors IDs",
+                        "name": "actors_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Credits",
+                        "name": "credits",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_http-server_handlers_actor-movie_save.Credit"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor-movie_save.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete actors from movie by movie_id and actors_ids",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor-Movie"
+                ],
+                "summary": "Delete actors from movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Actors IDs",
+                        "name": "actors_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor-movie_delete.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/movies/{movie_id}/actors/{actor_id}": {
+            "put": {
+                "description": "Add cast and crew credits to movie by movie_id. actors_ids are added as actors, actor_id with credit_type, character and billing_order. Adding a credit again only changes the given character and billing_order",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor-Movie"
+                ],
+                "summary": "Add actors to movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "actor_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Credit type of actor_id: actor, director, writer, producer or composer",
+                        "name": "credit_type",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Character played by actor_id",
+                        "name": "character",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Billing order of actor_id, from 1",
+                        "name": "billing_order",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Actors IDs",
+                        "name": "actors_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Credits",
+                        "name": "credits",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/internal_http-server_handlers_actor-movie_save.Credit"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor-movie_save.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete actors from movie by movie_id and actors_ids",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Actor-Movie"
+                ],
+                "summary": "Delete actors from movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Actor ID",
+                        "name": "actor_id",
+                        "in": "path"
+                    },
+                    {
+                        "description": "Actors IDs",
+                        "name": "actors_ids",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "integer"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_actor-movie_delete.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/movies/{movie_id}/reviews": {
+            "get": {
+                "description": "List the published reviews of a movie, most recent first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "List reviews of a movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_review_list.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Score a movie from 1 to 10, optionally with a text. Every user reviews a movie once and then edits the review",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Review a movie",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Movie ID",
+                        "name": "movie_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Score, 1 to 10",
+                        "name": "score",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Text of the review",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_review_save.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/reviews/{review_id}": {
+            "delete": {
+                "description": "Delete an own review by review_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Delete a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_review_delete.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Update an own review by review_id. Fields left out are kept, an empty or null body removes the text. PATCH also takes application/merge-patch+json bodies",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Review"
+                ],
+                "summary": "Update a review",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Review ID",
+                        "name": "review_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Score, 1 to 10",
+                        "name": "score",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Text of the review",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_review_update.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/shared/lists/{share_token}": {
+            "get": {
+                "description": "Get the movies of a list shared by its owner. No sign-in is needed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "List"
+                ],
+                "summary": "Get a shared list",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share token of the list",
+                        "name": "share_token",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_list_shared.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/export": {
+            "get": {
+                "description": "Stream every actor with the titles of their movies and then every movie with the names of its cast, in the format POST /import reads back. Movies and actors in the trash are left out",
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "Catalogue"
+                ],
+                "summary": "Export the catalogue",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "json (default), ndjson or csv",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/film_library_internal_lib_catalogue.Record"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/import": {
+            "post": {
+                "description": "Create or update movies and actors from a CSV, NDJSON or JSON array body. Actors are matched by name and birthdate, movies by title and release date, cast members by name, so actors should come before the movies they play in. CSV takes a header row naming the columns type, name, gender, birthdate, title, description, release_date, rating and cast, with cast names separated by |. Rows that fail are listed in errors and the others are imported",
+                "consumes": [
+                    "text/csv",
+                    "application/x-ndjson",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Catalogue"
+                ],
+                "summary": "Import movies and actors",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv, ndjson or json, taken from Content-Type by default",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_catalogue_bulk_import.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_catalogue_bulk_import.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_catalogue_bulk_import.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/all": {
+            "get": {
+                "description": "Get all movies. Repeated or comma-separated genre, country and tag values must all match.\nFilters combine with AND. sort takes comma-separated fields among title, release_date, rating,\ncommunity_score and runtime, each prefixed with - for descending, e.g. -rating,title.\nsort_by is the former single-field form and cannot be combined with sort. The default is -rating",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Get all movies",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Sort keys, e.g. -rating,title",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Deprecated, use sort. Sort by: title, release_date, rating or community_score, with _asc or _desc",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Genre, e.g. drama",
+                        "name": "genre",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Production country, e.g. FR",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Tag",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Original language, e.g. fr",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Age certification, e.g. PG-13",
+                        "name": "certification",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Released in this year or later",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Released in this year or earlier",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum rating, 0 to 10",
+                        "name": "rating_min",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum rating, 0 to 10",
+                        "name": "rating_max",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "integer"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Actor id",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "description": "Whether any (default) or all of the actors must star",
+                        "name": "actor_match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Text the title or description contains",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_all.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/delete": {
+            "delete": {
+                "description": "Move a movie to the trash by movie_id. Admins can restore it with its cast until it is purged",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Delete a movie",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag the deletion is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_delete.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/save": {
+            "post": {
+                "description": "Save movie by title, description, release_date, rating and actors_ids, optionally with runtime in minutes, original_language (ISO 639-1), certification, genres, countries (ISO 3166-1 alpha-2) and tags",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Save movie",
+                "parameters": [
+                    {
+                        "description": "Runtime, minutes",
+                        "name": "runtime",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Original language, e.g. fr",
+                        "name": "original_language",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Age certification, e.g. PG-13 or 16+",
+                        "name": "certification",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Genres, e.g. drama",
+                        "name": "genres",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Production countries, e.g. FR",
+                        "name": "countries",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Tags",
+                        "name": "tags",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_save.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/search_by_id": {
+            "get": {
+                "description": "Search a movie by movie_id",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Search a movie by movie_id",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ETag of a cached copy",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_search_by_id.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version, community score and the caller's list flags of the movie"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "Cached copy is current"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/search_by_part": {
+            "get": {
+                "description": "Full-text search over titles, descriptions and actor names, tolerant to typos, most relevant first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Search a movie by part",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part",
+                        "name": "part",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size, 50 by default",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Return the total count",
+                        "name": "with_total",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_search_by_part.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/movie/update": {
+            "post": {
+                "description": "Update movie by movie_id. Fields left out are kept, all given fields are changed at once. Given genres, countries and tags replace the current ones, zero runtime, empty original_language and certification clear them. PATCH also takes application/merge-patch+json bodies, where null clears any field but title, release_date and rating",
+                "consumes": [
+                    "application/json",
+                    "application/merge-patch+json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Movie"
+                ],
+                "summary": "Update movie",
+                "parameters": [
+                    {
+                        "description": "Title",
+                        "name": "title",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Description",
+                        "name": "description",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Release Date",
+                        "name": "release_date",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Rating",
+                        "name": "rating",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Runtime, minutes",
+                        "name": "runtime",
+                        "in": "body",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    {
+                        "description": "Original language, e.g. fr",
+                        "name": "original_language",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Age certification, e.g. PG-13 or 16+",
+                        "name": "certification",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Genres, e.g. drama",
+                        "name": "genres",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Production countries, e.g. FR",
+                        "name": "countries",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "description": "Tags",
+                        "name": "tags",
+                        "in": "body",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag the change is based on",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_movie_update.Response"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the movie"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/password/change": {
+            "post": {
+                "description": "Change the password by username and current password. Also clears a password reset forced by an admin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Change password",
+                "parameters": [
+                    {
+                        "description": "Username",
+                        "name": "username",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Current password",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "New password",
+                        "name": "new_password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_user_change_password.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/signin": {
+            "post": {
+                "description": "Sign in a user by username and password. Returns a short-lived access token and a refresh token for /token/refresh",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign in a user",
+                "parameters": [
+                    {
+                        "description": "Username",
+                        "name": "username",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Password",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_user_signin.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/signout": {
+            "post": {
+                "description": "Revoke the access token of the request and, if refresh_token is given, every refresh token issued for the same signin",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Sign out",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_user_signout.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/signup": {
+            "post": {
+                "description": "Create a new user by username and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Create a new user",
+                "parameters": [
+                    {
+                        "description": "Username",
+                        "name": "username",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    {
+                        "description": "Password",
+                        "name": "password",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        },
+        "/token/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access token and a new refresh token. Each refresh token can be used once. The new access token carries the current roles of the user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/internal_http-server_handlers_token_refresh.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/film_library_internal_lib_api_response.Response"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "film_library_internal_lib_api_response.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_lib_api_response.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_lib_catalogue.Credit": {
+            "type": "object",
+            "properties": {
+                "billing_order": {
+                    "type": "integer"
+                },
+                "character": {
+                    "type": "string"
+                },
+                "credit_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_lib_catalogue.Record": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "biography": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string"
+                },
+                "birthplace": {
+                    "type": "string"
+                },
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "certification": {
+                    "type": "string"
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_catalogue.Credit"
+                    }
+                },
+                "death_date": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "external_ids": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "movies": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "original_language": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_lib_catalogue.RowError": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "row": {
+                    "type": "integer"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.Actor": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "age": {
+                    "type": "integer"
+                },
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "biography": {
+                    "type": "string"
+                },
+                "birthdate": {
+                    "type": "string"
+                },
+                "birthplace": {
+                    "type": "string"
+                },
+                "death_date": {
+                    "type": "string"
+                },
+                "external_ids": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "gender": {
+                    "type": "string"
+                },
+                "movies": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "nationality": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.AuditEvent": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "type": "object"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "event_id": {
+                    "type": "integer"
+                },
+                "occurred_at": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.Credit": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "billing_order": {
+                    "type": "integer"
+                },
+                "character": {
+                    "type": "string"
+                },
+                "credit_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.List": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "item_count": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "list_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "share_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.ListFlags": {
+            "type": "object",
+            "properties": {
+                "favourite": {
+                    "type": "boolean"
+                },
+                "list_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "watched": {
+                    "type": "boolean"
+                },
+                "watched_at": {
+                    "type": "string"
+                },
+                "watchlist": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.ListItem": {
+            "type": "object",
+            "properties": {
+                "added_at": {
+                    "type": "string"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "watched_at": {
+                    "description": "WatchedAt is the day the movie was watched, on the watched list only",
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.Movie": {
+            "type": "object",
+            "properties": {
+                "actors": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.Credit"
+                    }
+                },
+                "certification": {
+                    "type": "string"
+                },
+                "community_score": {
+                    "description": "CommunityScore is the average score of the users' reviews, nil\nuntil the first one",
+                    "type": "number"
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.Credit"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "lists": {
+                    "description": "Lists is set for a signed-in caller only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/film_library_internal_storage_postgres.ListFlags"
+                        }
+                    ]
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "original_language": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "vote_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.MovieSearchResult": {
+            "type": "object",
+            "properties": {
+                "actors": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "cast": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.Credit"
+                    }
+                },
+                "certification": {
+                    "type": "string"
+                },
+                "community_score": {
+                    "description": "CommunityScore is the average score of the users' reviews, nil\nuntil the first one",
+                    "type": "number"
+                },
+                "countries": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "crew": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.Credit"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "headline": {
+                    "type": "string"
+                },
+                "lists": {
+                    "description": "Lists is set for a signed-in caller only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/film_library_internal_storage_postgres.ListFlags"
+                        }
+                    ]
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "original_language": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "rating": {
+                    "type": "integer"
+                },
+                "release_date": {
+                    "type": "string"
+                },
+                "runtime": {
+                    "type": "integer"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                },
+                "vote_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.Review": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "movie_id": {
+                    "type": "integer"
+                },
+                "review_id": {
+                    "type": "integer"
+                },
+                "score": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.TrashItem": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "type": "string"
+                },
+                "entity_id": {
+                    "type": "integer"
+                },
+                "entity_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "film_library_internal_storage_postgres.User": {
+            "type": "object",
+            "properties": {
+                "disabled": {
+                    "type": "boolean"
+                },
+                "password_reset_required": {
+                    "type": "boolean"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_actor-movie_delete.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_actor-movie_save.Credit": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "billing_order": {
+                    "type": "integer"
+                },
+                "character": {
+                    "type": "string"
+                },
+                "credit_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_actor-movie_save.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_actor_all.Response": {
+            "type": "object",
+            "properties": {
+                "actors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.Actor"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http-server_handlers_actor_delete.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_actor_save.Response": {
+            "type": "object",
+            "properties": {
+                "actor_id": {
+                    "type": "integer"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_actor_search.Response": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "$ref": "#/definitions/film_library_internal_storage_postgres.Actor"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_actor_update.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_audit_list.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "events": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.AuditEvent"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_review_list.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.Review"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_review_moderate.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_trash_list.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.TrashItem"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_trash_restore.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_user_disable.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_user_force_password_reset.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_user_grant_role.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_user_list.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.User"
+                    }
+                }
+            }
+        },
+        "internal_http-server_handlers_admin_user_revoke_role.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_catalogue_bulk_import.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_catalogue.RowError"
+                    }
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http-server_handlers_list_add_item.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_list_all.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "lists": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.List"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_list_delete.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
                 },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "internal_http-server_handlers_actor-movie_save.Response": {
+        "internal_http-server_handlers_list_items.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.ListItem"
+                    }
+                },
+                "list": {
+                    "$ref": "#/definitions/film_library_internal_storage_postgres.List"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "internal_http-server_handlers_actor_all.Response": {
+        "internal_http-server_handlers_list_remove_item.Response": {
             "type": "object",
             "properties": {
-                "actors": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/postgres.Actor"
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
                     }
                 },
                 "error": {
@@ -900,9 +6103,18 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_http-server_handlers_actor_delete.Response": {
+        "internal_http-server_handlers_list_reorder.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
@@ -911,23 +6123,99 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_http-server_handlers_actor_save.Response": {
+        "internal_http-server_handlers_list_save.Response": {
             "type": "object",
             "properties": {
-                "actor_id": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "list_id": {
                     "type": "integer"
                 },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_list_share.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
+                "share_token": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "internal_http-server_handlers_actor_update.Response": {
+        "internal_http-server_handlers_list_shared.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.ListItem"
+                    }
+                },
+                "list": {
+                    "$ref": "#/definitions/film_library_internal_storage_postgres.List"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "internal_http-server_handlers_list_unshare.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
@@ -939,23 +6227,47 @@ const docTemplate = `{
         "internal_http-server_handlers_movie_all.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
                 "movies": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/postgres.Movie"
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.Movie"
                     }
                 },
+                "next_cursor": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
         "internal_http-server_handlers_movie_delete.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
@@ -967,6 +6279,15 @@ const docTemplate = `{
         "internal_http-server_handlers_movie_save.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
@@ -978,128 +6299,246 @@ const docTemplate = `{
                 }
             }
         },
-        "internal_http-server_handlers_movie_update.Response": {
+        "internal_http-server_handlers_movie_search_by_id.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
+                "movie": {
+                    "$ref": "#/definitions/film_library_internal_storage_postgres.Movie"
+                },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "postgres.Actor": {
+        "internal_http-server_handlers_movie_search_by_part.Response": {
             "type": "object",
             "properties": {
-                "actor_id": {
-                    "type": "integer"
-                },
-                "birthdate": {
+                "code": {
                     "type": "string"
                 },
-                "gender": {
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
                     "type": "string"
                 },
                 "movies": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.MovieSearchResult"
                     }
                 },
-                "name": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "postgres.Movie": {
+        "internal_http-server_handlers_movie_update.Response": {
             "type": "object",
             "properties": {
-                "actors": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
                     }
                 },
-                "description": {
+                "error": {
                     "type": "string"
                 },
-                "movie_id": {
-                    "type": "integer"
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_review_delete.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
                 },
-                "rating": {
-                    "type": "integer"
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
                 },
-                "release_date": {
+                "error": {
                     "type": "string"
                 },
-                "title": {
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "response.Response": {
+        "internal_http-server_handlers_review_list.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "reviews": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_storage_postgres.Review"
+                    }
+                },
                 "status": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "search.Response": {
+        "internal_http-server_handlers_review_save.Response": {
             "type": "object",
             "properties": {
-                "actor": {
-                    "$ref": "#/definitions/postgres.Actor"
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
                 },
                 "error": {
                     "type": "string"
                 },
+                "review_id": {
+                    "type": "integer"
+                },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "search_by_id.Response": {
+        "internal_http-server_handlers_review_update.Response": {
             "type": "object",
             "properties": {
-                "error": {
+                "code": {
                     "type": "string"
                 },
-                "movie": {
-                    "$ref": "#/definitions/postgres.Movie"
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
                 },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "search_by_part.Response": {
+        "internal_http-server_handlers_token_refresh.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
-                "movies": {
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "internal_http-server_handlers_user_change_password.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/postgres.Movie"
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
                     }
                 },
+                "error": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "signin.Response": {
+        "internal_http-server_handlers_user_signin.Response": {
             "type": "object",
             "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
                 "error": {
                     "type": "string"
                 },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
                 "status": {
                     "type": "string"
                 },
@@ -1107,6 +6546,26 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "internal_http-server_handlers_user_signout.Response": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/film_library_internal_lib_api_response.FieldError"
+                    }
+                },
+                "error": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         }
     }
 }`
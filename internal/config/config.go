@@ -12,13 +12,38 @@ type Config struct {
 	Env        string `yaml:"env" env-default:"local"`
 	Storage    string `yaml:"storage" env-required:"true"`
 	HTTPServer `yaml:"http_server"`
+	Admin      `yaml:"admin"`
+	Search     `yaml:"search"`
+	Trash      `yaml:"trash"`
 }
 
 type HTTPServer struct {
-	Address     string        `yaml:"address" env-default:"localhost:8080"`
-	Timeout     time.Duration `yaml:"timeout" env-default:"4s"`
-	IdleTimeout time.Duration `yaml:"idleTimeout" env-default:"60s"`
-	JWTSecret   string        `yaml:"jwt_secret" env-required:"true"`
+	Address         string        `yaml:"address" env-default:"localhost:8080"`
+	Timeout         time.Duration `yaml:"timeout" env-default:"4s"`
+	IdleTimeout     time.Duration `yaml:"idleTimeout" env-default:"60s"`
+	JWTSecret       string        `yaml:"jwt_secret" env-required:"true"`
+	JWTIssuer       string        `yaml:"jwt_issuer" env-default:"film_library"`
+	AccessTokenTTL  time.Duration `yaml:"access_token_ttl" env-default:"15m"`
+	RefreshTokenTTL time.Duration `yaml:"refresh_token_ttl" env-default:"720h"`
+}
+
+type Admin struct {
+	Username string `yaml:"username" env:"ADMIN_USERNAME"`
+	// Password is only read from the environment, so that it never ends up
+	// in a config file. The admin is not seeded without it.
+	Password string `yaml:"-" env:"ADMIN_PASSWORD"`
+}
+
+type Search struct {
+	// Language is a Postgres text search configuration, e.g. "english".
+	Language string `yaml:"language" env:"SEARCH_LANGUAGE" env-default:"simple"`
+}
+
+type Trash struct {
+	// RetentionDays is how long deleted movies and actors can be restored
+	// before they are purged, 0 keeps them forever.
+	RetentionDays int           `yaml:"retention_days" env:"TRASH_RETENTION_DAYS" env-default:"30"`
+	PurgeInterval time.Duration `yaml:"purge_interval" env:"TRASH_PURGE_INTERVAL" env-default:"1h"`
 }
 
 func MustLoad() *Config {
@@ -1,6 +1,8 @@
 package delete
 
 import (
+	"context"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
 	"github.com/go-chi/chi/v5/middleware"
@@ -11,6 +13,7 @@ import (
 
 type Request struct {
 	MovieId   int   `json:"movie_id"`
+	ActorId   int   `json:"actor_id,omitempty"`
 	ActorsIds []int `json:"actors_ids"`
 }
 
@@ -20,7 +23,7 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ActorMovieDeleter
 type ActorMovieDeleter interface {
-	DeleteActorMovie(movieId int, actorsIds []int) error
+	DeleteActorMovie(ctx context.Context, movieId int, actorsIds []int) error
 }
 
 // @Summary		Delete actors from movie
@@ -29,12 +32,16 @@ type ActorMovieDeleter interface {
 // @Accept			json
 // @Produce		json
 // @Param			movie_id	path		int		true	"Movie ID"
-// @Param			actors_ids	path		[]int	true	"Actors IDs"
+// @Param			actor_id	path		int		false	"Actor ID"
+// @Param			actors_ids	body		[]int	false	"Actors IDs"
 // @Success		200			{object}	Response
 // @Failure		400			{object}	response.Response
 // @Failure		401			{object}	response.Response
 // @Failure		403			{object}	response.Response
+// @Failure		500			{object}	response.Response
 // @Router			/actor-movie/delete [delete]
+// @Router			/api/v1/movies/{movie_id}/actors [delete]
+// @Router			/api/v1/movies/{movie_id}/actors/{actor_id} [delete]
 func New(log *slog.Logger, actorMovieDeleter ActorMovieDeleter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actor-movie.delete.New"
@@ -46,11 +53,11 @@ func New(log *slog.Logger, actorMovieDeleter ActorMovieDeleter) http.HandlerFunc
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
@@ -60,16 +67,21 @@ func New(log *slog.Logger, actorMovieDeleter ActorMovieDeleter) http.HandlerFunc
 		if ok, field, msg := validateRequest(req); !ok {
 			log.Error("invalid request", field)
 
-			render.JSON(w, r, response.Error(msg))
+			response.ValidationFailed(w, r, field.Value.String(), msg)
 
 			return
 		}
 
-		err = actorMovieDeleter.DeleteActorMovie(req.MovieId, req.ActorsIds)
+		// A single actor can be given as a path parameter instead of the list.
+		if req.ActorId != 0 {
+			req.ActorsIds = append(req.ActorsIds, req.ActorId)
+		}
+
+		err = actorMovieDeleter.DeleteActorMovie(r.Context(), req.MovieId, req.ActorsIds)
 		if err != nil {
 			log.Error("failed to delete actor-movie", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to delete actor-movie"))
+			response.FromError(w, r, err, "failed to delete actor-movie")
 
 			return
 		}
@@ -84,6 +96,9 @@ func validateRequest(req Request) (bool, slog.Attr, string) {
 	if req.MovieId < 1 {
 		return false, slog.String("field", "movie_id"), "field movie_id is not valid"
 	}
+	if req.ActorId < 0 {
+		return false, slog.String("field", "actor_id"), "field actor_id is not valid"
+	}
 	for _, id := range req.ActorsIds {
 		if id < 1 {
 			return false, slog.String("field", "actors_ids"), "field actors_ids is not valid"
@@ -10,6 +10,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/actor-movie/delete"
@@ -23,30 +25,35 @@ func TestSaveHandler(t *testing.T) {
 		movieId   int
 		actorsIds []int
 		respError string
+		status    int
 		mockError error
 	}{
 		{
 			name:      "Success",
 			movieId:   1,
 			actorsIds: []int{1, 2},
+			status:    http.StatusOK,
 		},
 		{
 			name:      "Invalid movie_id",
 			movieId:   -1,
 			actorsIds: []int{1, 2},
 			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "Invalid actors_ids",
 			movieId:   1,
 			actorsIds: []int{1, -2},
 			respError: "field actors_ids is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "SaveActorMovie Error",
 			movieId:   1,
 			actorsIds: []int{1, 2},
 			respError: "failed to delete actor-movie",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -60,7 +67,7 @@ func TestSaveHandler(t *testing.T) {
 			actorMovieDeleterMock := mocks.NewActorMovieDeleter(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				actorMovieDeleterMock.On("DeleteActorMovie", tc.movieId, tc.actorsIds).
+				actorMovieDeleterMock.On("DeleteActorMovie", mock.Anything, tc.movieId, tc.actorsIds).
 					Return(tc.mockError).
 					Once()
 			}
@@ -77,7 +84,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -89,3 +96,70 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestPathParams(t *testing.T) {
+	cases := []struct {
+		name      string
+		url       string
+		body      string
+		actorsIds []int
+		respError string
+		status    int
+	}{
+		{
+			name:      "Single actor",
+			url:       "/api/v1/movies/1/actors/3",
+			actorsIds: []int{3},
+			status:    http.StatusOK,
+		},
+		{
+			name:      "Actors from body",
+			url:       "/api/v1/movies/1/actors",
+			body:      `{"actors_ids": [2, 3]}`,
+			actorsIds: []int{2, 3},
+			status:    http.StatusOK,
+		},
+		{
+			name:      "Invalid actor_id",
+			url:       "/api/v1/movies/1/actors/abc",
+			respError: "failed to decode request",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorMovieDeleterMock := mocks.NewActorMovieDeleter(t)
+
+			if tc.respError == "" {
+				actorMovieDeleterMock.On("DeleteActorMovie", mock.Anything, 1, tc.actorsIds).
+					Return(nil).
+					Once()
+			}
+
+			handler := delete.New(slogdiscard.NewDiscardLogger(), actorMovieDeleterMock)
+
+			router := chi.NewRouter()
+			router.Method(http.MethodDelete, "/api/v1/movies/{movie_id}/actors", handler)
+			router.Method(http.MethodDelete, "/api/v1/movies/{movie_id}/actors/{actor_id}", handler)
+
+			req, err := http.NewRequest(http.MethodDelete, tc.url, strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp delete.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
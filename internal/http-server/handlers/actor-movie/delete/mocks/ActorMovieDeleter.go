@@ -2,24 +2,28 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // ActorMovieDeleter is an autogenerated mock type for the ActorMovieDeleter type
 type ActorMovieDeleter struct {
 	mock.Mock
 }
 
-// DeleteActorMovie provides a mock function with given fields: movieId, actorsIds
-func (_m *ActorMovieDeleter) DeleteActorMovie(movieId int, actorsIds []int) error {
-	ret := _m.Called(movieId, actorsIds)
+// DeleteActorMovie provides a mock function with given fields: ctx, movieId, actorsIds
+func (_m *ActorMovieDeleter) DeleteActorMovie(ctx context.Context, movieId int, actorsIds []int) error {
+	ret := _m.Called(ctx, movieId, actorsIds)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteActorMovie")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, []int) error); ok {
-		r0 = rf(movieId, actorsIds)
+	if rf, ok := ret.Get(0).(func(context.Context, int, []int) error); ok {
+		r0 = rf(ctx, movieId, actorsIds)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2,24 +2,30 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	postgres "film_library/internal/storage/postgres"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // ActorMovieSaver is an autogenerated mock type for the ActorMovieSaver type
 type ActorMovieSaver struct {
 	mock.Mock
 }
 
-// SaveActorMovie provides a mock function with given fields: movieId, actorsIds
-func (_m *ActorMovieSaver) SaveActorMovie(movieId int, actorsIds []int) error {
-	ret := _m.Called(movieId, actorsIds)
+// SaveActorMovie provides a mock function with given fields: ctx, movieId, credits
+func (_m *ActorMovieSaver) SaveActorMovie(ctx context.Context, movieId int, credits []postgres.Credit) error {
+	ret := _m.Called(ctx, movieId, credits)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveActorMovie")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int, []int) error); ok {
-		r0 = rf(movieId, actorsIds)
+	if rf, ok := ret.Get(0).(func(context.Context, int, []postgres.Credit) error); ok {
+		r0 = rf(ctx, movieId, credits)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,40 +1,71 @@
 package save
 
 import (
+	"context"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage/postgres"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"slices"
 )
 
 type Request struct {
-	MovieId   int   `json:"movie_id"`
-	ActorsIds []int `json:"actors_ids"`
+	MovieId      int      `json:"movie_id"`
+	ActorId      int      `json:"actor_id,omitempty"`
+	CreditType   string   `json:"credit_type,omitempty"`
+	Character    string   `json:"character,omitempty"`
+	BillingOrder int      `json:"billing_order,omitempty"`
+	ActorsIds    []int    `json:"actors_ids"`
+	Credits      []Credit `json:"credits"`
+}
+
+// Credit is a credit of the credits list, credit_type is actor by default.
+type Credit struct {
+	ActorId      int    `json:"actor_id"`
+	CreditType   string `json:"credit_type,omitempty"`
+	Character    string `json:"character,omitempty"`
+	BillingOrder int    `json:"billing_order,omitempty"`
 }
 
 type Response struct {
 	response.Response
 }
 
+// single is the credit given by actor_id.
+func (req Request) single() Credit {
+	return Credit{ActorId: req.ActorId, CreditType: req.CreditType, Character: req.Character, BillingOrder: req.BillingOrder}
+}
+
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ActorMovieSaver
 type ActorMovieSaver interface {
-	SaveActorMovie(movieId int, actorsIds []int) error
+	SaveActorMovie(ctx context.Context, movieId int, credits []postgres.Credit) error
 }
 
 // @Summary		Add actors to movie
-// @Description	Add actors to movie by movie_id and actors_ids
+// @Description	Add cast and crew credits to movie by movie_id. actors_ids are added as actors, actor_id with credit_type, character and billing_order. Adding a credit again only changes the given character and billing_order
 // @Tags			Actor-Movie
 // @Accept			json
 // @Produce		json
-// @Param			movie_id	path		int		true	"Movie ID"
-// @Param			actors_ids	path		[]int	true	"Actors IDs"
-// @Success		200			{object}	Response
-// @Failure		400			{object}	response.Response
-// @Failure		401			{object}	response.Response
-// @Failure		403			{object}	response.Response
+// @Param			movie_id		path		int			true	"Movie ID"
+// @Param			actor_id		path		int			false	"Actor ID"
+// @Param			credit_type		body		string		false	"Credit type of actor_id: actor, director, writer, producer or composer"
+// @Param			character		body		string		false	"Character played by actor_id"
+// @Param			billing_order	body		int			false	"Billing order of actor_id, from 1"
+// @Param			actors_ids		body		[]int		false	"Actors IDs"
+// @Param			credits			body		[]Credit	false	"Credits"
+// @Success		200				{object}	Response
+// @Failure		400				{object}	response.Response
+// @Failure		401				{object}	response.Response
+// @Failure		403				{object}	response.Response
+// @Failure		409				{object}	response.Response
+// @Failure		500				{object}	response.Response
 // @Router			/actor-movie/save [post]
+// @Router			/api/v1/movies/{movie_id}/actors [post]
+// @Router			/api/v1/movies/{movie_id}/actors/{actor_id} [put]
 func New(log *slog.Logger, actorMovieSaver ActorMovieSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actor-movie.save.New"
@@ -46,11 +77,11 @@ func New(log *slog.Logger, actorMovieSaver ActorMovieSaver) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
@@ -60,21 +91,21 @@ func New(log *slog.Logger, actorMovieSaver ActorMovieSaver) http.HandlerFunc {
 		if ok, field, msg := validateRequest(req); !ok {
 			log.Error("invalid request", field)
 
-			render.JSON(w, r, response.Error(msg))
+			response.ValidationFailed(w, r, field.Value.String(), msg)
 
 			return
 		}
 
-		err = actorMovieSaver.SaveActorMovie(req.MovieId, req.ActorsIds)
+		err = actorMovieSaver.SaveActorMovie(r.Context(), req.MovieId, credits(req))
 		if err != nil {
 			log.Error("failed to save actor-movie", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to save actor-movie"))
+			response.FromError(w, r, err, "failed to save actor-movie")
 
 			return
 		}
 
-		log.Info("actors added to movie", slog.Int("movie_id", req.MovieId))
+		log.Info("credits added to movie", slog.Int("movie_id", req.MovieId))
 
 		render.JSON(w, r, Response{response.OK()})
 	}
@@ -84,10 +115,66 @@ func validateRequest(req Request) (bool, slog.Attr, string) {
 	if req.MovieId < 1 {
 		return false, slog.String("field", "movie_id"), "field movie_id is not valid"
 	}
+	if req.ActorId < 0 {
+		return false, slog.String("field", "actor_id"), "field actor_id is not valid"
+	}
+	if req.ActorId == 0 && (req.CreditType != "" || req.Character != "" || req.BillingOrder != 0) {
+		return false, slog.String("field", "actor_id"), "field actor_id is not valid"
+	}
+	if ok, field := ValidateCredit(req.single()); !ok {
+		return false, slog.String("field", field), "field " + field + " is not valid"
+	}
 	for _, id := range req.ActorsIds {
 		if id < 1 {
 			return false, slog.String("field", "actors_ids"), "field actors_ids is not valid"
 		}
 	}
+	for _, credit := range req.Credits {
+		if ok, _ := ValidateCredit(credit); !ok || credit.ActorId < 1 {
+			return false, slog.String("field", "credits"), "field credits is not valid"
+		}
+	}
 	return true, slog.Attr{}, ""
 }
+
+// ValidateCredit checks the fields of credit but actor_id and returns the
+// first invalid one.
+func ValidateCredit(credit Credit) (bool, string) {
+	if credit.CreditType != "" && !slices.Contains(postgres.CreditTypes, credit.CreditType) {
+		return false, "credit_type"
+	}
+	if len(credit.Character) > 150 || (credit.Character != "" && credit.CreditType != "" && credit.CreditType != postgres.CreditActor) {
+		return false, "character"
+	}
+	if credit.BillingOrder < 0 || credit.BillingOrder > 10000 {
+		return false, "billing_order"
+	}
+	return true, ""
+}
+
+// credits collects the credits of a request: actors_ids, then actor_id,
+// which can be given as a path parameter, then the credits list.
+func credits(req Request) []postgres.Credit {
+	var credits []postgres.Credit
+	for _, actorId := range req.ActorsIds {
+		credits = append(credits, postgres.Credit{ActorId: actorId, CreditType: postgres.CreditActor})
+	}
+
+	list := req.Credits
+	if req.ActorId != 0 {
+		list = append([]Credit{req.single()}, list...)
+	}
+	for _, credit := range list {
+		if credit.CreditType == "" {
+			credit.CreditType = postgres.CreditActor
+		}
+		credits = append(credits, postgres.Credit{
+			ActorId:      credit.ActorId,
+			CreditType:   credit.CreditType,
+			Character:    credit.Character,
+			BillingOrder: credit.BillingOrder,
+		})
+	}
+
+	return credits
+}
@@ -10,11 +10,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/actor-movie/save"
 	"film_library/internal/http-server/handlers/actor-movie/save/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage/postgres"
 )
 
 func TestSaveHandler(t *testing.T) {
@@ -23,30 +26,35 @@ func TestSaveHandler(t *testing.T) {
 		movieId   int
 		actorsIds []int
 		respError string
+		status    int
 		mockError error
 	}{
 		{
 			name:      "Success",
 			movieId:   1,
 			actorsIds: []int{1, 2},
+			status:    http.StatusOK,
 		},
 		{
 			name:      "Invalid movie_id",
 			movieId:   -1,
 			actorsIds: []int{1, 2},
 			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "Invalid actors_ids",
 			movieId:   1,
 			actorsIds: []int{1, -2},
 			respError: "field actors_ids is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "SaveActorMovie Error",
 			movieId:   1,
 			actorsIds: []int{1, 2},
 			respError: "failed to save actor-movie",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -60,7 +68,7 @@ func TestSaveHandler(t *testing.T) {
 			actorMovieSaverMock := mocks.NewActorMovieSaver(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				actorMovieSaverMock.On("SaveActorMovie", tc.movieId, tc.actorsIds).
+				actorMovieSaverMock.On("SaveActorMovie", mock.Anything, tc.movieId, actorCredits(tc.actorsIds)).
 					Return(tc.mockError).
 					Once()
 			}
@@ -77,7 +85,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -89,3 +97,177 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestPathParams(t *testing.T) {
+	cases := []struct {
+		name      string
+		url       string
+		body      string
+		actorsIds []int
+		respError string
+		status    int
+	}{
+		{
+			name:      "Single actor",
+			url:       "/api/v1/movies/1/actors/3",
+			actorsIds: []int{3},
+			status:    http.StatusOK,
+		},
+		{
+			name:      "Actors from body",
+			url:       "/api/v1/movies/1/actors",
+			body:      `{"actors_ids": [2, 3]}`,
+			actorsIds: []int{2, 3},
+			status:    http.StatusOK,
+		},
+		{
+			name:      "Invalid actor_id",
+			url:       "/api/v1/movies/1/actors/abc",
+			respError: "failed to decode request",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorMovieSaverMock := mocks.NewActorMovieSaver(t)
+
+			if tc.respError == "" {
+				actorMovieSaverMock.On("SaveActorMovie", mock.Anything, 1, actorCredits(tc.actorsIds)).
+					Return(nil).
+					Once()
+			}
+
+			handler := save.New(slogdiscard.NewDiscardLogger(), actorMovieSaverMock)
+
+			router := chi.NewRouter()
+			router.Method(http.MethodPut, "/api/v1/movies/{movie_id}/actors", handler)
+			router.Method(http.MethodPut, "/api/v1/movies/{movie_id}/actors/{actor_id}", handler)
+
+			req, err := http.NewRequest(http.MethodPut, tc.url, strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func TestCredits(t *testing.T) {
+	cases := []struct {
+		name      string
+		url       string
+		body      string
+		credits   []postgres.Credit
+		respError string
+		status    int
+	}{
+		{
+			name:    "Character and billing order",
+			url:     "/api/v1/movies/1/actors/3",
+			body:    `{"character": "Vito Corleone", "billing_order": 1}`,
+			credits: []postgres.Credit{{ActorId: 3, CreditType: "actor", Character: "Vito Corleone", BillingOrder: 1}},
+			status:  http.StatusOK,
+		},
+		{
+			name: "Cast and crew",
+			url:  "/api/v1/movies/1/actors",
+			body: `{"actors_ids": [2], "credits": [{"actor_id": 4, "credit_type": "director"}, {"actor_id": 5, "character": "Michael"}]}`,
+			credits: []postgres.Credit{
+				{ActorId: 2, CreditType: "actor"},
+				{ActorId: 4, CreditType: "director"},
+				{ActorId: 5, CreditType: "actor", Character: "Michael"},
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Unknown credit_type",
+			url:       "/api/v1/movies/1/actors/3",
+			body:      `{"credit_type": "stuntman"}`,
+			respError: "field credit_type is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Character of a director",
+			url:       "/api/v1/movies/1/actors/3",
+			body:      `{"credit_type": "director", "character": "Himself"}`,
+			respError: "field character is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid billing_order",
+			url:       "/api/v1/movies/1/actors/3",
+			body:      `{"billing_order": -1}`,
+			respError: "field billing_order is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Character without actor",
+			url:       "/api/v1/movies/1/actors",
+			body:      `{"character": "Vito Corleone"}`,
+			respError: "field actor_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid credits",
+			url:       "/api/v1/movies/1/actors",
+			body:      `{"credits": [{"actor_id": 0}]}`,
+			respError: "field credits is not valid",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorMovieSaverMock := mocks.NewActorMovieSaver(t)
+
+			if tc.respError == "" {
+				actorMovieSaverMock.On("SaveActorMovie", mock.Anything, 1, tc.credits).
+					Return(nil).
+					Once()
+			}
+
+			handler := save.New(slogdiscard.NewDiscardLogger(), actorMovieSaverMock)
+
+			router := chi.NewRouter()
+			router.Method(http.MethodPut, "/api/v1/movies/{movie_id}/actors", handler)
+			router.Method(http.MethodPut, "/api/v1/movies/{movie_id}/actors/{actor_id}", handler)
+
+			req, err := http.NewRequest(http.MethodPut, tc.url, strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func actorCredits(actorsIds []int) []postgres.Credit {
+	var credits []postgres.Credit
+	for _, actorId := range actorsIds {
+		credits = append(credits, postgres.Credit{ActorId: actorId, CreditType: postgres.CreditActor})
+	}
+	return credits
+}
@@ -1,34 +1,65 @@
 package all
 
 import (
+	"errors"
+	actorSave "film_library/internal/http-server/handlers/actor/save"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
 	"film_library/internal/storage/postgres"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"strings"
 )
 
+type Request struct {
+	Sort      string `json:"sort"`
+	Gender    string `json:"gender"`
+	BornFrom  int    `json:"born_from"`
+	BornTo    int    `json:"born_to"`
+	HasMovies *bool  `json:"has_movies"`
+	Name      string `json:"name"`
+	Limit     int    `json:"limit"`
+	Cursor    string `json:"cursor"`
+	WithTotal bool   `json:"with_total"`
+}
+
 type Response struct {
 	response.Response
 	Movies []postgres.Actor `json:"actors"`
+	postgres.PageInfo
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ActorsAllGetter
 type ActorsAllGetter interface {
-	GetActors() ([]postgres.Actor, error)
+	GetActors(sort []postgres.SortKey, filter postgres.ActorFilter, page postgres.Page) ([]postgres.Actor, postgres.PageInfo, error)
 }
 
 //	@Summary		Get all actors
-//	@Description	Get all actors
+//	@Description	Get all actors. Filters combine with AND. sort takes comma-separated fields among name, birthdate,
+//	@Description	movie_count and, with a name search, relevance, each prefixed with - for descending, e.g. -movie_count,name.
+//	@Description	Actors are by actor_id by default, or by relevance with a name search
 //	@Tags			Actor
 //	@Accept			json
 //	@Produce		json
+//	@Param			sort		query		string	false	"Sort keys, e.g. -movie_count,name"
+//	@Param			gender		query		string	false	"Gender"	Enums(male, female, other, unspecified)
+//	@Param			born_from	query		int		false	"Born in this year or later"
+//	@Param			born_to		query		int		false	"Born in this year or earlier"
+//	@Param			has_movies	query		bool	false	"Whether the actor is credited in a movie"
+//	@Param			name		query		string	false	"Start of or a misspelling of the name or an alias"
+//	@Param			limit		query		int		false	"Page size, 50 by default"
+//	@Param			cursor		query		string	false	"next_cursor of the previous page"
+//	@Param			with_total	query		bool	false	"Return the total count"
 //	@Success		200	{object}	Response
 //	@Failure		400	{object}	response.Response
 //	@Failure		401	{object}	response.Response
+//	@Failure		500	{object}	response.Response
 //	@Router			/actor/all [get]
+//	@Router			/api/v1/actors [get]
 func New(log *slog.Logger, actorsAllGetter ActorsAllGetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actor.all.New"
@@ -38,11 +69,75 @@ func New(log *slog.Logger, actorsAllGetter ActorsAllGetter) http.HandlerFunc {
 			slog.String("request_id", middleware.GetReqID(r.Context())),
 		)
 
-		actors, err := actorsAllGetter.GetActors()
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		var sort []postgres.SortKey
+		if req.Sort != "" {
+			sort, err = postgres.ParseActorSort(req.Sort)
+			if err == nil && req.Name == "" && containsField(sort, postgres.SortRelevance) {
+				err = errors.New("relevance without a name search")
+			}
+			if err != nil {
+				log.Error("invalid sort", slog.String("sort", req.Sort), sl.Err(err))
+
+				response.ValidationFailed(w, r, "sort", "field sort is not valid")
+
+				return
+			}
+		}
+
+		if ok, field := validateFilter(req); !ok {
+			log.Error("invalid filter", slog.String("field", field))
+
+			response.ValidationFailed(w, r, field, "field "+field+" is not valid")
+
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > postgres.MaxPageLimit {
+			log.Error("invalid limit", slog.Int("limit", req.Limit))
+
+			response.ValidationFailed(w, r, "limit", "field limit is not valid")
+
+			return
+		}
+
+		filter := postgres.ActorFilter{
+			Gender:    req.Gender,
+			BornFrom:  req.BornFrom,
+			BornTo:    req.BornTo,
+			HasMovies: req.HasMovies,
+			Name:      strings.TrimSpace(req.Name),
+		}
+
+		actors, page, err := actorsAllGetter.GetActors(sort, filter, postgres.Page{
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+			WithTotal: req.WithTotal,
+		})
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Error("invalid cursor", sl.Err(err))
+
+			response.ValidationFailed(w, r, "cursor", "field cursor is not valid")
+
+			return
+		}
+
 		if err != nil {
 			log.Error("actors search failed", sl.Err(err))
 
-			render.JSON(w, r, response.Error("actors search failed"))
+			response.FromError(w, r, err, "actors search failed")
 
 			return
 		}
@@ -52,6 +147,32 @@ func New(log *slog.Logger, actorsAllGetter ActorsAllGetter) http.HandlerFunc {
 		render.JSON(w, r, Response{
 			response.OK(),
 			actors,
+			page,
 		})
 	}
 }
+
+func containsField(sort []postgres.SortKey, field string) bool {
+	for _, key := range sort {
+		if key.Field == field {
+			return true
+		}
+	}
+	return false
+}
+
+func validateFilter(req Request) (bool, string) {
+	if req.Gender != "" && !actorSave.ValidGender(req.Gender) {
+		return false, "gender"
+	}
+	if req.BornFrom < 0 {
+		return false, "born_from"
+	}
+	if req.BornTo < 0 || req.BornFrom != 0 && req.BornTo != 0 && req.BornTo < req.BornFrom {
+		return false, "born_to"
+	}
+	if req.Name != "" && (strings.TrimSpace(req.Name) == "" || len(req.Name) > 255) {
+		return false, "name"
+	}
+	return true, ""
+}
@@ -3,7 +3,9 @@ package all_test
 import (
 	"encoding/json"
 	"errors"
+	"film_library/internal/storage"
 	"film_library/internal/storage/postgres"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -15,19 +17,108 @@ import (
 	"film_library/internal/lib/logger/handlers/slogdiscard"
 )
 
+func boolPtr(v bool) *bool {
+	return &v
+}
+
 func TestSaveHandler(t *testing.T) {
 	cases := []struct {
-		name      string
-		sortBy    string
-		respError string
-		mockError error
+		name       string
+		query      string
+		sort       []postgres.SortKey
+		filter     postgres.ActorFilter
+		page       postgres.Page
+		nextCursor string
+		respError  string
+		status     int
+		mockError  error
 	}{
 		{
-			name: "Success",
+			name:       "Success",
+			nextCursor: "next",
+			status:     http.StatusOK,
+		},
+		{
+			name:   "Page",
+			query:  "?limit=10&cursor=abc&with_total=true",
+			page:   postgres.Page{Limit: 10, Cursor: "abc", WithTotal: true},
+			status: http.StatusOK,
+		},
+		{
+			name:  "Sort",
+			query: "?sort=-movie_count,name",
+			sort: []postgres.SortKey{
+				{Field: postgres.SortMovieCount, Desc: true},
+				{Field: postgres.SortName},
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:  "Filter",
+			query: "?gender=female&born_from=1950&born_to=1969&has_movies=false",
+			filter: postgres.ActorFilter{
+				Gender:    "female",
+				BornFrom:  1950,
+				BornTo:    1969,
+				HasMovies: boolPtr(false),
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Name search",
+			query:  "?name=%20meryl%20&sort=-relevance,name",
+			sort:   []postgres.SortKey{{Field: postgres.SortRelevance, Desc: true}, {Field: postgres.SortName}},
+			filter: postgres.ActorFilter{Name: "meryl"},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid sort",
+			query:     "?sort=age",
+			respError: "field sort is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Relevance without name",
+			query:     "?sort=-relevance",
+			respError: "field sort is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid gender",
+			query:     "?gender=robot",
+			respError: "field gender is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid born_to",
+			query:     "?born_from=1970&born_to=1960",
+			respError: "field born_to is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Blank name",
+			query:     "?name=%20",
+			respError: "field name is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid limit",
+			query:     "?limit=-1",
+			respError: "field limit is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid cursor",
+			query:     "?cursor=abc",
+			page:      postgres.Page{Cursor: "abc"},
+			respError: "field cursor is not valid",
+			status:    http.StatusBadRequest,
+			mockError: fmt.Errorf("storage.postgres.GetActors: %w", storage.ErrInvalidCursor),
 		},
 		{
 			name:      "GetActors Error",
 			respError: "actors search failed",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -41,20 +132,20 @@ func TestSaveHandler(t *testing.T) {
 			actorsAllGetterMock := mocks.NewActorsAllGetter(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				actorsAllGetterMock.On("GetActors").
-					Return([]postgres.Actor{}, tc.mockError).
+				actorsAllGetterMock.On("GetActors", tc.sort, tc.filter, tc.page).
+					Return([]postgres.Actor{}, postgres.PageInfo{NextCursor: tc.nextCursor}, tc.mockError).
 					Once()
 			}
 
 			handler := searchAll.New(slogdiscard.NewDiscardLogger(), actorsAllGetterMock)
 
-			req, err := http.NewRequest(http.MethodGet, "/actor/all", nil)
+			req, err := http.NewRequest(http.MethodGet, "/actor/all"+tc.query, nil)
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -63,6 +154,7 @@ func TestSaveHandler(t *testing.T) {
 			require.NoError(t, json.Unmarshal([]byte(body), &resp))
 
 			require.Equal(t, tc.respError, resp.Error)
+			require.Equal(t, tc.nextCursor, resp.NextCursor)
 		})
 	}
 }
@@ -13,34 +13,41 @@ type ActorsAllGetter struct {
 	mock.Mock
 }
 
-// GetActors provides a mock function with given fields:
-func (_m *ActorsAllGetter) GetActors() ([]postgres.Actor, error) {
-	ret := _m.Called()
+// GetActors provides a mock function with given fields: sort, filter, page
+func (_m *ActorsAllGetter) GetActors(sort []postgres.SortKey, filter postgres.ActorFilter, page postgres.Page) ([]postgres.Actor, postgres.PageInfo, error) {
+	ret := _m.Called(sort, filter, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetActors")
 	}
 
 	var r0 []postgres.Actor
-	var r1 error
-	if rf, ok := ret.Get(0).(func() ([]postgres.Actor, error)); ok {
-		return rf()
+	var r1 postgres.PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]postgres.SortKey, postgres.ActorFilter, postgres.Page) ([]postgres.Actor, postgres.PageInfo, error)); ok {
+		return rf(sort, filter, page)
 	}
-	if rf, ok := ret.Get(0).(func() []postgres.Actor); ok {
-		r0 = rf()
+	if rf, ok := ret.Get(0).(func([]postgres.SortKey, postgres.ActorFilter, postgres.Page) []postgres.Actor); ok {
+		r0 = rf(sort, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]postgres.Actor)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func() error); ok {
-		r1 = rf()
+	if rf, ok := ret.Get(1).(func([]postgres.SortKey, postgres.ActorFilter, postgres.Page) postgres.PageInfo); ok {
+		r1 = rf(sort, filter, page)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(postgres.PageInfo)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func([]postgres.SortKey, postgres.ActorFilter, postgres.Page) error); ok {
+		r2 = rf(sort, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // NewActorsAllGetter creates a new instance of ActorsAllGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -1,8 +1,13 @@
 package delete
 
 import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/etag"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
@@ -19,20 +24,25 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ActorDeleter
 type ActorDeleter interface {
-	DeleteActor(actorId int) error
+	DeleteActor(ctx context.Context, actorId int, version int) error
 }
 
 //	@Summary		Delete an actor
-//	@Description	Delete an actor by actor_id
+//	@Description	Move an actor to the trash by actor_id. Admins can restore them until they are purged
 //	@Tags			Actor
 //	@Accept			json
 //	@Produce		json
-//	@Param			actor_id	path		int	true	"Actor ID"
+//	@Param			actor_id	path		int		true	"Actor ID"
+//	@Param			If-Match	header		string	false	"ETag the deletion is based on"
 //	@Success		200			{object}	Response
 //	@Failure		400			{object}	response.Response
 //	@Failure		401			{object}	response.Response
 //	@Failure		403			{object}	response.Response
+//	@Failure		404			{object}	response.Response
+//	@Failure		412			{object}	response.Response
+//	@Failure		500			{object}	response.Response
 //	@Router			/actor/delete [delete]
+//	@Router			/api/v1/actors/{actor_id} [delete]
 func New(log *slog.Logger, actorDeleter ActorDeleter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actor.delete.New"
@@ -44,11 +54,11 @@ func New(log *slog.Logger, actorDeleter ActorDeleter) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
@@ -58,16 +68,32 @@ func New(log *slog.Logger, actorDeleter ActorDeleter) http.HandlerFunc {
 		if req.ActorId < 1 {
 			log.Error("invalid actor_id", slog.Int("actor_id", req.ActorId))
 
-			render.JSON(w, r, response.Error("field actor_id is not valid"))
+			response.ValidationFailed(w, r, "actor_id", "field actor_id is not valid")
+
+			return
+		}
+
+		err = actorDeleter.DeleteActor(r.Context(), req.ActorId, etag.IfMatch(r))
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("actor not found", slog.Int("actor_id", req.ActorId))
+
+			response.FromError(w, r, err, "actor not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Warn("actor has been changed", slog.Int("actor_id", req.ActorId))
+
+			response.FromError(w, r, err, "actor has been changed")
 
 			return
 		}
 
-		err = actorDeleter.DeleteActor(req.ActorId)
 		if err != nil {
 			log.Error("failed to delete actor", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to delete actor"))
+			response.FromError(w, r, err, "failed to delete actor")
 
 			return
 		}
@@ -9,33 +9,64 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/actor/delete"
 	"film_library/internal/http-server/handlers/actor/delete/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
 )
 
 func TestSaveHandler(t *testing.T) {
 	cases := []struct {
 		name      string
 		actorId   int
+		ifMatch   string
+		version   int
 		respError string
+		status    int
 		mockError error
 	}{
 		{
 			name:    "Success",
 			actorId: 1,
+			status:  http.StatusOK,
 		},
 		{
 			name:      "Invalid actor_id",
 			actorId:   -1,
 			respError: "field actor_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			actorId:   1,
+			respError: "actor not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.DeleteActor: %w", storage.ErrNotFound),
+		},
+		{
+			name:    "If-Match",
+			actorId: 1,
+			ifMatch: `"3"`,
+			version: 3,
+			status:  http.StatusOK,
+		},
+		{
+			name:      "Version mismatch",
+			actorId:   1,
+			ifMatch:   `"3"`,
+			version:   3,
+			respError: "actor has been changed",
+			status:    http.StatusPreconditionFailed,
+			mockError: fmt.Errorf("storage.postgres.DeleteActor: %w", storage.ErrVersionMismatch),
 		},
 		{
 			name:      "DeleteActor Error",
 			actorId:   1,
 			respError: "failed to delete actor",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -49,7 +80,7 @@ func TestSaveHandler(t *testing.T) {
 			actorDeleterMock := mocks.NewActorDeleter(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				actorDeleterMock.On("DeleteActor", tc.actorId).
+				actorDeleterMock.On("DeleteActor", mock.Anything, tc.actorId, tc.version).
 					Return(tc.mockError).
 					Once()
 			}
@@ -60,11 +91,14 @@ func TestSaveHandler(t *testing.T) {
 
 			req, err := http.NewRequest(http.MethodPost, "/actor/delete", bytes.NewReader([]byte(input)))
 			require.NoError(t, err)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
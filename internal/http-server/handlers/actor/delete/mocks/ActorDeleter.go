@@ -2,24 +2,28 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // ActorDeleter is an autogenerated mock type for the ActorDeleter type
 type ActorDeleter struct {
 	mock.Mock
 }
 
-// DeleteActor provides a mock function with given fields: actorId
-func (_m *ActorDeleter) DeleteActor(actorId int) error {
-	ret := _m.Called(actorId)
+// DeleteActor provides a mock function with given fields: ctx, actorId, version
+func (_m *ActorDeleter) DeleteActor(ctx context.Context, actorId int, version int) error {
+	ret := _m.Called(ctx, actorId, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteActor")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(actorId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, actorId, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2,16 +2,22 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	postgres "film_library/internal/storage/postgres"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // ActorSaver is an autogenerated mock type for the ActorSaver type
 type ActorSaver struct {
 	mock.Mock
 }
 
-// SaveActor provides a mock function with given fields: name, gender, birthdate
-func (_m *ActorSaver) SaveActor(name string, gender string, birthdate string) (int, error) {
-	ret := _m.Called(name, gender, birthdate)
+// SaveActor provides a mock function with given fields: ctx, name, gender, birthdate, profile
+func (_m *ActorSaver) SaveActor(ctx context.Context, name string, gender string, birthdate string, profile postgres.ActorProfile) (int, error) {
+	ret := _m.Called(ctx, name, gender, birthdate, profile)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveActor")
@@ -19,17 +25,17 @@ func (_m *ActorSaver) SaveActor(name string, gender string, birthdate string) (i
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string) (int, error)); ok {
-		return rf(name, gender, birthdate)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, postgres.ActorProfile) (int, error)); ok {
+		return rf(ctx, name, gender, birthdate, profile)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string) int); ok {
-		r0 = rf(name, gender, birthdate)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, postgres.ActorProfile) int); ok {
+		r0 = rf(ctx, name, gender, birthdate, profile)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string) error); ok {
-		r1 = rf(name, gender, birthdate)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, postgres.ActorProfile) error); ok {
+		r1 = rf(ctx, name, gender, birthdate, profile)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,19 +1,41 @@
 package save
 
 import (
+	"context"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage/postgres"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"slices"
+	"strings"
 	"time"
 )
 
 type Request struct {
-	Name      string `json:"name"`
-	Gender    string `json:"gender"`
-	Birthdate string `json:"birthdate"`
+	Name        string            `json:"name"`
+	Gender      string            `json:"gender"`
+	Birthdate   string            `json:"birthdate"`
+	Biography   string            `json:"biography"`
+	Birthplace  string            `json:"birthplace"`
+	Nationality string            `json:"nationality"`
+	DeathDate   string            `json:"death_date"`
+	Aliases     []string          `json:"aliases"`
+	ExternalIds map[string]string `json:"external_ids"`
+}
+
+func (r Request) profile() postgres.ActorProfile {
+	return postgres.ActorProfile{
+		Biography:   r.Biography,
+		Birthplace:  r.Birthplace,
+		Nationality: r.Nationality,
+		DeathDate:   r.DeathDate,
+		Aliases:     r.Aliases,
+		ExternalIds: r.ExternalIds,
+	}
 }
 
 type Response struct {
@@ -23,22 +45,31 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ActorSaver
 type ActorSaver interface {
-	SaveActor(name string, gender string, birthdate string) (int, error)
+	SaveActor(ctx context.Context, name string, gender string, birthdate string, profile postgres.ActorProfile) (int, error)
 }
 
 // @Summary		Create a new actor
-// @Description	Create a new actor by name, gender and birthdate
+// @Description	Create a new actor by name, gender (male, female, other or unspecified) and birthdate, optionally with biography, birthplace, nationality (ISO 3166-1 alpha-2), death_date, aliases and external_ids (imdb, wikidata, tmdb)
 // @Tags			Actor
 // @Accept			json
 // @Produce		json
-// @Param			name		body		string	true	"Name"
-// @Param			name		body		string	true	"Gender"
-// @Param			birthdate	body		string	true	"Birthdate"
-// @Success		200			{object}	Response
-// @Failure		400			{object}	response.Response
-// @Failure		401			{object}	response.Response
-// @Failure		403			{object}	response.Response
+// @Param			name			body		string				true	"Name"
+// @Param			gender			body		string				true	"Gender"
+// @Param			birthdate		body		string				true	"Birthdate"
+// @Param			biography		body		string				false	"Biography"
+// @Param			birthplace		body		string				false	"Birthplace"
+// @Param			nationality		body		string				false	"Nationality, e.g. US"
+// @Param			death_date		body		string				false	"Death date"
+// @Param			aliases			body		[]string			false	"Alternative names"
+// @Param			external_ids	body		map[string]string	false	"Ids in other databases by source: imdb, wikidata or tmdb"
+// @Success		201				{object}	Response
+// @Failure		400				{object}	response.Response
+// @Failure		401				{object}	response.Response
+// @Failure		403				{object}	response.Response
+// @Failure		409				{object}	response.Response
+// @Failure		500				{object}	response.Response
 // @Router			/actor/save [post]
+// @Router			/api/v1/actors [post]
 func New(log *slog.Logger, actorSaver ActorSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actor.save.New"
@@ -50,52 +81,103 @@ func New(log *slog.Logger, actorSaver ActorSaver) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if ok, field, msg := validateRequest(req); !ok {
+		if ok, field, msg := ValidateRequest(req); !ok {
 			log.Error("invalid request", field)
 
-			render.JSON(w, r, response.Error(msg))
+			response.ValidationFailed(w, r, field.Value.String(), msg)
 
 			return
 		}
 
-		actorId, err := actorSaver.SaveActor(req.Name, req.Gender, req.Birthdate)
+		actorId, err := actorSaver.SaveActor(r.Context(), req.Name, req.Gender, req.Birthdate, req.profile())
 		if err != nil {
 			log.Error("failed to save actor", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to save actor"))
+			response.FromError(w, r, err, "failed to save actor")
 
 			return
 		}
 
 		log.Info("actor saved", slog.Int("actor_id", actorId))
 
-		render.JSON(w, r, Response{
+		response.Render(w, r, http.StatusCreated, Response{
 			response.OK(),
 			actorId,
 		})
 	}
 }
 
-func validateRequest(req Request) (bool, slog.Attr, string) {
+func ValidateRequest(req Request) (bool, slog.Attr, string) {
 	if len(req.Name) < 1 || len(req.Name) > 255 {
 		return false, slog.String("field", "name"), "field name is not valid"
 	}
-	if req.Gender != "male" && req.Gender != "female" {
+	if !ValidGender(req.Gender) {
 		return false, slog.String("field", "gender"), "field gender is not valid"
 	}
 	if _, err := time.Parse("2006-01-02", req.Birthdate); err != nil {
 		return false, slog.String("field", "birthdate"), "field birthdate is not valid"
 	}
+	return ValidateProfile(req.profile(), req.Birthdate)
+}
+
+func ValidGender(gender string) bool {
+	return slices.Contains(postgres.Genders, gender)
+}
+
+const maxAliases = 20
+
+var (
+	countryCode = regexp.MustCompile(`^[A-Z]{2}$`)
+	// externalIdFormats are the id formats of the sources of external ids
+	externalIdFormats = map[string]*regexp.Regexp{
+		postgres.SourceIMDb:     regexp.MustCompile(`^nm[0-9]{7,8}$`),
+		postgres.SourceWikidata: regexp.MustCompile(`^Q[1-9][0-9]*$`),
+		postgres.SourceTMDB:     regexp.MustCompile(`^[1-9][0-9]{0,9}$`),
+	}
+)
+
+// ValidateProfile checks the form of an actor profile. A death date must
+// not be before birthdate, if one is given. Whether a nationality exists is
+// up to the storage.
+func ValidateProfile(profile postgres.ActorProfile, birthdate string) (bool, slog.Attr, string) {
+	if len(profile.Biography) > 5000 {
+		return false, slog.String("field", "biography"), "field biography is not valid"
+	}
+	if len(profile.Birthplace) > 255 {
+		return false, slog.String("field", "birthplace"), "field birthplace is not valid"
+	}
+	if profile.Nationality != "" && !countryCode.MatchString(profile.Nationality) {
+		return false, slog.String("field", "nationality"), "field nationality is not valid"
+	}
+	if profile.DeathDate != "" {
+		// dates in this format compare as strings
+		if _, err := time.Parse("2006-01-02", profile.DeathDate); err != nil || profile.DeathDate < birthdate {
+			return false, slog.String("field", "death_date"), "field death_date is not valid"
+		}
+	}
+	if len(profile.Aliases) > maxAliases || slices.ContainsFunc(profile.Aliases, func(alias string) bool { return !validAlias(alias) }) {
+		return false, slog.String("field", "aliases"), "field aliases is not valid"
+	}
+	for source, id := range profile.ExternalIds {
+		if format, ok := externalIdFormats[source]; !ok || !format.MatchString(id) {
+			return false, slog.String("field", "external_ids"), "field external_ids is not valid"
+		}
+	}
 	return true, slog.Attr{}, ""
 }
+
+// validAlias rejects the separator of CSV list columns.
+func validAlias(alias string) bool {
+	return len(alias) >= 1 && len(alias) <= 255 && strings.TrimSpace(alias) == alias && !strings.Contains(alias, "|")
+}
@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/actor/save"
 	"film_library/internal/http-server/handlers/actor/save/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage/postgres"
 )
 
 func TestSaveHandler(t *testing.T) {
@@ -23,6 +26,7 @@ func TestSaveHandler(t *testing.T) {
 		gender    string
 		birthdate string
 		respError string
+		status    int
 		mockError error
 	}{
 		{
@@ -30,6 +34,7 @@ func TestSaveHandler(t *testing.T) {
 			actorName: "Nikita",
 			gender:    "male",
 			birthdate: "2000-01-01",
+			status:    http.StatusCreated,
 		},
 		{
 			name:      "Empty name",
@@ -37,6 +42,7 @@ func TestSaveHandler(t *testing.T) {
 			gender:    "female",
 			birthdate: "1900-01-01",
 			respError: "field name is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "Invalid gender",
@@ -44,6 +50,14 @@ func TestSaveHandler(t *testing.T) {
 			gender:    "abc",
 			birthdate: "1900-01-01",
 			respError: "field gender is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Unspecified gender",
+			actorName: "Nikita",
+			gender:    "unspecified",
+			birthdate: "2000-01-01",
+			status:    http.StatusCreated,
 		},
 		{
 			name:      "Invalid birthdate",
@@ -51,6 +65,7 @@ func TestSaveHandler(t *testing.T) {
 			gender:    "male",
 			birthdate: "1900.01.01",
 			respError: "field birthdate is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "SaveActor Error",
@@ -58,6 +73,7 @@ func TestSaveHandler(t *testing.T) {
 			gender:    "male",
 			birthdate: "2000-01-01",
 			respError: "failed to save actor",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -71,7 +87,7 @@ func TestSaveHandler(t *testing.T) {
 			actorSaverMock := mocks.NewActorSaver(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				actorSaverMock.On("SaveActor", tc.actorName, tc.gender, tc.birthdate).
+				actorSaverMock.On("SaveActor", mock.Anything, tc.actorName, tc.gender, tc.birthdate, postgres.ActorProfile{}).
 					Return(1, tc.mockError).
 					Once()
 			}
@@ -87,7 +103,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -99,3 +115,90 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveProfile(t *testing.T) {
+	cases := []struct {
+		name      string
+		profile   string
+		want      postgres.ActorProfile
+		respError string
+		status    int
+	}{
+		{
+			name: "Success",
+			profile: `"biography": "Actor and director", "birthplace": "Omaha", "nationality": "US", "death_date": "2004-07-01", ` +
+				`"aliases": ["Bud"], "external_ids": {"imdb": "nm0000008", "wikidata": "Q36949"}`,
+			want: postgres.ActorProfile{
+				Biography:   "Actor and director",
+				Birthplace:  "Omaha",
+				Nationality: "US",
+				DeathDate:   "2004-07-01",
+				Aliases:     []string{"Bud"},
+				ExternalIds: map[string]string{"imdb": "nm0000008", "wikidata": "Q36949"},
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:      "Invalid nationality",
+			profile:   `"nationality": "USA"`,
+			respError: "field nationality is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Death before birth",
+			profile:   `"death_date": "1900-01-01"`,
+			respError: "field death_date is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Alias with a separator",
+			profile:   `"aliases": ["Bud|Marlon"]`,
+			respError: "field aliases is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Unknown source",
+			profile:   `"external_ids": {"kinopoisk": "1"}`,
+			respError: "field external_ids is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid IMDb id",
+			profile:   `"external_ids": {"imdb": "tt0068646"}`,
+			respError: "field external_ids is not valid",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorSaverMock := mocks.NewActorSaver(t)
+
+			if tc.respError == "" {
+				actorSaverMock.On("SaveActor", mock.Anything, "Marlon Brando", "male", "1924-04-03", tc.want).
+					Return(1, nil).
+					Once()
+			}
+
+			handler := save.New(slogdiscard.NewDiscardLogger(), actorSaverMock)
+
+			input := `{"name": "Marlon Brando", "gender": "male", "birthdate": "1924-04-03", ` + tc.profile + `}`
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/actors", strings.NewReader(input))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
@@ -1,8 +1,12 @@
 package search
 
 import (
+	"errors"
+	"film_library/internal/lib/api/etag"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
 	"film_library/internal/storage/postgres"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -29,11 +33,17 @@ type ActorSearcher interface {
 //	@Tags			Actor
 //	@Accept			json
 //	@Produce		json
-//	@Param			actor_id	path		int	true	"Actor ID"
-//	@Success		200			{object}	Response
+//	@Param			actor_id		path		int		true	"Actor ID"
+//	@Param			If-None-Match	header		string	false	"ETag of a cached copy"
+//	@Success		200				{object}	Response
+//	@Header			200				{string}	ETag	"Version of the actor"
+//	@Success		304				"Cached copy is current"
 //	@Failure		400			{object}	response.Response
 //	@Failure		401			{object}	response.Response
+//	@Failure		404			{object}	response.Response
+//	@Failure		500			{object}	response.Response
 //	@Router			/actor/search [get]
+//	@Router			/api/v1/actors/{actor_id} [get]
 func New(log *slog.Logger, actorSearcher ActorSearcher) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actor.search.New"
@@ -45,11 +55,11 @@ func New(log *slog.Logger, actorSearcher ActorSearcher) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
@@ -59,22 +69,38 @@ func New(log *slog.Logger, actorSearcher ActorSearcher) http.HandlerFunc {
 		if req.ActorId < 1 {
 			log.Error("invalid actor_id", slog.Int("actor_id", req.ActorId))
 
-			render.JSON(w, r, response.Error("field actor_id is not valid"))
+			response.ValidationFailed(w, r, "actor_id", "field actor_id is not valid")
 
 			return
 		}
 
 		actor, err := actorSearcher.GetActor(req.ActorId)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("actor not found", slog.Int("actor_id", req.ActorId))
+
+			response.FromError(w, r, err, "actor not found")
+
+			return
+		}
+
 		if err != nil {
 			log.Error("actor search failed", sl.Err(err))
 
-			render.JSON(w, r, response.Error("actor search failed"))
+			response.FromError(w, r, err, "actor search failed")
 
 			return
 		}
 
 		log.Info("actor found", slog.Int("actor_id", req.ActorId))
 
+		etag.Set(w, actor.Version)
+
+		if etag.NotModified(r, actor.Version) {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
 		render.JSON(w, r, Response{
 			response.OK(),
 			actor,
@@ -15,6 +15,7 @@ import (
 	"film_library/internal/http-server/handlers/actor/search"
 	"film_library/internal/http-server/handlers/actor/search/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
 )
 
 func TestSaveHandler(t *testing.T) {
@@ -22,21 +23,32 @@ func TestSaveHandler(t *testing.T) {
 		name      string
 		actorId   int
 		respError string
+		status    int
 		mockError error
 	}{
 		{
 			name:    "Success",
 			actorId: 1,
+			status:  http.StatusOK,
 		},
 		{
 			name:      "Invalid actor_id",
 			actorId:   -1,
 			respError: "field actor_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			actorId:   1,
+			respError: "actor not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.GetActor: %w", storage.ErrNotFound),
 		},
 		{
 			name:      "GetActor Error",
 			actorId:   1,
 			respError: "actor search failed",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -65,7 +77,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -77,3 +89,56 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestETag(t *testing.T) {
+	cases := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{
+			name:   "No cached copy",
+			status: http.StatusOK,
+		},
+		{
+			name:        "Current copy",
+			ifNoneMatch: `"3"`,
+			status:      http.StatusNotModified,
+		},
+		{
+			name:        "Stale copy",
+			ifNoneMatch: `"2"`,
+			status:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorSearcherMock := mocks.NewActorSearcher(t)
+
+			actorSearcherMock.On("GetActor", 1).
+				Return(postgres.Actor{Id: 1, Version: 3}, nil).
+				Once()
+
+			handler := search.New(slogdiscard.NewDiscardLogger(), actorSearcherMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/actor/search?actor_id=1", nil)
+			require.NoError(t, err)
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+			require.Equal(t, `"3"`, rr.Header().Get("ETag"))
+
+			if tc.status == http.StatusNotModified {
+				require.Empty(t, rr.Body.String())
+			}
+		})
+	}
+}
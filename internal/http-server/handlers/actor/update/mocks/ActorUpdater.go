@@ -2,65 +2,45 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	postgres "film_library/internal/storage/postgres"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // ActorUpdater is an autogenerated mock type for the ActorUpdater type
 type ActorUpdater struct {
 	mock.Mock
 }
 
-// UpdateActorBirthdate provides a mock function with given fields: actorId, birthdate
-func (_m *ActorUpdater) UpdateActorBirthdate(actorId int, birthdate string) error {
-	ret := _m.Called(actorId, birthdate)
+// UpdateActor provides a mock function with given fields: ctx, actorId, patch, version
+func (_m *ActorUpdater) UpdateActor(ctx context.Context, actorId int, patch postgres.ActorPatch, version int) (int, error) {
+	ret := _m.Called(ctx, actorId, patch, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateActorBirthdate")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(actorId, birthdate)
-	} else {
-		r0 = ret.Error(0)
+		panic("no return value specified for UpdateActor")
 	}
 
-	return r0
-}
-
-// UpdateActorGender provides a mock function with given fields: actorId, gender
-func (_m *ActorUpdater) UpdateActorGender(actorId int, gender string) error {
-	ret := _m.Called(actorId, gender)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateActorGender")
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, postgres.ActorPatch, int) (int, error)); ok {
+		return rf(ctx, actorId, patch, version)
 	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(actorId, gender)
+	if rf, ok := ret.Get(0).(func(context.Context, int, postgres.ActorPatch, int) int); ok {
+		r0 = rf(ctx, actorId, patch, version)
 	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// UpdateActorName provides a mock function with given fields: actorId, name
-func (_m *ActorUpdater) UpdateActorName(actorId int, name string) error {
-	ret := _m.Called(actorId, name)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateActorName")
+		r0 = ret.Get(0).(int)
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(actorId, name)
+	if rf, ok := ret.Get(1).(func(context.Context, int, postgres.ActorPatch, int) error); ok {
+		r1 = rf(ctx, actorId, patch, version)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // NewActorUpdater creates a new instance of ActorUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
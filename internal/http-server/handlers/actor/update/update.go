@@ -1,8 +1,16 @@
 package update
 
 import (
+	"context"
+	"errors"
+	actorSave "film_library/internal/http-server/handlers/actor/save"
+	"film_library/internal/lib/api/etag"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"fmt"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
@@ -11,10 +19,16 @@ import (
 )
 
 type Request struct {
-	ActorId   int     `json:"actor_id"`
-	Name      *string `json:"name,omitempty"`
-	Gender    *string `json:"gender,omitempty"`
-	Birthdate *string `json:"birthdate,omitempty"`
+	ActorId     int               `json:"actor_id"`
+	Name        *string           `json:"name,omitempty"`
+	Gender      *string           `json:"gender,omitempty"`
+	Birthdate   *string           `json:"birthdate,omitempty"`
+	Biography   *string           `json:"biography,omitempty" patch:"nullable"`
+	Birthplace  *string           `json:"birthplace,omitempty" patch:"nullable"`
+	Nationality *string           `json:"nationality,omitempty" patch:"nullable"`
+	DeathDate   *string           `json:"death_date,omitempty" patch:"nullable"`
+	Aliases     []string          `json:"aliases,omitempty" patch:"nullable"`
+	ExternalIds map[string]string `json:"external_ids,omitempty" patch:"nullable"`
 }
 
 type Response struct {
@@ -23,25 +37,36 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ActorUpdater
 type ActorUpdater interface {
-	UpdateActorName(actorId int, name string) error
-	UpdateActorGender(actorId int, gender string) error
-	UpdateActorBirthdate(actorId int, birthdate string) error
+	UpdateActor(ctx context.Context, actorId int, patch postgres.ActorPatch, version int) (int, error)
 }
 
 //	@Summary		Update an actor
-//	@Description	Update an actor by actor_id
+//	@Description	Update an actor by actor_id. Fields left out are kept, all given fields are changed at once. Given aliases and external_ids replace the current ones, empty biography, birthplace, nationality and death_date clear them. PATCH also takes application/merge-patch+json bodies, where null clears any field but name, gender and birthdate
 //	@Tags			Actor
-//	@Accept			json
+//	@Accept			json,application/merge-patch+json
 //	@Produce		json
-//	@Param			actor_id	path		int		true	"Actor ID"
-//	@Param			name		body		string	false	"Name"
-//	@Param			gender		body		string	false	"Gender"
-//	@Param			birthdate	body		string	false	"Birthdate"
-//	@Success		200			{object}	Response
-//	@Failure		400			{object}	response.Response
-//	@Failure		401			{object}	response.Response
-//	@Failure		403			{object}	response.Response
+//	@Param			actor_id		path		int					true	"Actor ID"
+//	@Param			name			body		string				false	"Name"
+//	@Param			gender			body		string				false	"Gender: male, female, other or unspecified"
+//	@Param			birthdate		body		string				false	"Birthdate"
+//	@Param			biography		body		string				false	"Biography"
+//	@Param			birthplace		body		string				false	"Birthplace"
+//	@Param			nationality		body		string				false	"Nationality, e.g. FR"
+//	@Param			death_date		body		string				false	"Death date"
+//	@Param			aliases			body		[]string			false	"Other names"
+//	@Param			external_ids	body		map[string]string	false	"Ids by source: imdb, wikidata or tmdb"
+//	@Param			If-Match		header		string				false	"ETag the change is based on"
+//	@Success		200				{object}	Response
+//	@Header			200				{string}	ETag	"New version of the actor"
+//	@Failure		400				{object}	response.Response
+//	@Failure		401				{object}	response.Response
+//	@Failure		403				{object}	response.Response
+//	@Failure		404				{object}	response.Response
+//	@Failure		409				{object}	response.Response
+//	@Failure		412				{object}	response.Response
+//	@Failure		500				{object}	response.Response
 //	@Router			/actor/update [post]
+//	@Router			/api/v1/actors/{actor_id} [patch]
 func New(log *slog.Logger, actorSaver ActorUpdater) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.actor.update.New"
@@ -53,11 +78,20 @@ func New(log *slog.Logger, actorSaver ActorUpdater) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
+		var nullErr *request.NullFieldError
+		if errors.As(err, &nullErr) {
+			log.Error("field can not be removed", slog.String("field", nullErr.Field))
+
+			response.ValidationFailed(w, r, nullErr.Field, fmt.Sprintf("field %s is not valid", nullErr.Field))
+
+			return
+		}
+
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
@@ -67,53 +101,59 @@ func New(log *slog.Logger, actorSaver ActorUpdater) http.HandlerFunc {
 		if ok, field, msg := validateRequest(req); !ok {
 			log.Error("invalid request", field)
 
-			render.JSON(w, r, response.Error(msg))
+			response.ValidationFailed(w, r, field.Value.String(), msg)
 
 			return
 		}
 
-		if req.Name != nil {
-			err := actorSaver.UpdateActorName(req.ActorId, *req.Name)
-			if err != nil {
-				log.Error("failed to update actor name", sl.Err(err))
+		if req.Name == nil && req.Gender == nil && req.Birthdate == nil &&
+			req.Biography == nil && req.Birthplace == nil && req.Nationality == nil && req.DeathDate == nil &&
+			req.Aliases == nil && req.ExternalIds == nil {
+			log.Error("no fields to update")
 
-				render.JSON(w, r, response.Error("failed to update actor name"))
+			response.BadRequest(w, r, "no fields to update")
 
-				return
-			}
+			return
 		}
 
-		if req.Gender != nil {
-			err := actorSaver.UpdateActorGender(req.ActorId, *req.Gender)
-			if err != nil {
-				log.Error("failed to update actor gender", sl.Err(err))
+		version, err := actorSaver.UpdateActor(r.Context(), req.ActorId, postgres.ActorPatch{
+			Name:        req.Name,
+			Gender:      req.Gender,
+			Birthdate:   req.Birthdate,
+			Biography:   req.Biography,
+			Birthplace:  req.Birthplace,
+			Nationality: req.Nationality,
+			DeathDate:   req.DeathDate,
+			Aliases:     req.Aliases,
+			ExternalIds: req.ExternalIds,
+		}, etag.IfMatch(r))
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Error("actor not found", slog.Int("actor_id", req.ActorId))
+
+			response.FromError(w, r, err, "actor not found")
 
-				render.JSON(w, r, response.Error("failed to update actor gender"))
-
-				return
-			}
+			return
 		}
 
-		if req.Birthdate != nil {
-			err := actorSaver.UpdateActorBirthdate(req.ActorId, *req.Birthdate)
-			if err != nil {
-				log.Error("failed to update actor birthdate", sl.Err(err))
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Warn("actor has been changed", slog.Int("actor_id", req.ActorId))
 
-				render.JSON(w, r, response.Error("failed to update actor birthdate"))
+			response.FromError(w, r, err, "actor has been changed")
 
-				return
-			}
+			return
 		}
 
-		if req.Name == nil && req.Gender == nil && req.Birthdate == nil {
-			log.Error("no fields to update")
+		if err != nil {
+			log.Error("failed to update actor", sl.Err(err))
 
-			render.JSON(w, r, response.Error("no fields to update"))
+			response.FromError(w, r, err, "failed to update actor")
 
 			return
 		}
 
-		log.Info("actor updated", slog.Int("actor_id", req.ActorId))
+		log.Info("actor updated", slog.Int("actor_id", req.ActorId), slog.Int("version", version))
+
+		etag.Set(w, version)
 
 		render.JSON(w, r, Response{response.OK()})
 	}
@@ -126,14 +166,31 @@ func validateRequest(req Request) (bool, slog.Attr, string) {
 	if req.Name != nil && (len(*req.Name) < 1 || len(*req.Name) > 255) {
 		return false, slog.String("field", "name"), "field name is not valid"
 	}
-	if req.Gender != nil && *req.Gender != "male" && *req.Gender != "female" {
+	if req.Gender != nil && !actorSave.ValidGender(*req.Gender) {
 		return false, slog.String("field", "gender"), "field gender is not valid"
 	}
-	if req.Birthdate == nil {
-		return true, slog.Attr{}, ""
+	var birthdate string
+	if req.Birthdate != nil {
+		if _, err := time.Parse("2006-01-02", *req.Birthdate); err != nil {
+			return false, slog.String("field", "birthdate"), "field birthdate is not valid"
+		}
+		birthdate = *req.Birthdate
+	}
+
+	// fields left out are empty, which is always valid; a death date
+	// before the stored birthdate is left to the storage
+	profile := postgres.ActorProfile{Aliases: req.Aliases, ExternalIds: req.ExternalIds}
+	if req.Biography != nil {
+		profile.Biography = *req.Biography
+	}
+	if req.Birthplace != nil {
+		profile.Birthplace = *req.Birthplace
+	}
+	if req.Nationality != nil {
+		profile.Nationality = *req.Nationality
 	}
-	if _, err := time.Parse("2006-01-02", *req.Birthdate); err != nil {
-		return false, slog.String("field", "birthdate"), "field birthdate is not valid"
+	if req.DeathDate != nil {
+		profile.DeathDate = *req.DeathDate
 	}
-	return true, slog.Attr{}, ""
+	return actorSave.ValidateProfile(profile, birthdate)
 }
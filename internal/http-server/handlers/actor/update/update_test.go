@@ -4,16 +4,22 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/actor/update"
 	"film_library/internal/http-server/handlers/actor/update/mocks"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
 )
 
 const bigName = "Lorem ipsum dolor sit amet, consectetuer adipiscing elit, sed diam nonummy nibh euismod tincidunt ut laoreet dolore magna aliquam erat volutpat. Ut wisi enim ad minim veniam, quis nostrud exerci tation ullamcorper suscipit lobortis nisl ut aliquip ex ea co"
@@ -26,40 +32,47 @@ func TestSaveHandler(t *testing.T) {
 		gender    string
 		birthdate string
 		respError string
+		status    int
 		mockError error
 	}{
 		{
 			name:      "Success update name",
 			actorId:   1,
 			actorName: "Nikita",
+			status:    http.StatusOK,
 		},
 		{
 			name:      "Invalid name",
 			actorId:   1,
 			actorName: bigName,
 			respError: "field name is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:    "Success update gender",
 			actorId: 1,
 			gender:  "male",
+			status:  http.StatusOK,
 		},
 		{
 			name:      "Invalid gender",
 			actorId:   1,
 			gender:    "abc",
 			respError: "field gender is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "Success update birthdate",
 			actorId:   1,
 			birthdate: "2000-01-01",
+			status:    http.StatusOK,
 		},
 		{
 			name:      "Invalid birthdate",
 			actorId:   1,
 			birthdate: "1900.01.01",
 			respError: "field birthdate is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "Success update all",
@@ -67,11 +80,35 @@ func TestSaveHandler(t *testing.T) {
 			actorName: "Nikita",
 			gender:    "male",
 			birthdate: "2000-01-01",
+			status:    http.StatusOK,
 		},
 		{
 			name:      "Invalid actorId",
 			actorId:   0,
 			respError: "field actor_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "No fields",
+			actorId:   1,
+			respError: "no fields to update",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			actorId:   1,
+			actorName: "Nikita",
+			respError: "actor not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.UpdateActor: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "Version mismatch",
+			actorId:   1,
+			actorName: "Nikita",
+			respError: "actor has been changed",
+			status:    http.StatusPreconditionFailed,
+			mockError: fmt.Errorf("storage.postgres.UpdateActor: %w", storage.ErrVersionMismatch),
 		},
 		{
 			name:      "UpdateActor Error",
@@ -79,7 +116,8 @@ func TestSaveHandler(t *testing.T) {
 			actorName: "Nikita",
 			gender:    "male",
 			birthdate: "2000-01-01",
-			respError: "failed to update actor name",
+			respError: "failed to update actor",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -93,15 +131,13 @@ func TestSaveHandler(t *testing.T) {
 			actorUpdaterMock := mocks.NewActorUpdater(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				actorUpdaterMock.On("UpdateActorName", tc.actorId, tc.actorName).
-					Return(tc.mockError).
-					Maybe()
-				actorUpdaterMock.On("UpdateActorGender", tc.actorId, tc.gender).
-					Return(tc.mockError).
-					Maybe()
-				actorUpdaterMock.On("UpdateActorBirthdate", tc.actorId, tc.birthdate).
-					Return(tc.mockError).
-					Maybe()
+				actorUpdaterMock.On("UpdateActor", mock.Anything, tc.actorId, postgres.ActorPatch{
+					Name:      optional(tc.actorName),
+					Gender:    optional(tc.gender),
+					Birthdate: optional(tc.birthdate),
+				}, 0).
+					Return(2, tc.mockError).
+					Once()
 			}
 
 			handler := update.New(slogdiscard.NewDiscardLogger(), actorUpdaterMock)
@@ -126,7 +162,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -138,3 +174,167 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestMergePatch(t *testing.T) {
+	empty := ""
+
+	cases := []struct {
+		name      string
+		body      string
+		patch     postgres.ActorPatch
+		respError string
+		status    int
+	}{
+		{
+			name:   "Success",
+			body:   `{"name": "Nikita"}`,
+			patch:  postgres.ActorPatch{Name: optional("Nikita")},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Null clears profile",
+			body:   `{"death_date": null, "biography": null, "aliases": null, "external_ids": null}`,
+			patch:  postgres.ActorPatch{DeathDate: &empty, Biography: &empty, Aliases: []string{}, ExternalIds: map[string]string{}},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Null birthdate",
+			body:      `{"birthdate": null}`,
+			respError: "field birthdate is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Null member",
+			body:      `{"name": null}`,
+			respError: "field name is not valid",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorUpdaterMock := mocks.NewActorUpdater(t)
+
+			if tc.respError == "" {
+				actorUpdaterMock.On("UpdateActor", mock.Anything, 7, tc.patch, 3).
+					Return(4, nil).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Patch("/api/v1/actors/{actor_id}", update.New(slogdiscard.NewDiscardLogger(), actorUpdaterMock))
+
+			req, err := http.NewRequest(http.MethodPatch, "/api/v1/actors/7", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", request.MergePatchContentType)
+			req.Header.Set("If-Match", `"3"`)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			if tc.respError == "" {
+				require.Equal(t, `"4"`, rr.Header().Get("ETag"))
+			}
+
+			var resp update.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func TestUpdateProfile(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		patch     postgres.ActorPatch
+		respError string
+		status    int
+	}{
+		{
+			name: "Success",
+			body: `{"actor_id": 1, "biography": "Born in Rome", "nationality": "IT", "death_date": "1996-12-19", "aliases": ["Marcello"], "external_ids": {"imdb": "nm0000052"}}`,
+			patch: postgres.ActorPatch{
+				Biography:   optional("Born in Rome"),
+				Nationality: optional("IT"),
+				DeathDate:   optional("1996-12-19"),
+				Aliases:     []string{"Marcello"},
+				ExternalIds: map[string]string{"imdb": "nm0000052"},
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Clear aliases",
+			body:   `{"actor_id": 1, "aliases": []}`,
+			patch:  postgres.ActorPatch{Aliases: []string{}},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Other gender",
+			body:   `{"actor_id": 1, "gender": "other"}`,
+			patch:  postgres.ActorPatch{Gender: optional("other")},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid nationality",
+			body:      `{"actor_id": 1, "nationality": "Italy"}`,
+			respError: "field nationality is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Death before birth",
+			body:      `{"actor_id": 1, "birthdate": "1924-09-28", "death_date": "1900-01-01"}`,
+			respError: "field death_date is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid external id",
+			body:      `{"actor_id": 1, "external_ids": {"imdb": "tt0000052"}}`,
+			respError: "field external_ids is not valid",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			actorUpdaterMock := mocks.NewActorUpdater(t)
+
+			if tc.respError == "" {
+				actorUpdaterMock.On("UpdateActor", mock.Anything, 1, tc.patch, 0).
+					Return(2, nil).
+					Once()
+			}
+
+			handler := update.New(slogdiscard.NewDiscardLogger(), actorUpdaterMock)
+
+			req, err := http.NewRequest(http.MethodPost, "/actor/update", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp update.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,161 @@
+package list
+
+import (
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Request struct {
+	EntityType string `json:"entity_type"`
+	EntityId   int    `json:"entity_id"`
+	UserId     int    `json:"user_id"`
+	From       string `json:"from"`
+	To         string `json:"to"`
+	Limit      int    `json:"limit"`
+	Cursor     string `json:"cursor"`
+	WithTotal  bool   `json:"with_total"`
+}
+
+type Response struct {
+	response.Response
+	Events []postgres.AuditEvent `json:"events"`
+	postgres.PageInfo
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=AuditEventsGetter
+type AuditEventsGetter interface {
+	GetAuditEvents(filter postgres.AuditFilter, page postgres.Page) ([]postgres.AuditEvent, postgres.PageInfo, error)
+}
+
+var entityTypes = map[string]bool{"movie": true, "actor": true, "user": true, "review": true}
+
+// @Summary		List audit events
+// @Description	List changes of movies, actors, users and reviews, most recent first. before and after hold the changed fields
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			entity_type	query		string	false	"movie, actor, user or review"
+// @Param			entity_id	query		int		false	"ID of the entity, with entity_type"
+// @Param			user_id		query		int		false	"ID of the user who made the changes"
+// @Param			from		query		string	false	"RFC 3339 time, inclusive"
+// @Param			to			query		string	false	"RFC 3339 time, exclusive"
+// @Param			limit		query		int		false	"Page size, 50 by default"
+// @Param			cursor		query		string	false	"next_cursor of the previous page"
+// @Param			with_total	query		bool	false	"Return the total count"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		403			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/admin/audit [get]
+func New(log *slog.Logger, auditEventsGetter AuditEventsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.audit.list.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		filter, ok, field, msg := parseFilter(req)
+		if !ok {
+			log.Error("invalid request", field)
+
+			response.ValidationFailed(w, r, field.Value.String(), msg)
+
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > postgres.MaxPageLimit {
+			log.Error("invalid limit", slog.Int("limit", req.Limit))
+
+			response.ValidationFailed(w, r, "limit", "field limit is not valid")
+
+			return
+		}
+
+		events, page, err := auditEventsGetter.GetAuditEvents(filter, postgres.Page{
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+			WithTotal: req.WithTotal,
+		})
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Error("invalid cursor", sl.Err(err))
+
+			response.ValidationFailed(w, r, "cursor", "field cursor is not valid")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("audit events search failed", sl.Err(err))
+
+			response.FromError(w, r, err, "audit events search failed")
+
+			return
+		}
+
+		log.Info("audit events found", slog.Int("events_count", len(events)))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+			events,
+			page,
+		})
+	}
+}
+
+func parseFilter(req Request) (postgres.AuditFilter, bool, slog.Attr, string) {
+	filter := postgres.AuditFilter{
+		EntityType: req.EntityType,
+		EntityId:   req.EntityId,
+		UserId:     req.UserId,
+	}
+
+	if req.EntityType != "" && !entityTypes[req.EntityType] {
+		return filter, false, slog.String("field", "entity_type"), "field entity_type is not valid"
+	}
+	if req.EntityId < 0 || (req.EntityId > 0 && req.EntityType == "") {
+		return filter, false, slog.String("field", "entity_id"), "field entity_id is not valid"
+	}
+	if req.UserId < 0 {
+		return filter, false, slog.String("field", "user_id"), "field user_id is not valid"
+	}
+
+	var err error
+	if req.From != "" {
+		if filter.From, err = time.Parse(time.RFC3339, req.From); err != nil {
+			return filter, false, slog.String("field", "from"), "field from is not valid"
+		}
+	}
+	if req.To != "" {
+		filter.To, err = time.Parse(time.RFC3339, req.To)
+		if err != nil || !filter.To.After(filter.From) {
+			return filter, false, slog.String("field", "to"), "field to is not valid"
+		}
+	}
+
+	return filter, true, slog.Attr{}, ""
+}
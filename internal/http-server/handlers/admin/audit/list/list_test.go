@@ -0,0 +1,128 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/audit/list"
+	"film_library/internal/http-server/handlers/admin/audit/list/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestListHandler(t *testing.T) {
+	from := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name      string
+		query     string
+		filter    postgres.AuditFilter
+		page      postgres.Page
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Entity",
+			query:  "entity_type=movie&entity_id=3",
+			filter: postgres.AuditFilter{EntityType: "movie", EntityId: 3},
+			status: http.StatusOK,
+		},
+		{
+			name:   "User and time range",
+			query:  "user_id=2&from=2024-03-01T00:00:00Z&to=2024-04-01T00:00:00Z&limit=10&cursor=abc",
+			filter: postgres.AuditFilter{UserId: 2, From: from, To: to},
+			page:   postgres.Page{Limit: 10, Cursor: "abc"},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid entity_type",
+			query:     "entity_type=role",
+			respError: "field entity_type is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "entity_id without entity_type",
+			query:     "entity_id=3",
+			respError: "field entity_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid from",
+			query:     "from=2024-03-01",
+			respError: "field from is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "to before from",
+			query:     "from=2024-04-01T00:00:00Z&to=2024-03-01T00:00:00Z",
+			respError: "field to is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid limit",
+			query:     "limit=501",
+			respError: "field limit is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid cursor",
+			query:     "cursor=abc",
+			page:      postgres.Page{Cursor: "abc"},
+			respError: "field cursor is not valid",
+			status:    http.StatusBadRequest,
+			mockError: fmt.Errorf("storage.postgres.GetAuditEvents: %w", storage.ErrInvalidCursor),
+		},
+		{
+			name:      "GetAuditEvents Error",
+			respError: "audit events search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			auditEventsGetterMock := mocks.NewAuditEventsGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				auditEventsGetterMock.On("GetAuditEvents", tc.filter, tc.page).
+					Return([]postgres.AuditEvent{}, postgres.PageInfo{}, tc.mockError).
+					Once()
+			}
+
+			handler := list.New(slogdiscard.NewDiscardLogger(), auditEventsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/admin/audit?"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp list.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
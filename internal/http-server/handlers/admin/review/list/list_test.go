@@ -0,0 +1,86 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/review/list"
+	"film_library/internal/http-server/handlers/admin/review/list/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage/postgres"
+)
+
+func TestListHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		filter    postgres.ReviewFilter
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "All reviews",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Hidden reviews of a user",
+			query:  "?user_id=7&status=hidden",
+			filter: postgres.ReviewFilter{UserId: 7, Status: postgres.ReviewHidden},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid status",
+			query:     "?status=deleted",
+			respError: "field status is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid movie_id",
+			query:     "?movie_id=-1",
+			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "GetReviews Error",
+			respError: "reviews search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewsGetterMock := mocks.NewReviewsGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				reviewsGetterMock.On("GetReviews", tc.filter, postgres.Page{}).
+					Return([]postgres.Review{}, postgres.PageInfo{}, tc.mockError).
+					Once()
+			}
+
+			handler := list.New(slogdiscard.NewDiscardLogger(), reviewsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/admin/reviews"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp list.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
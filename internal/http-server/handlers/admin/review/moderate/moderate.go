@@ -0,0 +1,105 @@
+package moderate
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"slices"
+)
+
+type Request struct {
+	ReviewId int    `json:"review_id"`
+	Status   string `json:"status"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ReviewModerator
+type ReviewModerator interface {
+	ModerateReview(ctx context.Context, reviewId int, status string) error
+}
+
+// @Summary		Moderate a review
+// @Description	Hide a review of any user, which takes its score out of the community score, or publish it again
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			review_id	body		int		true	"Review ID"
+// @Param			status		body		string	true	"published or hidden"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		403			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/admin/reviews/moderate [post]
+func New(log *slog.Logger, reviewModerator ReviewModerator) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.review.moderate.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.ReviewId < 1 {
+			log.Error("invalid request", slog.String("field", "review_id"))
+
+			response.ValidationFailed(w, r, "review_id", "field review_id is not valid")
+
+			return
+		}
+
+		if !slices.Contains(postgres.ReviewStatuses, req.Status) {
+			log.Error("invalid request", slog.String("field", "status"))
+
+			response.ValidationFailed(w, r, "status", "field status is not valid")
+
+			return
+		}
+
+		err = reviewModerator.ModerateReview(r.Context(), req.ReviewId, req.Status)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Error("review not found", slog.Int("review_id", req.ReviewId))
+
+			response.FromError(w, r, err, "review not found")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to moderate review", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to moderate review")
+
+			return
+		}
+
+		log.Info("review moderated", slog.Int("review_id", req.ReviewId), slog.String("status", req.Status))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
@@ -0,0 +1,105 @@
+package moderate_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/review/moderate"
+	"film_library/internal/http-server/handlers/admin/review/moderate/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+)
+
+func TestModerateHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		reviewId  int
+		status    string
+		respError string
+		code      int
+		mockError error
+	}{
+		{
+			name:     "Hide",
+			reviewId: 5,
+			status:   "hidden",
+			code:     http.StatusOK,
+		},
+		{
+			name:     "Publish",
+			reviewId: 5,
+			status:   "published",
+			code:     http.StatusOK,
+		},
+		{
+			name:      "Invalid review_id",
+			reviewId:  0,
+			status:    "hidden",
+			respError: "field review_id is not valid",
+			code:      http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid status",
+			reviewId:  5,
+			status:    "deleted",
+			respError: "field status is not valid",
+			code:      http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			reviewId:  5,
+			status:    "hidden",
+			respError: "review not found",
+			code:      http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.ModerateReview: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "ModerateReview Error",
+			reviewId:  5,
+			status:    "hidden",
+			respError: "failed to moderate review",
+			code:      http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewModeratorMock := mocks.NewReviewModerator(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				reviewModeratorMock.On("ModerateReview", mock.Anything, tc.reviewId, tc.status).
+					Return(tc.mockError).
+					Once()
+			}
+
+			handler := moderate.New(slogdiscard.NewDiscardLogger(), reviewModeratorMock)
+
+			input := fmt.Sprintf(`{"review_id": %d, "status": "%s"}`, tc.reviewId, tc.status)
+
+			req, err := http.NewRequest(http.MethodPost, "/admin/reviews/moderate", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.code, rr.Code)
+
+			var resp moderate.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
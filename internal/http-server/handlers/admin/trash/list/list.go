@@ -0,0 +1,116 @@
+package list
+
+import (
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	EntityType string `json:"entity_type"`
+	Limit      int    `json:"limit"`
+	Cursor     string `json:"cursor"`
+	WithTotal  bool   `json:"with_total"`
+}
+
+type Response struct {
+	response.Response
+	Items []postgres.TrashItem `json:"items"`
+	postgres.PageInfo
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=TrashGetter
+type TrashGetter interface {
+	GetTrash(entityType string, page postgres.Page) ([]postgres.TrashItem, postgres.PageInfo, error)
+}
+
+// @Summary		List trash
+// @Description	List deleted movies and actors that can still be restored, most recently deleted first
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			entity_type	query		string	false	"movie or actor, both by default"
+// @Param			limit		query		int		false	"Page size, 50 by default"
+// @Param			cursor		query		string	false	"next_cursor of the previous page"
+// @Param			with_total	query		bool	false	"Return the total count"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		403			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/admin/trash [get]
+func New(log *slog.Logger, trashGetter TrashGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.trash.list.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.EntityType != "" && req.EntityType != postgres.EntityMovie && req.EntityType != postgres.EntityActor {
+			log.Error("invalid entity_type", slog.String("entity_type", req.EntityType))
+
+			response.ValidationFailed(w, r, "entity_type", "field entity_type is not valid")
+
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > postgres.MaxPageLimit {
+			log.Error("invalid limit", slog.Int("limit", req.Limit))
+
+			response.ValidationFailed(w, r, "limit", "field limit is not valid")
+
+			return
+		}
+
+		items, page, err := trashGetter.GetTrash(req.EntityType, postgres.Page{
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+			WithTotal: req.WithTotal,
+		})
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Error("invalid cursor", sl.Err(err))
+
+			response.ValidationFailed(w, r, "cursor", "field cursor is not valid")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("trash search failed", sl.Err(err))
+
+			response.FromError(w, r, err, "trash search failed")
+
+			return
+		}
+
+		log.Info("trash found", slog.Int("items_count", len(items)))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+			items,
+			page,
+		})
+	}
+}
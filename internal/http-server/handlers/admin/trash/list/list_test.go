@@ -0,0 +1,100 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/trash/list"
+	"film_library/internal/http-server/handlers/admin/trash/list/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestListHandler(t *testing.T) {
+	cases := []struct {
+		name       string
+		query      string
+		entityType string
+		page       postgres.Page
+		respError  string
+		status     int
+		mockError  error
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+		},
+		{
+			name:       "Movies",
+			query:      "entity_type=movie&limit=10&cursor=abc",
+			entityType: "movie",
+			page:       postgres.Page{Limit: 10, Cursor: "abc"},
+			status:     http.StatusOK,
+		},
+		{
+			name:      "Invalid entity_type",
+			query:     "entity_type=user",
+			respError: "field entity_type is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid limit",
+			query:     "limit=-1",
+			respError: "field limit is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid cursor",
+			query:     "cursor=abc",
+			page:      postgres.Page{Cursor: "abc"},
+			respError: "field cursor is not valid",
+			status:    http.StatusBadRequest,
+			mockError: fmt.Errorf("storage.postgres.GetTrash: %w", storage.ErrInvalidCursor),
+		},
+		{
+			name:      "GetTrash Error",
+			respError: "trash search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			trashGetterMock := mocks.NewTrashGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				trashGetterMock.On("GetTrash", tc.entityType, tc.page).
+					Return([]postgres.TrashItem{}, postgres.PageInfo{}, tc.mockError).
+					Once()
+			}
+
+			handler := list.New(slogdiscard.NewDiscardLogger(), trashGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/admin/trash?"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp list.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,108 @@
+package restore
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	EntityType string `json:"entity_type"`
+	EntityId   int    `json:"entity_id"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=Restorer
+type Restorer interface {
+	RestoreMovie(ctx context.Context, movieId int) error
+	RestoreActor(ctx context.Context, actorId int) error
+}
+
+// @Summary		Restore from trash
+// @Description	Restore a deleted movie with its cast, or a deleted actor back into their casts
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			entity_type	body		string	true	"movie or actor"
+// @Param			entity_id	body		int		true	"Movie or actor ID"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		403			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/admin/trash/restore [post]
+func New(log *slog.Logger, restorer Restorer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.trash.restore.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.EntityId < 1 {
+			log.Error("invalid request", slog.String("field", "entity_id"))
+
+			response.ValidationFailed(w, r, "entity_id", "field entity_id is not valid")
+
+			return
+		}
+
+		switch req.EntityType {
+		case postgres.EntityMovie:
+			err = restorer.RestoreMovie(r.Context(), req.EntityId)
+		case postgres.EntityActor:
+			err = restorer.RestoreActor(r.Context(), req.EntityId)
+		default:
+			log.Error("invalid request", slog.String("field", "entity_type"))
+
+			response.ValidationFailed(w, r, "entity_type", "field entity_type is not valid")
+
+			return
+		}
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Error("not found in trash", slog.String("entity_type", req.EntityType), slog.Int("entity_id", req.EntityId))
+
+			response.FromError(w, r, err, req.EntityType+" not found in trash")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to restore", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to restore "+req.EntityType)
+
+			return
+		}
+
+		log.Info("restored", slog.String("entity_type", req.EntityType), slog.Int("entity_id", req.EntityId))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
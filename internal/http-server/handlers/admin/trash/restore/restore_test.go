@@ -0,0 +1,111 @@
+package restore_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/trash/restore"
+	"film_library/internal/http-server/handlers/admin/trash/restore/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+)
+
+func TestRestoreHandler(t *testing.T) {
+	cases := []struct {
+		name       string
+		entityType string
+		entityId   int
+		method     string
+		respError  string
+		status     int
+		mockError  error
+	}{
+		{
+			name:       "Movie",
+			entityType: "movie",
+			entityId:   1,
+			method:     "RestoreMovie",
+			status:     http.StatusOK,
+		},
+		{
+			name:       "Actor",
+			entityType: "actor",
+			entityId:   2,
+			method:     "RestoreActor",
+			status:     http.StatusOK,
+		},
+		{
+			name:       "Invalid entity_type",
+			entityType: "user",
+			entityId:   1,
+			respError:  "field entity_type is not valid",
+			status:     http.StatusBadRequest,
+		},
+		{
+			name:       "Invalid entity_id",
+			entityType: "movie",
+			respError:  "field entity_id is not valid",
+			status:     http.StatusBadRequest,
+		},
+		{
+			name:       "Not in trash",
+			entityType: "movie",
+			entityId:   1,
+			method:     "RestoreMovie",
+			respError:  "movie not found in trash",
+			status:     http.StatusNotFound,
+			mockError:  fmt.Errorf("storage.postgres.RestoreMovie: %w", storage.ErrNotFound),
+		},
+		{
+			name:       "RestoreActor Error",
+			entityType: "actor",
+			entityId:   1,
+			method:     "RestoreActor",
+			respError:  "failed to restore actor",
+			status:     http.StatusInternalServerError,
+			mockError:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			restorerMock := mocks.NewRestorer(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				restorerMock.On(tc.method, mock.Anything, tc.entityId).
+					Return(tc.mockError).
+					Once()
+			}
+
+			handler := restore.New(slogdiscard.NewDiscardLogger(), restorerMock)
+
+			input := fmt.Sprintf(`{"entity_type": "%s", "entity_id": %d}`, tc.entityType, tc.entityId)
+
+			req, err := http.NewRequest(http.MethodPost, "/admin/trash/restore", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp restore.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
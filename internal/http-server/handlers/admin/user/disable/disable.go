@@ -0,0 +1,114 @@
+package disable
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	UserId   int  `json:"user_id"`
+	Disabled bool `json:"disabled"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=UserDisabler
+type UserDisabler interface {
+	SetUserDisabled(ctx context.Context, userId int, disabled bool) error
+}
+
+// @Summary		Disable user
+// @Description	Disable or re-enable a user account. Disabling ends the user's sessions at once. The last enabled admin can not be disabled
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			user_id		body		int		true	"User ID"
+// @Param			disabled	body		bool	true	"Disable or enable"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		403			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		409			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/admin/users/disable [post]
+func New(log *slog.Logger, userDisabler UserDisabler) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.user.disable.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.UserId < 1 {
+			log.Error("invalid request", slog.String("field", "user_id"))
+
+			response.ValidationFailed(w, r, "user_id", "field user_id is not valid")
+
+			return
+		}
+
+		_, claims, _ := jwtauth.FromContext(r.Context())
+		if callerId, ok := token.UserId(claims); ok && callerId == req.UserId && req.Disabled {
+			log.Error("attempt to disable own account", slog.Int("user_id", req.UserId))
+
+			response.Forbidden(w, r, "cannot disable own account")
+
+			return
+		}
+
+		err = userDisabler.SetUserDisabled(r.Context(), req.UserId, req.Disabled)
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Error("user not found", slog.Int("user_id", req.UserId))
+
+			response.FromError(w, r, err, "user not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrLastAdmin) {
+			log.Error("attempt to disable the last admin", slog.Int("user_id", req.UserId))
+
+			response.FromError(w, r, err, "cannot disable the last admin")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to update user", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to update user")
+
+			return
+		}
+
+		log.Info("user updated", slog.Int("user_id", req.UserId), slog.Bool("disabled", req.Disabled))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
@@ -0,0 +1,129 @@
+package disable_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/user/disable"
+	"film_library/internal/http-server/handlers/admin/user/disable/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestDisableHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		userId    int
+		disabled  bool
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:     "Success",
+			userId:   2,
+			disabled: true,
+			status:   http.StatusOK,
+		},
+		{
+			name:   "Enable",
+			userId: 2,
+			status: http.StatusOK,
+		},
+		{
+			name:      "Disable self",
+			userId:    7,
+			disabled:  true,
+			respError: "cannot disable own account",
+			status:    http.StatusForbidden,
+		},
+		{
+			name:      "Invalid user_id",
+			userId:    0,
+			disabled:  true,
+			respError: "field user_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "User not found",
+			userId:    2,
+			disabled:  true,
+			respError: "user not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.SetUserDisabled: %w", storage.ErrUserNotFound),
+		},
+		{
+			name:      "Last admin",
+			userId:    2,
+			disabled:  true,
+			respError: "cannot disable the last admin",
+			status:    http.StatusConflict,
+			mockError: fmt.Errorf("storage.postgres.SetUserDisabled: %w", storage.ErrLastAdmin),
+		},
+		{
+			name:      "SetUserDisabled Error",
+			userId:    2,
+			disabled:  true,
+			respError: "failed to update user",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			userDisablerMock := mocks.NewUserDisabler(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				userDisablerMock.On("SetUserDisabled", mock.Anything, tc.userId, tc.disabled).
+					Return(tc.mockError).
+					Once()
+			}
+
+			handler := disable.New(slogdiscard.NewDiscardLogger(), userDisablerMock)
+
+			input := fmt.Sprintf(`{"user_id": %d, "disabled": %t}`, tc.userId, tc.disabled)
+
+			req, err := http.NewRequest(http.MethodPost, "/admin/users/disable", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, []string{"admin"}, []string{"user:manage"})
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			body := rr.Body.String()
+
+			var resp disable.Response
+
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package force_password_reset
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	UserId int `json:"user_id"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=PasswordResetForcer
+type PasswordResetForcer interface {
+	ForcePasswordReset(ctx context.Context, userId int) error
+}
+
+// @Summary		Force password reset
+// @Description	Require the user to change the password before the next signin and end the user's sessions
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			user_id	body		int	true	"User ID"
+// @Success		200		{object}	Response
+// @Failure		400		{object}	response.Response
+// @Failure		401		{object}	response.Response
+// @Failure		403		{object}	response.Response
+// @Failure		404		{object}	response.Response
+// @Failure		500		{object}	response.Response
+// @Router			/admin/users/force_password_reset [post]
+func New(log *slog.Logger, passwordResetForcer PasswordResetForcer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.user.force_password_reset.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.UserId < 1 {
+			log.Error("invalid request", slog.String("field", "user_id"))
+
+			response.ValidationFailed(w, r, "user_id", "field user_id is not valid")
+
+			return
+		}
+
+		err = passwordResetForcer.ForcePasswordReset(r.Context(), req.UserId)
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Error("user not found", slog.Int("user_id", req.UserId))
+
+			response.FromError(w, r, err, "user not found")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to force password reset", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to force password reset")
+
+			return
+		}
+
+		log.Info("password reset forced", slog.Int("user_id", req.UserId))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
@@ -0,0 +1,91 @@
+package force_password_reset_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/user/force_password_reset"
+	"film_library/internal/http-server/handlers/admin/user/force_password_reset/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+)
+
+func TestForcePasswordResetHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		userId    int
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			userId: 2,
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid user_id",
+			userId:    -1,
+			respError: "field user_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "User not found",
+			userId:    2,
+			respError: "user not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.ForcePasswordReset: %w", storage.ErrUserNotFound),
+		},
+		{
+			name:      "ForcePasswordReset Error",
+			userId:    2,
+			respError: "failed to force password reset",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			passwordResetForcerMock := mocks.NewPasswordResetForcer(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				passwordResetForcerMock.On("ForcePasswordReset", mock.Anything, tc.userId).
+					Return(tc.mockError).
+					Once()
+			}
+
+			handler := force_password_reset.New(slogdiscard.NewDiscardLogger(), passwordResetForcerMock)
+
+			input := fmt.Sprintf(`{"user_id": %d}`, tc.userId)
+
+			req, err := http.NewRequest(http.MethodPost, "/admin/users/force_password_reset", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			body := rr.Body.String()
+
+			var resp force_password_reset.Response
+
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
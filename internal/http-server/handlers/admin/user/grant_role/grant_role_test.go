@@ -0,0 +1,110 @@
+package grant_role_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/user/grant_role"
+	"film_library/internal/http-server/handlers/admin/user/grant_role/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+)
+
+func TestGrantRoleHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		userId    int
+		role      string
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			userId: 2,
+			role:   "admin",
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid user_id",
+			userId:    0,
+			role:      "admin",
+			respError: "field user_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Empty role",
+			userId:    2,
+			respError: "field role is required",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "User not found",
+			userId:    2,
+			role:      "admin",
+			respError: "user not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.GrantRole: %w", storage.ErrUserNotFound),
+		},
+		{
+			name:      "Role not found",
+			userId:    2,
+			role:      "owner",
+			respError: "role not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.GrantRole: %w", storage.ErrRoleNotFound),
+		},
+		{
+			name:      "GrantRole Error",
+			userId:    2,
+			role:      "admin",
+			respError: "failed to grant role",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			roleGranterMock := mocks.NewRoleGranter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				roleGranterMock.On("GrantRole", mock.Anything, tc.userId, tc.role).
+					Return(tc.mockError).
+					Once()
+			}
+
+			handler := grant_role.New(slogdiscard.NewDiscardLogger(), roleGranterMock)
+
+			input := fmt.Sprintf(`{"user_id": %d, "role": "%s"}`, tc.userId, tc.role)
+
+			req, err := http.NewRequest(http.MethodPost, "/admin/users/roles/grant", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			body := rr.Body.String()
+
+			var resp grant_role.Response
+
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
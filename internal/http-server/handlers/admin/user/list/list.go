@@ -0,0 +1,78 @@
+package list
+
+import (
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	Query string `json:"query"`
+}
+
+type Response struct {
+	response.Response
+	Users []postgres.User `json:"users"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=UsersGetter
+type UsersGetter interface {
+	GetUsers(query string) ([]postgres.User, error)
+}
+
+// @Summary		List users
+// @Description	List users with their roles, optionally filtered by a part of the username
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			query	query		string	false	"Part of username"
+// @Success		200		{object}	Response
+// @Failure		400		{object}	response.Response
+// @Failure		401		{object}	response.Response
+// @Failure		403		{object}	response.Response
+// @Failure		500		{object}	response.Response
+// @Router			/admin/users [get]
+func New(log *slog.Logger, usersGetter UsersGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.user.list.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		users, err := usersGetter.GetUsers(req.Query)
+		if err != nil {
+			log.Error("users search failed", sl.Err(err))
+
+			response.FromError(w, r, err, "users search failed")
+
+			return
+		}
+
+		log.Info("users found", slog.Int("users_count", len(users)))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+			users,
+		})
+	}
+}
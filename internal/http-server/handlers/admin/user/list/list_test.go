@@ -0,0 +1,86 @@
+package list_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/user/list"
+	"film_library/internal/http-server/handlers/admin/user/list/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage/postgres"
+)
+
+func TestListHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		users     []postgres.User
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			query:  "adm",
+			users:  []postgres.User{{Id: 1, Username: "admin", Roles: []string{"admin", "user"}}},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Empty query",
+			users:  []postgres.User{{Id: 1, Username: "admin"}, {Id: 2, Username: "bob", Disabled: true}},
+			status: http.StatusOK,
+		},
+		{
+			name:      "GetUsers Error",
+			query:     "adm",
+			respError: "users search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			usersGetterMock := mocks.NewUsersGetter(t)
+
+			usersGetterMock.On("GetUsers", tc.query).
+				Return(tc.users, tc.mockError).
+				Once()
+
+			handler := list.New(slogdiscard.NewDiscardLogger(), usersGetterMock)
+
+			input := fmt.Sprintf(`{"query": "%s"}`, tc.query)
+
+			req, err := http.NewRequest(http.MethodGet, "/admin/users", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			body := rr.Body.String()
+
+			var resp list.Response
+
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.Equal(t, tc.users, resp.Users)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+package revoke_role
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	UserId int    `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=RoleRevoker
+type RoleRevoker interface {
+	RevokeRole(ctx context.Context, userId int, role string) error
+}
+
+// @Summary		Revoke role
+// @Description	Revoke a role from a user. Takes effect at once, the user's access tokens are revoked. The last enabled admin keeps the admin role
+// @Tags			Admin
+// @Accept			json
+// @Produce		json
+// @Param			user_id	body		int		true	"User ID"
+// @Param			role	body		string	true	"Role"
+// @Success		200		{object}	Response
+// @Failure		400		{object}	response.Response
+// @Failure		401		{object}	response.Response
+// @Failure		403		{object}	response.Response
+// @Failure		404		{object}	response.Response
+// @Failure		409		{object}	response.Response
+// @Failure		500		{object}	response.Response
+// @Router			/admin/users/roles/revoke [post]
+func New(log *slog.Logger, roleRevoker RoleRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.admin.user.revoke_role.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if ok, field, msg := validateRequest(req); !ok {
+			log.Error("invalid request", field)
+
+			response.ValidationFailed(w, r, field.Value.String(), msg)
+
+			return
+		}
+
+		// An admin removing their own admin role could leave nobody able to
+		// manage users.
+		_, claims, _ := jwtauth.FromContext(r.Context())
+		if callerId, ok := token.UserId(claims); ok && callerId == req.UserId && req.Role == postgres.RoleAdmin {
+			log.Error("attempt to revoke own admin role", slog.Int("user_id", req.UserId))
+
+			response.Forbidden(w, r, "cannot revoke own admin role")
+
+			return
+		}
+
+		err = roleRevoker.RevokeRole(r.Context(), req.UserId, req.Role)
+		if errors.Is(err, storage.ErrUserNotFound) {
+			log.Error("user not found", slog.Int("user_id", req.UserId))
+
+			response.FromError(w, r, err, "user not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrRoleNotFound) {
+			log.Error("role not found", slog.String("role", req.Role))
+
+			response.FromError(w, r, err, "role not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrLastAdmin) {
+			log.Error("attempt to revoke the last admin", slog.Int("user_id", req.UserId))
+
+			response.FromError(w, r, err, "cannot revoke the last admin")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to revoke role", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to revoke role")
+
+			return
+		}
+
+		log.Info("role revoked", slog.Int("user_id", req.UserId), slog.String("role", req.Role))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
+
+func validateRequest(req Request) (bool, slog.Attr, string) {
+	if req.UserId < 1 {
+		return false, slog.String("field", "user_id"), "field user_id is not valid"
+	}
+	if req.Role == "" {
+		return false, slog.String("field", "role"), "field role is required"
+	}
+	return true, slog.Attr{}, ""
+}
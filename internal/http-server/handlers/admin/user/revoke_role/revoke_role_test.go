@@ -0,0 +1,130 @@
+package revoke_role_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/admin/user/revoke_role"
+	"film_library/internal/http-server/handlers/admin/user/revoke_role/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestRevokeRoleHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		userId    int
+		role      string
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			userId: 2,
+			role:   "admin",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Own non admin role",
+			userId: 7,
+			role:   "user",
+			status: http.StatusOK,
+		},
+		{
+			name:      "Own admin role",
+			userId:    7,
+			role:      "admin",
+			respError: "cannot revoke own admin role",
+			status:    http.StatusForbidden,
+		},
+		{
+			name:      "Invalid user_id",
+			userId:    -1,
+			role:      "admin",
+			respError: "field user_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "User not found",
+			userId:    2,
+			role:      "admin",
+			respError: "user not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.RevokeRole: %w", storage.ErrUserNotFound),
+		},
+		{
+			name:      "Last admin",
+			userId:    2,
+			role:      "admin",
+			respError: "cannot revoke the last admin",
+			status:    http.StatusConflict,
+			mockError: fmt.Errorf("storage.postgres.RevokeRole: %w", storage.ErrLastAdmin),
+		},
+		{
+			name:      "RevokeRole Error",
+			userId:    2,
+			role:      "admin",
+			respError: "failed to revoke role",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			roleRevokerMock := mocks.NewRoleRevoker(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				roleRevokerMock.On("RevokeRole", mock.Anything, tc.userId, tc.role).
+					Return(tc.mockError).
+					Once()
+			}
+
+			handler := revoke_role.New(slogdiscard.NewDiscardLogger(), roleRevokerMock)
+
+			input := fmt.Sprintf(`{"user_id": %d, "role": "%s"}`, tc.userId, tc.role)
+
+			req, err := http.NewRequest(http.MethodPost, "/admin/users/roles/revoke", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, []string{"admin"}, []string{"user:manage"})
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			body := rr.Body.String()
+
+			var resp revoke_role.Response
+
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
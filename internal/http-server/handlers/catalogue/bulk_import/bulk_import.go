@@ -0,0 +1,110 @@
+package bulk_import
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/catalogue"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"mime"
+	"net/http"
+	"time"
+)
+
+type Response struct {
+	response.Response
+	catalogue.Report
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=CatalogueImporter
+type CatalogueImporter interface {
+	ImportBatch(ctx context.Context, rows []postgres.ImportRow) ([]postgres.ImportResult, error)
+}
+
+var contentTypeFormats = map[string]string{
+	"text/csv":             catalogue.FormatCSV,
+	"application/x-ndjson": catalogue.FormatNDJSON,
+	"application/jsonl":    catalogue.FormatNDJSON,
+	"application/json":     catalogue.FormatJSON,
+}
+
+// @Summary		Import movies and actors
+// @Description	Create or update movies and actors from a CSV, NDJSON or JSON array body. Actors are matched by name and birthdate, movies by title and release date, cast members by name, so actors should come before the movies they play in. CSV takes a header row naming the columns type, name, gender, birthdate, title, description, release_date, rating and cast, with cast names separated by |. Rows that fail are listed in errors and the others are imported
+// @Tags			Catalogue
+// @Accept			text/csv,application/x-ndjson,json
+// @Produce		json
+// @Param			format	query		string	false	"csv, ndjson or json, taken from Content-Type by default"
+// @Success		200		{object}	Response
+// @Failure		400		{object}	Response
+// @Failure		401		{object}	response.Response
+// @Failure		403		{object}	response.Response
+// @Failure		500		{object}	Response
+// @Router			/import [post]
+func New(log *slog.Logger, importer CatalogueImporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.catalogue.bulk_import.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+			format = contentTypeFormats[mediaType]
+		}
+		if format != catalogue.FormatCSV && format != catalogue.FormatNDJSON && format != catalogue.FormatJSON {
+			log.Error("invalid format", slog.String("format", format))
+
+			response.ValidationFailed(w, r, "format", "field format is not valid")
+
+			return
+		}
+
+		// a large import outlasts the server timeouts, which are meant for
+		// the usual small requests
+		rc := http.NewResponseController(w)
+		_ = rc.SetReadDeadline(time.Time{})
+		_ = rc.SetWriteDeadline(time.Time{})
+
+		report, err := catalogue.Import(r.Context(), r.Body, format, importer, catalogue.DefaultBatchSize)
+		if errors.Is(err, catalogue.ErrMalformed) {
+			log.Error("malformed import", sl.Err(err), slog.Int("created", report.Created), slog.Int("updated", report.Updated))
+
+			response.Render(w, r, http.StatusBadRequest, Response{
+				response.ErrorWithCode(response.CodeBadRequest, "failed to read import"),
+				report,
+			})
+
+			return
+		}
+
+		if err != nil {
+			log.Error("import failed", sl.Err(err), slog.Int("created", report.Created), slog.Int("updated", report.Updated))
+
+			response.Render(w, r, http.StatusInternalServerError, Response{
+				response.ErrorWithCode(response.CodeInternal, "import failed"),
+				report,
+			})
+
+			return
+		}
+
+		log.Info("catalogue imported",
+			slog.Int("created", report.Created),
+			slog.Int("updated", report.Updated),
+			slog.Int("unchanged", report.Unchanged),
+			slog.Int("failed", report.Failed),
+		)
+
+		render.JSON(w, r, Response{
+			response.OK(),
+			report,
+		})
+	}
+}
@@ -0,0 +1,119 @@
+package bulk_import_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/catalogue/bulk_import"
+	"film_library/internal/http-server/handlers/catalogue/bulk_import/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage/postgres"
+)
+
+func TestImportHandler(t *testing.T) {
+	cases := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+		created     int
+		failed      int
+		respError   string
+		status      int
+		mockError   error
+	}{
+		{
+			name:        "CSV",
+			contentType: "text/csv; charset=utf-8",
+			body:        "type,name,gender,birthdate\nactor,Marlon Brando,male,1924-04-03\nactor,Al Pacino,,1940-04-25\n",
+			created:     1,
+			failed:      1,
+			status:      http.StatusOK,
+		},
+		{
+			name:    "NDJSON by query",
+			query:   "?format=ndjson",
+			body:    `{"type":"actor","name":"Marlon Brando","gender":"male","birthdate":"1924-04-03"}`,
+			created: 1,
+			status:  http.StatusOK,
+		},
+		{
+			name:        "JSON",
+			contentType: "application/json",
+			body:        `[{"type":"actor","name":"Marlon Brando","gender":"male","birthdate":"1924-04-03"}]`,
+			created:     1,
+			status:      http.StatusOK,
+		},
+		{
+			name:        "Unknown format",
+			contentType: "text/plain",
+			body:        "Marlon Brando",
+			respError:   "field format is not valid",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "Malformed",
+			contentType: "application/json",
+			body:        `{"type":"actor"}`,
+			respError:   "failed to read import",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "ImportBatch Error",
+			contentType: "text/csv",
+			body:        "type,name,gender,birthdate\nactor,Marlon Brando,male,1924-04-03\n",
+			respError:   "import failed",
+			status:      http.StatusInternalServerError,
+			mockError:   errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			importerMock := mocks.NewCatalogueImporter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				var results []postgres.ImportResult
+				if tc.mockError == nil {
+					results = []postgres.ImportResult{{Id: 1, Action: postgres.ImportCreated}}
+				}
+				importerMock.On("ImportBatch", mock.Anything, mock.MatchedBy(func(rows []postgres.ImportRow) bool {
+					return len(rows) == 1 && rows[0].Actor.Name == "Marlon Brando"
+				})).
+					Return(results, tc.mockError).
+					Once()
+			}
+
+			handler := bulk_import.New(slogdiscard.NewDiscardLogger(), importerMock)
+
+			req, err := http.NewRequest(http.MethodPost, "/import"+tc.query, strings.NewReader(tc.body))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", tc.contentType)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp bulk_import.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			require.Equal(t, tc.created, resp.Created)
+			require.Equal(t, tc.failed, resp.Failed)
+			require.Len(t, resp.Errors, tc.failed)
+		})
+	}
+}
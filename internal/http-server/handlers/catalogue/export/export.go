@@ -0,0 +1,103 @@
+package export
+
+import (
+	"context"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/catalogue"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Request struct {
+	Format string `json:"format"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=CatalogueExporter
+type CatalogueExporter interface {
+	ExportCatalogue(ctx context.Context, fn func(row postgres.ExportRow) error) error
+}
+
+var contentTypes = map[string]string{
+	catalogue.FormatCSV:    "text/csv; charset=utf-8",
+	catalogue.FormatNDJSON: "application/x-ndjson",
+	catalogue.FormatJSON:   "application/json",
+}
+
+// @Summary		Export the catalogue
+// @Description	Stream every actor with the titles of their movies and then every movie with the names of its cast, in the format POST /import reads back. Movies and actors in the trash are left out
+// @Tags			Catalogue
+// @Produce		json,text/csv,application/x-ndjson
+// @Param			format	query		string	false	"json (default), ndjson or csv"
+// @Success		200		{array}		catalogue.Record
+// @Failure		400		{object}	response.Response
+// @Failure		401		{object}	response.Response
+// @Failure		403		{object}	response.Response
+// @Failure		500		{object}	response.Response
+// @Router			/export [get]
+func New(log *slog.Logger, exporter CatalogueExporter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.catalogue.export.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		if req.Format == "" {
+			req.Format = catalogue.FormatJSON
+		}
+
+		contentType, ok := contentTypes[req.Format]
+		if !ok {
+			log.Error("invalid format", slog.String("format", req.Format))
+
+			response.ValidationFailed(w, r, "format", "field format is not valid")
+
+			return
+		}
+
+		// the export outlasts the server timeouts, which are meant for the
+		// usual small requests
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+		ww := middleware.NewWrapResponseWriter(w, r.ProtoMajor)
+		ww.Header().Set("Content-Type", contentType)
+		ww.Header().Set("Content-Disposition", `attachment; filename="catalogue.`+req.Format+`"`)
+
+		err = catalogue.Export(r.Context(), ww, req.Format, exporter)
+		if err != nil && ww.BytesWritten() == 0 {
+			log.Error("export failed", sl.Err(err))
+
+			ww.Header().Del("Content-Disposition")
+			response.FromError(ww, r, err, "export failed")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("export failed midway", sl.Err(err), slog.Int("bytes_written", ww.BytesWritten()))
+
+			// the status is gone, breaking the connection is the only way
+			// left to tell the client that the export is incomplete
+			panic(http.ErrAbortHandler)
+		}
+
+		log.Info("catalogue exported", slog.String("format", req.Format), slog.Int("bytes_written", ww.BytesWritten()))
+	}
+}
@@ -0,0 +1,137 @@
+package export_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/catalogue/export"
+	"film_library/internal/http-server/handlers/catalogue/export/mocks"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage/postgres"
+)
+
+func exportActor(ctx context.Context, fn func(row postgres.ExportRow) error) error {
+	return fn(postgres.ExportRow{Actor: &postgres.ExportActor{Name: "Marlon Brando", Gender: "male", Birthdate: "1924-04-03"}})
+}
+
+func TestExportHandler(t *testing.T) {
+	cases := []struct {
+		name        string
+		format      string
+		contentType string
+		filename    string
+		body        string
+		respError   string
+		status      int
+		mockError   error
+	}{
+		{
+			name:        "JSON by default",
+			contentType: "application/json",
+			filename:    "catalogue.json",
+			body:        "[\n" + `{"type":"actor","name":"Marlon Brando","gender":"male","birthdate":"1924-04-03"}` + "\n]\n",
+			status:      http.StatusOK,
+		},
+		{
+			name:        "NDJSON",
+			format:      "ndjson",
+			contentType: "application/x-ndjson",
+			filename:    "catalogue.ndjson",
+			body:        `{"type":"actor","name":"Marlon Brando","gender":"male","birthdate":"1924-04-03"}` + "\n",
+			status:      http.StatusOK,
+		},
+		{
+			name:        "CSV",
+			format:      "csv",
+			contentType: "text/csv; charset=utf-8",
+			filename:    "catalogue.csv",
+			body:        "type,name,gender,birthdate,movies,title,description,release_date,rating,cast,genres,countries,runtime,original_language,certification,tags,biography,birthplace,nationality,death_date,aliases,external_ids,credits\nactor,Marlon Brando,male,1924-04-03,,,,,,,,,,,,,,,,,,,\n",
+			status:      http.StatusOK,
+		},
+		{
+			name:      "Invalid format",
+			format:    "xml",
+			respError: "field format is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "ExportCatalogue Error",
+			format:    "csv",
+			respError: "export failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			exporterMock := mocks.NewCatalogueExporter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				var ret interface{} = exportActor
+				if tc.mockError != nil {
+					ret = tc.mockError
+				}
+				exporterMock.On("ExportCatalogue", mock.Anything, mock.Anything).
+					Return(ret).
+					Once()
+			}
+
+			handler := export.New(slogdiscard.NewDiscardLogger(), exporterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/export?format="+tc.format, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			if tc.respError != "" {
+				var resp response.Response
+
+				require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+				require.Equal(t, tc.respError, resp.Error)
+				require.Empty(t, rr.Header().Get("Content-Disposition"))
+
+				return
+			}
+
+			require.Equal(t, tc.contentType, rr.Header().Get("Content-Type"))
+			require.Equal(t, `attachment; filename="`+tc.filename+`"`, rr.Header().Get("Content-Disposition"))
+			require.Equal(t, tc.body, rr.Body.String())
+		})
+	}
+}
+
+func TestExportFailsMidway(t *testing.T) {
+	exporterMock := mocks.NewCatalogueExporter(t)
+
+	exporterMock.On("ExportCatalogue", mock.Anything, mock.Anything).
+		Return(func(ctx context.Context, fn func(row postgres.ExportRow) error) error {
+			_ = exportActor(ctx, fn)
+			return errors.New("connection lost")
+		}).
+		Once()
+
+	handler := export.New(slogdiscard.NewDiscardLogger(), exporterMock)
+
+	req, err := http.NewRequest(http.MethodGet, "/export?format=ndjson", nil)
+	require.NoError(t, err)
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	})
+}
@@ -0,0 +1,143 @@
+package add_item
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Request struct {
+	ListId    int    `json:"list_id"`
+	MovieId   int    `json:"movie_id"`
+	WatchedAt string `json:"watched_at"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListItemAdder
+type ListItemAdder interface {
+	AddListItem(ctx context.Context, listId int, userId int, movieId int, watchedAt string) error
+}
+
+// @Summary		Add a movie to a list
+// @Description	Append a movie to an own list. A movie already on the list keeps its position. watched_at is accepted by the watched list only and defaults to today
+// @Tags			List
+// @Accept			json
+// @Produce		json
+// @Param			list_id		path		int		true	"List ID"
+// @Param			movie_id	body		int		true	"Movie ID"
+// @Param			watched_at	body		string	false	"Day the movie was watched, YYYY-MM-DD"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/lists/{list_id}/items [post]
+func New(log *slog.Logger, listItemAdder ListItemAdder) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.list.add_item.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if ok, field, msg := validateRequest(req); !ok {
+			log.Error("invalid request", field)
+
+			response.ValidationFailed(w, r, field.Value.String(), msg)
+
+			return
+		}
+
+		err = listItemAdder.AddListItem(r.Context(), req.ListId, userId, req.MovieId, req.WatchedAt)
+		var constraintErr *storage.ConstraintError
+		if errors.As(err, &constraintErr) && constraintErr.Field == "movie_id" {
+			log.Warn("movie not found", slog.Int("movie_id", req.MovieId))
+
+			response.FromError(w, r, err, "movie not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("list not found", slog.Int("list_id", req.ListId))
+
+			response.FromError(w, r, err, "list not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrInvalidValue) {
+			log.Warn("watched_at on a list other than watched", slog.Int("list_id", req.ListId))
+
+			response.FromError(w, r, err, "field watched_at is not valid")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to add movie to list", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to add movie to list")
+
+			return
+		}
+
+		log.Info("movie added to list", slog.Int("list_id", req.ListId), slog.Int("movie_id", req.MovieId))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+		})
+	}
+}
+
+func validateRequest(req Request) (bool, slog.Attr, string) {
+	if req.ListId < 1 {
+		return false, slog.String("field", "list_id"), "field list_id is not valid"
+	}
+	if req.MovieId < 1 {
+		return false, slog.String("field", "movie_id"), "field movie_id is not valid"
+	}
+	if req.WatchedAt != "" {
+		if _, err := time.Parse("2006-01-02", req.WatchedAt); err != nil {
+			return false, slog.String("field", "watched_at"), "field watched_at is not valid"
+		}
+	}
+	return true, slog.Attr{}, ""
+}
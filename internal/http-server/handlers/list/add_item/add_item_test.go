@@ -0,0 +1,131 @@
+package add_item_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	addItem "film_library/internal/http-server/handlers/list/add_item"
+	"film_library/internal/http-server/handlers/list/add_item/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestAddItemHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		movieId   int
+		watchedAt string
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:    "Success",
+			movieId: 1,
+			status:  http.StatusOK,
+		},
+		{
+			name:      "Watched",
+			movieId:   1,
+			watchedAt: "2024-05-01",
+			status:    http.StatusOK,
+		},
+		{
+			name:      "Invalid movie_id",
+			movieId:   0,
+			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid watched_at",
+			movieId:   1,
+			watchedAt: "yesterday",
+			respError: "field watched_at is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "watched_at on a watchlist",
+			movieId:   1,
+			watchedAt: "2024-05-01",
+			respError: "field watched_at is not valid",
+			status:    http.StatusBadRequest,
+			mockError: fmt.Errorf("storage.postgres.AddListItem: %w", &storage.ConstraintError{Field: "watched_at", Err: storage.ErrInvalidValue}),
+		},
+		{
+			name:      "Movie not found",
+			movieId:   1,
+			respError: "movie not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.AddListItem: %w", &storage.ConstraintError{Field: "movie_id", Err: storage.ErrNotFound}),
+		},
+		{
+			name:      "List not found",
+			movieId:   1,
+			respError: "list not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.AddListItem: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "AddListItem Error",
+			movieId:   1,
+			respError: "failed to add movie to list",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listItemAdderMock := mocks.NewListItemAdder(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listItemAdderMock.On("AddListItem", mock.Anything, 2, 7, tc.movieId, tc.watchedAt).
+					Return(tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Post("/api/v1/lists/{list_id}/items", addItem.New(slogdiscard.NewDiscardLogger(), listItemAdderMock))
+
+			input := fmt.Sprintf(`{"movie_id": %d, "watched_at": "%s"}`, tc.movieId, tc.watchedAt)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/lists/2/items", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp addItem.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
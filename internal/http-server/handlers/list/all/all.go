@@ -0,0 +1,69 @@
+package all
+
+import (
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Response struct {
+	response.Response
+	Lists []postgres.List `json:"lists"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListsGetter
+type ListsGetter interface {
+	GetLists(userId int) ([]postgres.List, error)
+}
+
+// @Summary		Get own lists
+// @Description	Get the watchlist, favourites, watched and custom lists of the caller
+// @Tags			List
+// @Accept			json
+// @Produce		json
+// @Success		200	{object}	Response
+// @Failure		401	{object}	response.Response
+// @Failure		500	{object}	response.Response
+// @Router			/api/v1/lists [get]
+func New(log *slog.Logger, listsGetter ListsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.list.all.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		lists, err := listsGetter.GetLists(userId)
+		if err != nil {
+			log.Error("lists search failed", sl.Err(err))
+
+			response.FromError(w, r, err, "lists search failed")
+
+			return
+		}
+
+		log.Info("lists found", slog.Int("lists_count", len(lists)))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+			lists,
+		})
+	}
+}
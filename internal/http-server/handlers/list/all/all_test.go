@@ -0,0 +1,91 @@
+package all_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/list/all"
+	"film_library/internal/http-server/handlers/list/all/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage/postgres"
+)
+
+func TestAllHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		noToken   bool
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+		},
+		{
+			name:      "No token",
+			noToken:   true,
+			respError: "unauthorized",
+			status:    http.StatusUnauthorized,
+		},
+		{
+			name:      "GetLists Error",
+			respError: "lists search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listsGetterMock := mocks.NewListsGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listsGetterMock.On("GetLists", 7).
+					Return([]postgres.List{{Id: 1, Kind: postgres.ListWatchlist, Name: "Watchlist"}}, tc.mockError).
+					Once()
+			}
+
+			handler := all.New(slogdiscard.NewDiscardLogger(), listsGetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/lists", nil)
+			require.NoError(t, err)
+
+			if !tc.noToken {
+				accessToken, err := tokens.IssueAccess(7, nil, nil)
+				require.NoError(t, err)
+
+				parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+				require.NoError(t, err)
+
+				req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp all.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			if tc.respError == "" {
+				require.Len(t, resp.Lists, 1)
+			}
+		})
+	}
+}
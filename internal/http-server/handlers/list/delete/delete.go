@@ -0,0 +1,115 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	ListId int `json:"list_id"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListDeleter
+type ListDeleter interface {
+	DeleteList(ctx context.Context, listId int, userId int) error
+}
+
+// @Summary		Delete a list
+// @Description	Delete an own custom list with its items. The watchlist, favourites and watched lists cannot be deleted
+// @Tags			List
+// @Accept			json
+// @Produce		json
+// @Param			list_id	path		int	true	"List ID"
+// @Success		200		{object}	Response
+// @Failure		400		{object}	response.Response
+// @Failure		401		{object}	response.Response
+// @Failure		404		{object}	response.Response
+// @Failure		409		{object}	response.Response
+// @Failure		500		{object}	response.Response
+// @Router			/api/v1/lists/{list_id} [delete]
+func New(log *slog.Logger, listDeleter ListDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.list.delete.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.ListId < 1 {
+			log.Error("invalid list_id", slog.Int("list_id", req.ListId))
+
+			response.ValidationFailed(w, r, "list_id", "field list_id is not valid")
+
+			return
+		}
+
+		err = listDeleter.DeleteList(r.Context(), req.ListId, userId)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("list not found", slog.Int("list_id", req.ListId))
+
+			response.FromError(w, r, err, "list not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrConflict) {
+			log.Warn("built-in list", slog.Int("list_id", req.ListId))
+
+			response.FromError(w, r, err, "built-in lists cannot be deleted")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to delete list", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to delete list")
+
+			return
+		}
+
+		log.Info("list deleted", slog.Int("list_id", req.ListId))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+		})
+	}
+}
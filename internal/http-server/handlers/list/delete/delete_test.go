@@ -0,0 +1,106 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/list/delete"
+	"film_library/internal/http-server/handlers/list/delete/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestDeleteHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		listId    int
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			listId: 4,
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid list_id",
+			listId:    0,
+			respError: "field list_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "List of another user",
+			listId:    4,
+			respError: "list not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.DeleteList: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "Built-in list",
+			listId:    4,
+			respError: "built-in lists cannot be deleted",
+			status:    http.StatusConflict,
+			mockError: fmt.Errorf("storage.postgres.DeleteList: %w", &storage.ConstraintError{Field: "list_id", Err: storage.ErrConflict}),
+		},
+		{
+			name:      "DeleteList Error",
+			listId:    4,
+			respError: "failed to delete list",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listDeleterMock := mocks.NewListDeleter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listDeleterMock.On("DeleteList", mock.Anything, tc.listId, 7).
+					Return(tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Delete("/api/v1/lists/{list_id}", delete.New(slogdiscard.NewDiscardLogger(), listDeleterMock))
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/lists/%d", tc.listId), nil)
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp delete.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package items
+
+import (
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	ListId    int    `json:"list_id"`
+	Limit     int    `json:"limit"`
+	Cursor    string `json:"cursor"`
+	WithTotal bool   `json:"with_total"`
+}
+
+type Response struct {
+	response.Response
+	List  postgres.List       `json:"list"`
+	Items []postgres.ListItem `json:"items"`
+	postgres.PageInfo
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListItemsGetter
+type ListItemsGetter interface {
+	GetListItems(listId int, userId int, page postgres.Page) (postgres.List, []postgres.ListItem, postgres.PageInfo, error)
+}
+
+// @Summary		Get list items
+// @Description	Get the movies of an own list in list order
+// @Tags			List
+// @Accept			json
+// @Produce		json
+// @Param			list_id		path		int		true	"List ID"
+// @Param			limit		query		int		false	"Page size, 50 by default"
+// @Param			cursor		query		string	false	"next_cursor of the previous page"
+// @Param			with_total	query		bool	false	"Return the total count"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/lists/{list_id}/items [get]
+func New(log *slog.Logger, listItemsGetter ListItemsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.list.items.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.ListId < 1 {
+			log.Error("invalid list_id", slog.Int("list_id", req.ListId))
+
+			response.ValidationFailed(w, r, "list_id", "field list_id is not valid")
+
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > postgres.MaxPageLimit {
+			log.Error("invalid limit", slog.Int("limit", req.Limit))
+
+			response.ValidationFailed(w, r, "limit", "field limit is not valid")
+
+			return
+		}
+
+		list, items, page, err := listItemsGetter.GetListItems(req.ListId, userId, postgres.Page{
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+			WithTotal: req.WithTotal,
+		})
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Error("invalid cursor", sl.Err(err))
+
+			response.ValidationFailed(w, r, "cursor", "field cursor is not valid")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("list not found", slog.Int("list_id", req.ListId))
+
+			response.FromError(w, r, err, "list not found")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("list items search failed", sl.Err(err))
+
+			response.FromError(w, r, err, "list items search failed")
+
+			return
+		}
+
+		log.Info("list items found", slog.Int("items_count", len(items)))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+			list,
+			items,
+			page,
+		})
+	}
+}
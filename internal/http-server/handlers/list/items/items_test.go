@@ -0,0 +1,126 @@
+package items_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/list/items"
+	"film_library/internal/http-server/handlers/list/items/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestItemsHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		listId    int
+		query     string
+		page      postgres.Page
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			listId: 2,
+			status: http.StatusOK,
+		},
+		{
+			name:   "Page",
+			listId: 2,
+			query:  "?limit=20&cursor=abc",
+			page:   postgres.Page{Limit: 20, Cursor: "abc"},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid list_id",
+			listId:    0,
+			respError: "field list_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid limit",
+			listId:    2,
+			query:     "?limit=-1",
+			respError: "field limit is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid cursor",
+			listId:    2,
+			respError: "field cursor is not valid",
+			status:    http.StatusBadRequest,
+			mockError: fmt.Errorf("storage.postgres.GetListItems: %w", storage.ErrInvalidCursor),
+		},
+		{
+			name:      "Not found",
+			listId:    2,
+			respError: "list not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.GetListItems: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "GetListItems Error",
+			listId:    2,
+			respError: "list items search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listItemsGetterMock := mocks.NewListItemsGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listItemsGetterMock.On("GetListItems", tc.listId, 7, tc.page).
+					Return(postgres.List{Id: tc.listId}, []postgres.ListItem{{MovieId: 1, Position: 1}}, postgres.PageInfo{}, tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Get("/api/v1/lists/{list_id}/items", items.New(slogdiscard.NewDiscardLogger(), listItemsGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/lists/%d/items%s", tc.listId, tc.query), nil)
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp items.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			if tc.respError == "" {
+				require.Equal(t, tc.listId, resp.List.Id)
+				require.Len(t, resp.Items, 1)
+			}
+		})
+	}
+}
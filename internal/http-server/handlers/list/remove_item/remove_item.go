@@ -0,0 +1,116 @@
+package remove_item
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	ListId  int `json:"list_id"`
+	MovieId int `json:"movie_id"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListItemRemover
+type ListItemRemover interface {
+	RemoveListItem(ctx context.Context, listId int, userId int, movieId int) error
+}
+
+// @Summary		Remove a movie from a list
+// @Description	Remove a movie from an own list
+// @Tags			List
+// @Accept			json
+// @Produce		json
+// @Param			list_id		path		int	true	"List ID"
+// @Param			movie_id	path		int	true	"Movie ID"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/lists/{list_id}/items/{movie_id} [delete]
+func New(log *slog.Logger, listItemRemover ListItemRemover) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.list.remove_item.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.ListId < 1 {
+			log.Error("invalid list_id", slog.Int("list_id", req.ListId))
+
+			response.ValidationFailed(w, r, "list_id", "field list_id is not valid")
+
+			return
+		}
+
+		if req.MovieId < 1 {
+			log.Error("invalid movie_id", slog.Int("movie_id", req.MovieId))
+
+			response.ValidationFailed(w, r, "movie_id", "field movie_id is not valid")
+
+			return
+		}
+
+		err = listItemRemover.RemoveListItem(r.Context(), req.ListId, userId, req.MovieId)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("list item not found", slog.Int("list_id", req.ListId), slog.Int("movie_id", req.MovieId))
+
+			response.FromError(w, r, err, "list item not found")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to remove movie from list", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to remove movie from list")
+
+			return
+		}
+
+		log.Info("movie removed from list", slog.Int("list_id", req.ListId), slog.Int("movie_id", req.MovieId))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+		})
+	}
+}
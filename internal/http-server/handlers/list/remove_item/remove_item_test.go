@@ -0,0 +1,105 @@
+package remove_item_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	removeItem "film_library/internal/http-server/handlers/list/remove_item"
+	"film_library/internal/http-server/handlers/list/remove_item/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestRemoveItemHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		path      string
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			path:   "/api/v1/lists/2/items/9",
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid list_id",
+			path:      "/api/v1/lists/0/items/9",
+			respError: "field list_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid movie_id",
+			path:      "/api/v1/lists/2/items/-9",
+			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Not on the list",
+			path:      "/api/v1/lists/2/items/9",
+			respError: "list item not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.RemoveListItem: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "RemoveListItem Error",
+			path:      "/api/v1/lists/2/items/9",
+			respError: "failed to remove movie from list",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listItemRemoverMock := mocks.NewListItemRemover(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listItemRemoverMock.On("RemoveListItem", mock.Anything, 2, 7, 9).
+					Return(tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Delete("/api/v1/lists/{list_id}/items/{movie_id}", removeItem.New(slogdiscard.NewDiscardLogger(), listItemRemoverMock))
+
+			req, err := http.NewRequest(http.MethodDelete, tc.path, nil)
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp removeItem.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
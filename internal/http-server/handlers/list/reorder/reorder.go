@@ -0,0 +1,132 @@
+package reorder
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	ListId   int   `json:"list_id"`
+	MovieIds []int `json:"movie_ids"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListReorderer
+type ListReorderer interface {
+	ReorderList(ctx context.Context, listId int, userId int, movieIds []int) error
+}
+
+// @Summary		Reorder a list
+// @Description	Put the movies of an own list in the given order. movie_ids must name every movie of the list once
+// @Tags			List
+// @Accept			json
+// @Produce		json
+// @Param			list_id		path		int		true	"List ID"
+// @Param			movie_ids	body		[]int	true	"Movie IDs in the new order"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		409			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/lists/{list_id}/items [put]
+func New(log *slog.Logger, listReorderer ListReorderer) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.list.reorder.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if ok, field, msg := validateRequest(req); !ok {
+			log.Error("invalid request", field)
+
+			response.ValidationFailed(w, r, field.Value.String(), msg)
+
+			return
+		}
+
+		err = listReorderer.ReorderList(r.Context(), req.ListId, userId, req.MovieIds)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("list not found", slog.Int("list_id", req.ListId))
+
+			response.FromError(w, r, err, "list not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrConflict) {
+			log.Warn("movie_ids do not match the list", slog.Int("list_id", req.ListId))
+
+			response.FromError(w, r, err, "movie_ids do not match the list")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to reorder list", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to reorder list")
+
+			return
+		}
+
+		log.Info("list reordered", slog.Int("list_id", req.ListId))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+		})
+	}
+}
+
+func validateRequest(req Request) (bool, slog.Attr, string) {
+	if req.ListId < 1 {
+		return false, slog.String("field", "list_id"), "field list_id is not valid"
+	}
+
+	seen := make(map[int]bool, len(req.MovieIds))
+	for _, movieId := range req.MovieIds {
+		if movieId < 1 || seen[movieId] {
+			return false, slog.String("field", "movie_ids"), "field movie_ids is not valid"
+		}
+		seen[movieId] = true
+	}
+	return true, slog.Attr{}, ""
+}
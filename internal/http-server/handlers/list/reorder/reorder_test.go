@@ -0,0 +1,118 @@
+package reorder_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/list/reorder"
+	"film_library/internal/http-server/handlers/list/reorder/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestReorderHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		body      string
+		movieIds  []int
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:     "Success",
+			body:     `{"movie_ids": [3, 1, 2]}`,
+			movieIds: []int{3, 1, 2},
+			status:   http.StatusOK,
+		},
+		{
+			name:      "Duplicate movie",
+			body:      `{"movie_ids": [3, 1, 3]}`,
+			respError: "field movie_ids is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid movie_id",
+			body:      `{"movie_ids": [3, 0]}`,
+			respError: "field movie_ids is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "List changed",
+			body:      `{"movie_ids": [3, 1, 2]}`,
+			movieIds:  []int{3, 1, 2},
+			respError: "movie_ids do not match the list",
+			status:    http.StatusConflict,
+			mockError: fmt.Errorf("storage.postgres.ReorderList: %w", &storage.ConstraintError{Field: "movie_ids", Err: storage.ErrConflict}),
+		},
+		{
+			name:      "Not found",
+			body:      `{"movie_ids": [3, 1, 2]}`,
+			movieIds:  []int{3, 1, 2},
+			respError: "list not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.ReorderList: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "ReorderList Error",
+			body:      `{"movie_ids": [3, 1, 2]}`,
+			movieIds:  []int{3, 1, 2},
+			respError: "failed to reorder list",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listReordererMock := mocks.NewListReorderer(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listReordererMock.On("ReorderList", mock.Anything, 2, 7, tc.movieIds).
+					Return(tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Put("/api/v1/lists/{list_id}/items", reorder.New(slogdiscard.NewDiscardLogger(), listReordererMock))
+
+			req, err := http.NewRequest(http.MethodPut, "/api/v1/lists/2/items", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp reorder.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package save
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"log/slog"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+type Request struct {
+	Name string `json:"name"`
+}
+
+type Response struct {
+	response.Response
+	ListId int `json:"list_id"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListSaver
+type ListSaver interface {
+	SaveList(ctx context.Context, userId int, name string) (int, error)
+}
+
+// @Summary		Create a list
+// @Description	Create a custom list. List names are unique per user
+// @Tags			List
+// @Accept			json
+// @Produce		json
+// @Param			name	body		string	true	"Name of the list"
+// @Success		201		{object}	Response
+// @Failure		400		{object}	response.Response
+// @Failure		401		{object}	response.Response
+// @Failure		409		{object}	response.Response
+// @Failure		500		{object}	response.Response
+// @Router			/api/v1/lists [post]
+func New(log *slog.Logger, listSaver ListSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.list.save.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if strings.TrimSpace(req.Name) == "" || utf8.RuneCountInString(req.Name) > MaxNameLength {
+			log.Error("invalid name", slog.String("name", req.Name))
+
+			response.ValidationFailed(w, r, "name", "field name is not valid")
+
+			return
+		}
+
+		listId, err := listSaver.SaveList(r.Context(), userId, req.Name)
+		if errors.Is(err, storage.ErrConflict) {
+			log.Warn("list already exists", slog.String("name", req.Name))
+
+			response.FromError(w, r, err, "list already exists")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to save list", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to save list")
+
+			return
+		}
+
+		log.Info("list saved", slog.Int("list_id", listId))
+
+		response.Render(w, r, http.StatusCreated, Response{
+			response.OK(),
+			listId,
+		})
+	}
+}
+
+const MaxNameLength = 100
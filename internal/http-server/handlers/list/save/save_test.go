@@ -0,0 +1,110 @@
+package save_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/list/save"
+	"film_library/internal/http-server/handlers/list/save/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestSaveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		listName  string
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:     "Success",
+			listName: "Noir",
+			status:   http.StatusCreated,
+		},
+		{
+			name:      "Empty name",
+			listName:  " ",
+			respError: "field name is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Long name",
+			listName:  strings.Repeat("a", save.MaxNameLength+1),
+			respError: "field name is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Name taken",
+			listName:  "Noir",
+			respError: "list already exists",
+			status:    http.StatusConflict,
+			mockError: fmt.Errorf("storage.postgres.SaveList: %w", &storage.ConstraintError{Field: "name", Err: storage.ErrConflict}),
+		},
+		{
+			name:      "SaveList Error",
+			listName:  "Noir",
+			respError: "failed to save list",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listSaverMock := mocks.NewListSaver(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listSaverMock.On("SaveList", mock.Anything, 7, tc.listName).
+					Return(3, tc.mockError).
+					Once()
+			}
+
+			handler := save.New(slogdiscard.NewDiscardLogger(), listSaverMock)
+
+			input := fmt.Sprintf(`{"name": "%s"}`, tc.listName)
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/lists", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			if tc.respError == "" {
+				require.Equal(t, 3, resp.ListId)
+			}
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package share_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/list/share"
+	"film_library/internal/http-server/handlers/list/share/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestShareHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		listId    int
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			listId: 2,
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid list_id",
+			listId:    -2,
+			respError: "field list_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "List of another user",
+			listId:    2,
+			respError: "list not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.ShareList: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "ShareList Error",
+			listId:    2,
+			respError: "failed to share list",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listSharerMock := mocks.NewListSharer(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listSharerMock.On("ShareList", mock.Anything, tc.listId, 7).
+					Return("tok", tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Post("/api/v1/lists/{list_id}/share", share.New(slogdiscard.NewDiscardLogger(), listSharerMock))
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/lists/%d/share", tc.listId), nil)
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp share.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			if tc.respError == "" {
+				require.Equal(t, "tok", resp.ShareToken)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package shared
+
+import (
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	ShareToken string `json:"share_token"`
+	Limit      int    `json:"limit"`
+	Cursor     string `json:"cursor"`
+	WithTotal  bool   `json:"with_total"`
+}
+
+type Response struct {
+	response.Response
+	List  postgres.List       `json:"list"`
+	Items []postgres.ListItem `json:"items"`
+	postgres.PageInfo
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=SharedListGetter
+type SharedListGetter interface {
+	GetSharedList(shareToken string, page postgres.Page) (postgres.List, []postgres.ListItem, postgres.PageInfo, error)
+}
+
+// @Summary		Get a shared list
+// @Description	Get the movies of a list shared by its owner. No sign-in is needed
+// @Tags			List
+// @Accept			json
+// @Produce		json
+// @Param			share_token	path		string	true	"Share token of the list"
+// @Param			limit		query		int		false	"Page size, 50 by default"
+// @Param			cursor		query		string	false	"next_cursor of the previous page"
+// @Param			with_total	query		bool	false	"Return the total count"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/shared/lists/{share_token} [get]
+func New(log *slog.Logger, sharedListGetter SharedListGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.list.shared.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request decoded", slog.Int("limit", req.Limit))
+
+		if req.ShareToken == "" {
+			log.Error("empty share_token")
+
+			response.ValidationFailed(w, r, "share_token", "field share_token is not valid")
+
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > postgres.MaxPageLimit {
+			log.Error("invalid limit", slog.Int("limit", req.Limit))
+
+			response.ValidationFailed(w, r, "limit", "field limit is not valid")
+
+			return
+		}
+
+		list, items, page, err := sharedListGetter.GetSharedList(req.ShareToken, postgres.Page{
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+			WithTotal: req.WithTotal,
+		})
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Error("invalid cursor", sl.Err(err))
+
+			response.ValidationFailed(w, r, "cursor", "field cursor is not valid")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("shared list not found")
+
+			response.FromError(w, r, err, "list not found")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("shared list search failed", sl.Err(err))
+
+			response.FromError(w, r, err, "shared list search failed")
+
+			return
+		}
+
+		log.Info("shared list found", slog.Int("list_id", list.Id), slog.Int("items_count", len(items)))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+			list,
+			items,
+			page,
+		})
+	}
+}
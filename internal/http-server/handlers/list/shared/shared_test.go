@@ -0,0 +1,94 @@
+package shared_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/list/shared"
+	"film_library/internal/http-server/handlers/list/shared/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestSharedHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		query     string
+		page      postgres.Page
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Page",
+			query:  "?limit=5&with_total=true",
+			page:   postgres.Page{Limit: 5, WithTotal: true},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid limit",
+			query:     "?limit=501",
+			respError: "field limit is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Revoked link",
+			respError: "list not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.GetSharedList: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "GetSharedList Error",
+			respError: "shared list search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			sharedListGetterMock := mocks.NewSharedListGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				sharedListGetterMock.On("GetSharedList", "tok", tc.page).
+					Return(postgres.List{Id: 2, Name: "Noir"}, []postgres.ListItem{{MovieId: 1, Position: 1}}, postgres.PageInfo{}, tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Get("/api/v1/shared/lists/{share_token}", shared.New(slogdiscard.NewDiscardLogger(), sharedListGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, "/api/v1/shared/lists/tok"+tc.query, nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp shared.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			if tc.respError == "" {
+				require.Equal(t, "Noir", resp.List.Name)
+				require.Len(t, resp.Items, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package unshare_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/list/unshare"
+	"film_library/internal/http-server/handlers/list/unshare/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestUnshareHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		listId    int
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			listId: 2,
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid list_id",
+			listId:    -2,
+			respError: "field list_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "List of another user",
+			listId:    2,
+			respError: "list not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.UnshareList: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "UnshareList Error",
+			listId:    2,
+			respError: "failed to unshare list",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			listUnsharerMock := mocks.NewListUnsharer(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				listUnsharerMock.On("UnshareList", mock.Anything, tc.listId, 7).
+					Return(tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Delete("/api/v1/lists/{list_id}/share", unshare.New(slogdiscard.NewDiscardLogger(), listUnsharerMock))
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/lists/%d/share", tc.listId), nil)
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp unshare.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
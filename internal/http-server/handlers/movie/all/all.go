@@ -1,40 +1,95 @@
 package all
 
 import (
+	"errors"
+	movieSave "film_library/internal/http-server/handlers/movie/save"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
 	"film_library/internal/storage/postgres"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"strings"
 )
 
 type Request struct {
-	SortBy string `json:"sort_by"`
+	Sort             string   `json:"sort"`
+	SortBy           string   `json:"sort_by"`
+	Genres           []string `json:"genre"`
+	Countries        []string `json:"country"`
+	Tags             []string `json:"tag"`
+	OriginalLanguage string   `json:"language"`
+	Certification    string   `json:"certification"`
+	YearFrom         int      `json:"year_from"`
+	YearTo           int      `json:"year_to"`
+	RatingMin        *int     `json:"rating_min"`
+	RatingMax        *int     `json:"rating_max"`
+	ActorIds         []int    `json:"actor"`
+	ActorMatch       string   `json:"actor_match"`
+	Query            string   `json:"q"`
+	Limit            int      `json:"limit"`
+	Cursor           string   `json:"cursor"`
+	WithTotal        bool     `json:"with_total"`
 }
 
+const (
+	ActorMatchAny = "any"
+	ActorMatchAll = "all"
+)
+
 type Response struct {
 	response.Response
 	Movies []postgres.Movie `json:"movies"`
+	postgres.PageInfo
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=MoviesAllGetter
 type MoviesAllGetter interface {
-	GetMovies(sortBy string) ([]postgres.Movie, error)
+	GetMovies(sort []postgres.SortKey, filter postgres.MovieFilter, page postgres.Page) ([]postgres.Movie, postgres.PageInfo, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListFlagsSetter
+type ListFlagsSetter interface {
+	SetListFlags(userId int, movies []*postgres.Movie) error
 }
 
 //	@Summary		Get all movies
-//	@Description	Get all movies
+//	@Description	Get all movies. Repeated or comma-separated genre, country and tag values must all match.
+//	@Description	Filters combine with AND. sort takes comma-separated fields among title, release_date, rating,
+//	@Description	community_score and runtime, each prefixed with - for descending, e.g. -rating,title.
+//	@Description	sort_by is the former single-field form and cannot be combined with sort. The default is -rating
 //	@Tags			Movie
 //	@Accept			json
 //	@Produce		json
-//	@Param			sort_by	query		string	true	"Sort by"
+//	@Param			sort			query		string		false	"Sort keys, e.g. -rating,title"
+//	@Param			sort_by			query		string		false	"Deprecated, use sort. Sort by: title, release_date, rating or community_score, with _asc or _desc"
+//	@Param			genre			query		[]string	false	"Genre, e.g. drama"
+//	@Param			country			query		[]string	false	"Production country, e.g. FR"
+//	@Param			tag				query		[]string	false	"Tag"
+//	@Param			language		query		string		false	"Original language, e.g. fr"
+//	@Param			certification	query		string		false	"Age certification, e.g. PG-13"
+//	@Param			year_from		query		int			false	"Released in this year or later"
+//	@Param			year_to			query		int			false	"Released in this year or earlier"
+//	@Param			rating_min		query		int			false	"Minimum rating, 0 to 10"
+//	@Param			rating_max		query		int			false	"Maximum rating, 0 to 10"
+//	@Param			actor			query		[]int		false	"Actor id"
+//	@Param			actor_match		query		string		false	"Whether any (default) or all of the actors must star"	Enums(any, all)
+//	@Param			q				query		string		false	"Text the title or description contains"
+//	@Param			limit		query		int		false	"Page size, 50 by default"
+//	@Param			cursor		query		string	false	"next_cursor of the previous page"
+//	@Param			with_total	query		bool	false	"Return the total count"
 //	@Success		200		{object}	Response
 //	@Failure		400		{object}	response.Response
 //	@Failure		401		{object}	response.Response
+//	@Failure		500		{object}	response.Response
 //	@Router			/movie/all [get]
-func New(log *slog.Logger, moviesAllGetter MoviesAllGetter) http.HandlerFunc {
+//	@Router			/api/v1/movies [get]
+func New(log *slog.Logger, moviesAllGetter MoviesAllGetter, listFlagsSetter ListFlagsSetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movie.all.New"
 
@@ -45,45 +100,179 @@ func New(log *slog.Logger, moviesAllGetter MoviesAllGetter) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if !validateSortBy(req.SortBy) {
-			log.Error("invalid sort_by", slog.String("sort_by", req.SortBy))
+		if req.Sort != "" && req.SortBy != "" {
+			log.Error("both sort and sort_by are set")
+
+			response.ValidationFailed(w, r, "sort", "fields sort and sort_by cannot be combined")
+
+			return
+		}
+
+		sort, ok, field := sortKeys(req.Sort, req.SortBy)
+		if !ok {
+			log.Error("invalid sort", slog.String("sort", req.Sort), slog.String("sort_by", req.SortBy))
+
+			response.ValidationFailed(w, r, field, "field "+field+" is not valid")
+
+			return
+		}
+
+		filter := postgres.MovieFilter{
+			Genres:           req.Genres,
+			Countries:        req.Countries,
+			Tags:             req.Tags,
+			OriginalLanguage: req.OriginalLanguage,
+			Certification:    req.Certification,
+			YearFrom:         req.YearFrom,
+			YearTo:           req.YearTo,
+			RatingMin:        req.RatingMin,
+			RatingMax:        req.RatingMax,
+			ActorIds:         req.ActorIds,
+			AllActors:        req.ActorMatch == ActorMatchAll,
+			Contains:         req.Query,
+		}
+		if ok, field := validateFilter(filter); !ok {
+			log.Error("invalid filter", slog.String("field", field))
 
-			render.JSON(w, r, response.Error("field sort_by is not valid"))
+			response.ValidationFailed(w, r, field, "field "+field+" is not valid")
+
+			return
+		}
+		if ok, field := validateRanges(req); !ok {
+			log.Error("invalid filter", slog.String("field", field))
+
+			response.ValidationFailed(w, r, field, "field "+field+" is not valid")
+
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > postgres.MaxPageLimit {
+			log.Error("invalid limit", slog.Int("limit", req.Limit))
+
+			response.ValidationFailed(w, r, "limit", "field limit is not valid")
+
+			return
+		}
+
+		movies, page, err := moviesAllGetter.GetMovies(sort, filter, postgres.Page{
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+			WithTotal: req.WithTotal,
+		})
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Error("invalid cursor", sl.Err(err))
+
+			response.ValidationFailed(w, r, "cursor", "field cursor is not valid")
 
 			return
 		}
 
-		movies, err := moviesAllGetter.GetMovies(req.SortBy)
 		if err != nil {
 			log.Error("movies search failed", sl.Err(err))
 
-			render.JSON(w, r, response.Error("movies search failed"))
+			response.FromError(w, r, err, "movies search failed")
 
 			return
 		}
 
+		if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+			if userId, ok := token.UserId(claims); ok {
+				ptrs := make([]*postgres.Movie, len(movies))
+				for i := range movies {
+					ptrs[i] = &movies[i]
+				}
+				if err = listFlagsSetter.SetListFlags(userId, ptrs); err != nil {
+					log.Error("failed to set list flags", sl.Err(err))
+
+					response.FromError(w, r, err, "movies search failed")
+
+					return
+				}
+			}
+		}
+
 		log.Info("movies found", slog.Int("movies_count", len(movies)))
 
 		render.JSON(w, r, Response{
 			response.OK(),
 			movies,
+			page,
 		})
 	}
 }
 
-func validateSortBy(sortBy string) bool {
-	return sortBy == postgres.OrderByTitleAsc || sortBy == postgres.OrderByTitleDesc ||
-		sortBy == postgres.OrderByReleaseDateAsc || sortBy == postgres.OrderByReleaseDateDesc ||
-		sortBy == postgres.OrderByRatingAsc || sortBy == postgres.OrderByRatingDesc
+// sortKeys returns the sort keys of the sort parameter or of the legacy
+// sort_by one, nil for the default order, and the invalid parameter if any.
+func sortKeys(sort string, sortBy string) ([]postgres.SortKey, bool, string) {
+	if sortBy != "" {
+		keys, ok := postgres.SortByKeys(sortBy)
+		return keys, ok, "sort_by"
+	}
+	if sort != "" {
+		keys, err := postgres.ParseMovieSort(sort)
+		return keys, err == nil, "sort"
+	}
+	return nil, true, ""
+}
+
+func validateRanges(req Request) (bool, string) {
+	if req.YearFrom < 0 {
+		return false, "year_from"
+	}
+	if req.YearTo < 0 || req.YearFrom != 0 && req.YearTo != 0 && req.YearTo < req.YearFrom {
+		return false, "year_to"
+	}
+	if req.RatingMin != nil && (*req.RatingMin < 0 || *req.RatingMin > 10) {
+		return false, "rating_min"
+	}
+	if req.RatingMax != nil && (*req.RatingMax < 0 || *req.RatingMax > 10 ||
+		req.RatingMin != nil && *req.RatingMax < *req.RatingMin) {
+		return false, "rating_max"
+	}
+	for _, actorId := range req.ActorIds {
+		if actorId < 1 {
+			return false, "actor"
+		}
+	}
+	if req.ActorMatch != "" && req.ActorMatch != ActorMatchAny && req.ActorMatch != ActorMatchAll {
+		return false, "actor_match"
+	}
+	if req.Query != "" && (strings.TrimSpace(req.Query) == "" || len(req.Query) > 255) {
+		return false, "q"
+	}
+	return true, ""
+}
+
+// filterParams maps movie detail fields to the query parameters filtering by them.
+var filterParams = map[string]string{
+	"original_language": "language",
+	"certification":     "certification",
+	"genres":            "genre",
+	"countries":         "country",
+	"tags":              "tag",
+}
+
+func validateFilter(filter postgres.MovieFilter) (bool, string) {
+	ok, field, _ := movieSave.ValidateDetails(postgres.MovieDetails{
+		OriginalLanguage: filter.OriginalLanguage,
+		Certification:    filter.Certification,
+		Genres:           filter.Genres,
+		Countries:        filter.Countries,
+		Tags:             filter.Tags,
+	})
+	if ok {
+		return true, ""
+	}
+	return false, filterParams[field.Value.String()]
 }
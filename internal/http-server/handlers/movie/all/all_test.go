@@ -4,39 +4,227 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"film_library/internal/storage"
 	"film_library/internal/storage/postgres"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	searchAll "film_library/internal/http-server/handlers/movie/all"
 	"film_library/internal/http-server/handlers/movie/all/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
 )
 
+var titleAsc = []postgres.SortKey{{Field: postgres.SortTitle}}
+
+func intPtr(v int) *int {
+	return &v
+}
+
 func TestSaveHandler(t *testing.T) {
 	cases := []struct {
-		name      string
-		sortBy    string
-		respError string
-		mockError error
+		name       string
+		sortBy     string
+		sort       []postgres.SortKey
+		query      string
+		filter     postgres.MovieFilter
+		limit      int
+		cursor     string
+		nextCursor string
+		respError  string
+		status     int
+		mockError  error
 	}{
 		{
-			name:   "Success",
+			name:       "Success",
+			sortBy:     "title_asc",
+			sort:       titleAsc,
+			nextCursor: "next",
+			status:     http.StatusOK,
+		},
+		{
+			name:   "Community score",
+			sortBy: "community_score_desc",
+			sort:   []postgres.SortKey{{Field: postgres.SortCommunityScore, Desc: true}},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Page",
+			sortBy: "title_asc",
+			sort:   titleAsc,
+			limit:  10,
+			cursor: "abc",
+			status: http.StatusOK,
+		},
+		{
+			name:   "Filter",
 			sortBy: "title_asc",
+			sort:   titleAsc,
+			query:  "?genre=drama,comedy&country=FR&tag=noir&language=fr&certification=16%2B",
+			filter: postgres.MovieFilter{
+				Genres:           []string{"drama", "comedy"},
+				Countries:        []string{"FR"},
+				Tags:             []string{"noir"},
+				OriginalLanguage: "fr",
+				Certification:    "16+",
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid country",
+			sortBy:    "title_asc",
+			sort:      titleAsc,
+			query:     "?country=fr",
+			respError: "field country is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid language",
+			sortBy:    "title_asc",
+			sort:      titleAsc,
+			query:     "?language=french",
+			respError: "field language is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid limit",
+			sortBy:    "title_asc",
+			sort:      titleAsc,
+			limit:     501,
+			respError: "field limit is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid cursor",
+			sortBy:    "title_asc",
+			sort:      titleAsc,
+			cursor:    "abc",
+			respError: "field cursor is not valid",
+			status:    http.StatusBadRequest,
+			mockError: fmt.Errorf("storage.postgres.GetMovies: %w", storage.ErrInvalidCursor),
 		},
 		{
 			name:      "Invalid sort_by",
 			sortBy:    "title",
 			respError: "field sort_by is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:   "Default sort",
+			status: http.StatusOK,
+		},
+		{
+			name:  "Sort",
+			query: "?sort=-rating,title",
+			sort: []postgres.SortKey{
+				{Field: postgres.SortRating, Desc: true},
+				{Field: postgres.SortTitle},
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid sort",
+			query:     "?sort=-rating,budget",
+			respError: "field sort is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Repeated sort field",
+			query:     "?sort=rating,-rating",
+			respError: "field sort is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Sort and sort_by",
+			sortBy:    "title_asc",
+			query:     "?sort=title",
+			respError: "fields sort and sort_by cannot be combined",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:  "Ranges",
+			query: "?year_from=1990&year_to=1999&rating_min=7&rating_max=10",
+			filter: postgres.MovieFilter{
+				YearFrom:  1990,
+				YearTo:    1999,
+				RatingMin: intPtr(7),
+				RatingMax: intPtr(10),
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Zero rating_min",
+			query:  "?rating_min=0",
+			filter: postgres.MovieFilter{RatingMin: intPtr(0)},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Any actor",
+			query:  "?actor=3,5",
+			filter: postgres.MovieFilter{ActorIds: []int{3, 5}},
+			status: http.StatusOK,
+		},
+		{
+			name:   "All actors",
+			query:  "?actor=3&actor=5&actor_match=all",
+			filter: postgres.MovieFilter{ActorIds: []int{3, 5}, AllActors: true},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Contains",
+			query:  "?q=heist&genre=crime",
+			filter: postgres.MovieFilter{Genres: []string{"crime"}, Contains: "heist"},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid year_to",
+			query:     "?year_from=2000&year_to=1999",
+			respError: "field year_to is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid rating_min",
+			query:     "?rating_min=11",
+			respError: "field rating_min is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid rating_max",
+			query:     "?rating_min=8&rating_max=5",
+			respError: "field rating_max is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid actor",
+			query:     "?actor=0",
+			respError: "field actor is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid actor_match",
+			query:     "?actor=3&actor_match=some",
+			respError: "field actor_match is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Blank q",
+			query:     "?q=%20%20",
+			respError: "field q is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "GetMovies Error",
 			sortBy:    "title_asc",
+			sort:      titleAsc,
 			respError: "movies search failed",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -50,22 +238,22 @@ func TestSaveHandler(t *testing.T) {
 			moviesAllGetterMock := mocks.NewMoviesAllGetter(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				moviesAllGetterMock.On("GetMovies", tc.sortBy).
-					Return([]postgres.Movie{}, tc.mockError).
+				moviesAllGetterMock.On("GetMovies", tc.sort, tc.filter, postgres.Page{Limit: tc.limit, Cursor: tc.cursor}).
+					Return([]postgres.Movie{}, postgres.PageInfo{NextCursor: tc.nextCursor}, tc.mockError).
 					Once()
 			}
 
-			handler := searchAll.New(slogdiscard.NewDiscardLogger(), moviesAllGetterMock)
+			handler := searchAll.New(slogdiscard.NewDiscardLogger(), moviesAllGetterMock, mocks.NewListFlagsSetter(t))
 
-			input := fmt.Sprintf(`{"sort_by": "%s"}`, tc.sortBy)
+			input := fmt.Sprintf(`{"sort_by": "%s", "limit": %d, "cursor": "%s"}`, tc.sortBy, tc.limit, tc.cursor)
 
-			req, err := http.NewRequest(http.MethodGet, "/movie/all", bytes.NewReader([]byte(input)))
+			req, err := http.NewRequest(http.MethodGet, "/movie/all"+tc.query, bytes.NewReader([]byte(input)))
 			require.NoError(t, err)
 
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -74,6 +262,53 @@ func TestSaveHandler(t *testing.T) {
 			require.NoError(t, json.Unmarshal([]byte(body), &resp))
 
 			require.Equal(t, tc.respError, resp.Error)
+			require.Equal(t, tc.nextCursor, resp.NextCursor)
 		})
 	}
 }
+
+func TestListFlags(t *testing.T) {
+	moviesAllGetterMock := mocks.NewMoviesAllGetter(t)
+	listFlagsSetterMock := mocks.NewListFlagsSetter(t)
+
+	moviesAllGetterMock.On("GetMovies", []postgres.SortKey{{Field: postgres.SortRating, Desc: true}}, postgres.MovieFilter{}, postgres.Page{}).
+		Return([]postgres.Movie{{Id: 1}, {Id: 2}}, postgres.PageInfo{}, nil).
+		Once()
+
+	listFlagsSetterMock.On("SetListFlags", 7, mock.Anything).
+		Run(func(args mock.Arguments) {
+			movies := args.Get(1).([]*postgres.Movie)
+			movies[0].Lists = &postgres.ListFlags{Watched: true, WatchedAt: "2024-05-01", ListIds: []int{}}
+			movies[1].Lists = &postgres.ListFlags{ListIds: []int{12}}
+		}).
+		Return(nil).
+		Once()
+
+	handler := searchAll.New(slogdiscard.NewDiscardLogger(), moviesAllGetterMock, listFlagsSetterMock)
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/movies?sort_by=rating_desc", nil)
+	require.NoError(t, err)
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	accessToken, err := tokens.IssueAccess(7, nil, nil)
+	require.NoError(t, err)
+
+	parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+	require.NoError(t, err)
+
+	req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp searchAll.Response
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	require.Len(t, resp.Movies, 2)
+	require.Equal(t, "2024-05-01", resp.Movies[0].Lists.WatchedAt)
+	require.Equal(t, []int{12}, resp.Movies[1].Lists.ListIds)
+}
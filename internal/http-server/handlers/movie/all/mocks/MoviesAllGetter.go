@@ -13,34 +13,41 @@ type MoviesAllGetter struct {
 	mock.Mock
 }
 
-// GetMovies provides a mock function with given fields: sortBy
-func (_m *MoviesAllGetter) GetMovies(sortBy string) ([]postgres.Movie, error) {
-	ret := _m.Called(sortBy)
+// GetMovies provides a mock function with given fields: sort, filter, page
+func (_m *MoviesAllGetter) GetMovies(sort []postgres.SortKey, filter postgres.MovieFilter, page postgres.Page) ([]postgres.Movie, postgres.PageInfo, error) {
+	ret := _m.Called(sort, filter, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMovies")
 	}
 
 	var r0 []postgres.Movie
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]postgres.Movie, error)); ok {
-		return rf(sortBy)
+	var r1 postgres.PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func([]postgres.SortKey, postgres.MovieFilter, postgres.Page) ([]postgres.Movie, postgres.PageInfo, error)); ok {
+		return rf(sort, filter, page)
 	}
-	if rf, ok := ret.Get(0).(func(string) []postgres.Movie); ok {
-		r0 = rf(sortBy)
+	if rf, ok := ret.Get(0).(func([]postgres.SortKey, postgres.MovieFilter, postgres.Page) []postgres.Movie); ok {
+		r0 = rf(sort, filter, page)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]postgres.Movie)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(sortBy)
+	if rf, ok := ret.Get(1).(func([]postgres.SortKey, postgres.MovieFilter, postgres.Page) postgres.PageInfo); ok {
+		r1 = rf(sort, filter, page)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(postgres.PageInfo)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func([]postgres.SortKey, postgres.MovieFilter, postgres.Page) error); ok {
+		r2 = rf(sort, filter, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // NewMoviesAllGetter creates a new instance of MoviesAllGetter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
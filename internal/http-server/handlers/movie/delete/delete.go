@@ -1,8 +1,13 @@
 package delete
 
 import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/etag"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
@@ -19,20 +24,25 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=MovieDeleter
 type MovieDeleter interface {
-	DeleteMovie(movieId int) error
+	DeleteMovie(ctx context.Context, movieId int, version int) error
 }
 
 // @Summary		Delete a movie
-// @Description	Delete a movie by movie_id
+// @Description	Move a movie to the trash by movie_id. Admins can restore it with its cast until it is purged
 // @Tags			Movie
 // @Accept			json
 // @Produce		json
-// @Param			movie_id	path		int	true	"Movie ID"
+// @Param			movie_id	path		int		true	"Movie ID"
+// @Param			If-Match	header		string	false	"ETag the deletion is based on"
 // @Success		200			{object}	Response
 // @Failure		400			{object}	response.Response
 // @Failure		401			{object}	response.Response
 // @Failure		403			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		412			{object}	response.Response
+// @Failure		500			{object}	response.Response
 // @Router			/movie/delete [delete]
+// @Router			/api/v1/movies/{movie_id} [delete]
 func New(log *slog.Logger, actorDeleter MovieDeleter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movie.delete.New"
@@ -44,11 +54,11 @@ func New(log *slog.Logger, actorDeleter MovieDeleter) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
@@ -58,16 +68,32 @@ func New(log *slog.Logger, actorDeleter MovieDeleter) http.HandlerFunc {
 		if req.MovieId < 1 {
 			log.Error("invalid movie_id", slog.Int("movie_id", req.MovieId))
 
-			render.JSON(w, r, response.Error("field movie_id is not valid"))
+			response.ValidationFailed(w, r, "movie_id", "field movie_id is not valid")
+
+			return
+		}
+
+		err = actorDeleter.DeleteMovie(r.Context(), req.MovieId, etag.IfMatch(r))
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("movie not found", slog.Int("movie_id", req.MovieId))
+
+			response.FromError(w, r, err, "movie not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Warn("movie has been changed", slog.Int("movie_id", req.MovieId))
+
+			response.FromError(w, r, err, "movie has been changed")
 
 			return
 		}
 
-		err = actorDeleter.DeleteMovie(req.MovieId)
 		if err != nil {
 			log.Error("failed to delete movie", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to delete movie"))
+			response.FromError(w, r, err, "failed to delete movie")
 
 			return
 		}
@@ -9,33 +9,64 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/movie/delete"
 	"film_library/internal/http-server/handlers/movie/delete/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
 )
 
 func TestSaveHandler(t *testing.T) {
 	cases := []struct {
 		name      string
 		movieId   int
+		ifMatch   string
+		version   int
 		respError string
+		status    int
 		mockError error
 	}{
 		{
 			name:    "Success",
 			movieId: 1,
+			status:  http.StatusOK,
 		},
 		{
 			name:      "Invalid movie_id",
 			movieId:   -1,
 			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			movieId:   1,
+			respError: "movie not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.DeleteMovie: %w", storage.ErrNotFound),
+		},
+		{
+			name:    "If-Match",
+			movieId: 1,
+			ifMatch: `"3"`,
+			version: 3,
+			status:  http.StatusOK,
+		},
+		{
+			name:      "Version mismatch",
+			movieId:   1,
+			ifMatch:   `"3"`,
+			version:   3,
+			respError: "movie has been changed",
+			status:    http.StatusPreconditionFailed,
+			mockError: fmt.Errorf("storage.postgres.DeleteMovie: %w", storage.ErrVersionMismatch),
 		},
 		{
 			name:      "DeleteMovie Error",
 			movieId:   1,
 			respError: "failed to delete movie",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -49,7 +80,7 @@ func TestSaveHandler(t *testing.T) {
 			movieDeleterMock := mocks.NewMovieDeleter(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				movieDeleterMock.On("DeleteMovie", tc.movieId).
+				movieDeleterMock.On("DeleteMovie", mock.Anything, tc.movieId, tc.version).
 					Return(tc.mockError).
 					Once()
 			}
@@ -60,11 +91,14 @@ func TestSaveHandler(t *testing.T) {
 
 			req, err := http.NewRequest(http.MethodPost, "/movie/delete", bytes.NewReader([]byte(input)))
 			require.NoError(t, err)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
 
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
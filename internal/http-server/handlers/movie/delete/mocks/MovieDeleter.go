@@ -2,24 +2,28 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MovieDeleter is an autogenerated mock type for the MovieDeleter type
 type MovieDeleter struct {
 	mock.Mock
 }
 
-// DeleteMovie provides a mock function with given fields: movieId
-func (_m *MovieDeleter) DeleteMovie(movieId int) error {
-	ret := _m.Called(movieId)
+// DeleteMovie provides a mock function with given fields: ctx, movieId, version
+func (_m *MovieDeleter) DeleteMovie(ctx context.Context, movieId int, version int) error {
+	ret := _m.Called(ctx, movieId, version)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteMovie")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int) error); ok {
-		r0 = rf(movieId)
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) error); ok {
+		r0 = rf(ctx, movieId, version)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -2,16 +2,22 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	postgres "film_library/internal/storage/postgres"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MovieSaver is an autogenerated mock type for the MovieSaver type
 type MovieSaver struct {
 	mock.Mock
 }
 
-// SaveMovie provides a mock function with given fields: title, description, releaseDate, rating, actorsIds
-func (_m *MovieSaver) SaveMovie(title string, description string, releaseDate string, rating int, actorsIds []int) (int, error) {
-	ret := _m.Called(title, description, releaseDate, rating, actorsIds)
+// SaveMovie provides a mock function with given fields: ctx, title, description, releaseDate, rating, actorsIds, details
+func (_m *MovieSaver) SaveMovie(ctx context.Context, title string, description string, releaseDate string, rating int, actorsIds []int, details postgres.MovieDetails) (int, error) {
+	ret := _m.Called(ctx, title, description, releaseDate, rating, actorsIds, details)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveMovie")
@@ -19,17 +25,17 @@ func (_m *MovieSaver) SaveMovie(title string, description string, releaseDate st
 
 	var r0 int
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, string, string, int, []int) (int, error)); ok {
-		return rf(title, description, releaseDate, rating, actorsIds)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int, []int, postgres.MovieDetails) (int, error)); ok {
+		return rf(ctx, title, description, releaseDate, rating, actorsIds, details)
 	}
-	if rf, ok := ret.Get(0).(func(string, string, string, int, []int) int); ok {
-		r0 = rf(title, description, releaseDate, rating, actorsIds)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string, int, []int, postgres.MovieDetails) int); ok {
+		r0 = rf(ctx, title, description, releaseDate, rating, actorsIds, details)
 	} else {
 		r0 = ret.Get(0).(int)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, string, string, int, []int) error); ok {
-		r1 = rf(title, description, releaseDate, rating, actorsIds)
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string, int, []int, postgres.MovieDetails) error); ok {
+		r1 = rf(ctx, title, description, releaseDate, rating, actorsIds, details)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -1,21 +1,42 @@
 package save
 
 import (
+	"context"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage/postgres"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"regexp"
+	"strings"
 	"time"
 )
 
 type Request struct {
-	Title       string `json:"title"`
-	Description string `json:"description"`
-	ReleaseDate string `json:"release_date"`
-	Rating      int    `json:"rating"`
-	ActorsIds   []int  `json:"actors_ids"`
+	Title            string   `json:"title"`
+	Description      string   `json:"description"`
+	ReleaseDate      string   `json:"release_date"`
+	Rating           int      `json:"rating"`
+	ActorsIds        []int    `json:"actors_ids"`
+	Runtime          int      `json:"runtime"`
+	OriginalLanguage string   `json:"original_language"`
+	Certification    string   `json:"certification"`
+	Genres           []string `json:"genres"`
+	Countries        []string `json:"countries"`
+	Tags             []string `json:"tags"`
+}
+
+func (r Request) details() postgres.MovieDetails {
+	return postgres.MovieDetails{
+		Runtime:          r.Runtime,
+		OriginalLanguage: r.OriginalLanguage,
+		Certification:    r.Certification,
+		Genres:           r.Genres,
+		Countries:        r.Countries,
+		Tags:             r.Tags,
+	}
 }
 
 type Response struct {
@@ -25,24 +46,32 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=MovieSaver
 type MovieSaver interface {
-	SaveMovie(title string, description string, releaseDate string, rating int, actorsIds []int) (int, error)
+	SaveMovie(ctx context.Context, title string, description string, releaseDate string, rating int, actorsIds []int, details postgres.MovieDetails) (int, error)
 }
 
 // @Summary		Save movie
-// @Description	Save movie by title, description, release_date, rating and actors_ids
+// @Description	Save movie by title, description, release_date, rating and actors_ids, optionally with runtime in minutes, original_language (ISO 639-1), certification, genres, countries (ISO 3166-1 alpha-2) and tags
 // @Tags			Movie
 // @Accept			json
 // @Produce		json
-// @Param			title			path		string	true	"Title"
-// @Param			description		path		string	true	"Description"
-// @Param			release_date	path		string	true	"Release Date"
-// @Param			rating			path		int		true	"Rating"
-// @Param			actors_ids		path		[]int	true	"Actors IDs"
-// @Success		200				{object}	Response
-// @Failure		400				{object}	response.Response
-// @Failure		401				{object}	response.Response
-// @Failure		403				{object}	response.Response
+// @Param			title				path		string		true	"Title"
+// @Param			description			path		string		true	"Description"
+// @Param			release_date		path		string		true	"Release Date"
+// @Param			rating				path		int			true	"Rating"
+// @Param			actors_ids			path		[]int		true	"Actors IDs"
+// @Param			runtime				body		int			false	"Runtime, minutes"
+// @Param			original_language	body		string		false	"Original language, e.g. fr"
+// @Param			certification		body		string		false	"Age certification, e.g. PG-13 or 16+"
+// @Param			genres				body		[]string	false	"Genres, e.g. drama"
+// @Param			countries			body		[]string	false	"Production countries, e.g. FR"
+// @Param			tags				body		[]string	false	"Tags"
+// @Success		201					{object}	Response
+// @Failure		400					{object}	response.Response
+// @Failure		401					{object}	response.Response
+// @Failure		403					{object}	response.Response
+// @Failure		500					{object}	response.Response
 // @Router			/movie/save [post]
+// @Router			/api/v1/movies [post]
 func New(log *slog.Logger, movieSaver MovieSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movie.save.New"
@@ -54,44 +83,44 @@ func New(log *slog.Logger, movieSaver MovieSaver) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		if ok, field, msg := validateRequest(req); !ok {
+		if ok, field, msg := ValidateRequest(req); !ok {
 			log.Error("invalid request", field)
 
-			render.JSON(w, r, response.Error(msg))
+			response.ValidationFailed(w, r, field.Value.String(), msg)
 
 			return
 		}
 
-		movieId, err := movieSaver.SaveMovie(req.Title, req.Description, req.ReleaseDate, req.Rating, req.ActorsIds)
+		movieId, err := movieSaver.SaveMovie(r.Context(), req.Title, req.Description, req.ReleaseDate, req.Rating, req.ActorsIds, req.details())
 		if err != nil {
 			log.Error("failed to save movie", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to save movie"))
+			response.FromError(w, r, err, "failed to save movie")
 
 			return
 		}
 
 		log.Info("movie saved", slog.Int("movie_id", movieId))
 
-		render.JSON(w, r, Response{
+		response.Render(w, r, http.StatusCreated, Response{
 			response.OK(),
 			movieId,
 		})
 	}
 }
 
-func validateRequest(req Request) (bool, slog.Attr, string) {
+func ValidateRequest(req Request) (bool, slog.Attr, string) {
 	if len(req.Title) < 1 || len(req.Title) > 150 {
 		return false, slog.String("field", "title"), "field title is not valid"
 	}
@@ -109,5 +138,53 @@ func validateRequest(req Request) (bool, slog.Attr, string) {
 			return false, slog.String("field", "actors_ids"), "field actors_ids is not valid"
 		}
 	}
+	return ValidateDetails(req.details())
+}
+
+const maxListLength = 20
+
+var (
+	languageCode = regexp.MustCompile(`^[a-z]{2}$`)
+	countryCode  = regexp.MustCompile(`^[A-Z]{2}$`)
+)
+
+// ValidateDetails checks the form of movie details. Whether a language,
+// certification, genre or country exists is up to the storage.
+func ValidateDetails(details postgres.MovieDetails) (bool, slog.Attr, string) {
+	if details.Runtime < 0 || details.Runtime > 1000 {
+		return false, slog.String("field", "runtime"), "field runtime is not valid"
+	}
+	if details.OriginalLanguage != "" && !languageCode.MatchString(details.OriginalLanguage) {
+		return false, slog.String("field", "original_language"), "field original_language is not valid"
+	}
+	if len(details.Certification) > 10 {
+		return false, slog.String("field", "certification"), "field certification is not valid"
+	}
+	if !validList(details.Genres, func(genre string) bool { return len(genre) >= 1 && len(genre) <= 50 }) {
+		return false, slog.String("field", "genres"), "field genres is not valid"
+	}
+	if !validList(details.Countries, countryCode.MatchString) {
+		return false, slog.String("field", "countries"), "field countries is not valid"
+	}
+	if !validList(details.Tags, validTag) {
+		return false, slog.String("field", "tags"), "field tags is not valid"
+	}
 	return true, slog.Attr{}, ""
 }
+
+func validList(list []string, valid func(s string) bool) bool {
+	if len(list) > maxListLength {
+		return false
+	}
+	for _, s := range list {
+		if !valid(s) {
+			return false
+		}
+	}
+	return true
+}
+
+// validTag rejects the separators of list filters and CSV list columns.
+func validTag(tag string) bool {
+	return len(tag) >= 1 && len(tag) <= 50 && strings.TrimSpace(tag) == tag && !strings.ContainsAny(tag, ",|")
+}
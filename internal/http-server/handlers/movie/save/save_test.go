@@ -10,11 +10,13 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/movie/save"
 	"film_library/internal/http-server/handlers/movie/save/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage/postgres"
 )
 
 const bigDesription = "Lorem ipsum dolor sit amet, consectetuer adipiscing elit, sed diam nonummy nibh euismod tincidunt ut laoreet dolore magna aliquam erat volutpat. Ut wisi enim ad minim veniam, quis nostrud exerci tation ullamcorper suscipit lobortis nisl ut aliquip ex ea commodo consequat. Duis autem vel eum iriure dolor in hendrerit in vulputate velit esse molestie consequat, vel illum dolore eu feugiat nulla facilisis at vero eros et accumsan et iusto odio dignissim qui blandit praesent luptatum zzril delenit augue duis dolore te feugait nulla facilisi.Lorem ipsum dolor sit amet, consectetuer adipiscing elit, sed diam nonummy nibh euismod tincidunt ut laoreet dolore magna aliquam erat volutpat. Ut wisi enim ad minim veniam, quis nostrud exerci tation ullamcorper suscipit lobortis nisl ut aliquip ex ea commodo consequat. Duis autem vel eum iriure dolor in hendrerit in vulputate velit esse molestie consequat, vel illum dolore eu feugiat nulla facilisis at vero eros et accumsan et iusto odio dignissim qui"
@@ -28,6 +30,7 @@ func TestSaveHandler(t *testing.T) {
 		rating      int
 		actorsIds   []int
 		respError   string
+		status      int
 		mockError   error
 	}{
 		{
@@ -37,6 +40,7 @@ func TestSaveHandler(t *testing.T) {
 			releaseDate: "2000-01-01",
 			rating:      10,
 			actorsIds:   []int{1, 2, 3},
+			status:      http.StatusCreated,
 		},
 		{
 			name:        "Empty Title",
@@ -46,6 +50,7 @@ func TestSaveHandler(t *testing.T) {
 			rating:      10,
 			actorsIds:   []int{1, 2, 3},
 			respError:   "field title is not valid",
+			status:      http.StatusBadRequest,
 		},
 		{
 			name:        "Invalid Description",
@@ -55,6 +60,7 @@ func TestSaveHandler(t *testing.T) {
 			rating:      10,
 			actorsIds:   []int{1, 2, 3},
 			respError:   "field description is not valid",
+			status:      http.StatusBadRequest,
 		},
 		{
 			name:        "Invalid Release Date",
@@ -64,6 +70,7 @@ func TestSaveHandler(t *testing.T) {
 			rating:      10,
 			actorsIds:   []int{1, 2, 3},
 			respError:   "field release_date is not valid",
+			status:      http.StatusBadRequest,
 		},
 		{
 			name:        "Invalid Rating",
@@ -73,6 +80,7 @@ func TestSaveHandler(t *testing.T) {
 			rating:      11,
 			actorsIds:   []int{1, 2, 3},
 			respError:   "field rating is not valid",
+			status:      http.StatusBadRequest,
 		},
 		{
 			name:        "Invalid Actors",
@@ -82,6 +90,7 @@ func TestSaveHandler(t *testing.T) {
 			rating:      10,
 			actorsIds:   []int{1, -2, 3},
 			respError:   "field actors_ids is not valid",
+			status:      http.StatusBadRequest,
 		},
 		{
 			name:        "SaveMovie Error",
@@ -91,6 +100,7 @@ func TestSaveHandler(t *testing.T) {
 			rating:      10,
 			actorsIds:   []int{1, 2, 3},
 			respError:   "failed to save movie",
+			status:      http.StatusInternalServerError,
 			mockError:   errors.New("failed to save movie"),
 		},
 	}
@@ -104,7 +114,7 @@ func TestSaveHandler(t *testing.T) {
 			movieSaverMock := mocks.NewMovieSaver(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				movieSaverMock.On("SaveMovie", tc.title, tc.description, tc.releaseDate, tc.rating, tc.actorsIds).
+				movieSaverMock.On("SaveMovie", mock.Anything, tc.title, tc.description, tc.releaseDate, tc.rating, tc.actorsIds, postgres.MovieDetails{}).
 					Return(1, tc.mockError).
 					Once()
 			}
@@ -122,7 +132,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -134,3 +144,89 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestSaveDetails(t *testing.T) {
+	cases := []struct {
+		name      string
+		details   string
+		want      postgres.MovieDetails
+		respError string
+		status    int
+	}{
+		{
+			name:    "Success",
+			details: `"runtime": 122, "original_language": "fr", "certification": "16+", "genres": ["comedy"], "countries": ["FR", "DE"], "tags": ["paris"]`,
+			want: postgres.MovieDetails{
+				Runtime:          122,
+				OriginalLanguage: "fr",
+				Certification:    "16+",
+				Genres:           []string{"comedy"},
+				Countries:        []string{"FR", "DE"},
+				Tags:             []string{"paris"},
+			},
+			status: http.StatusCreated,
+		},
+		{
+			name:      "Invalid runtime",
+			details:   `"runtime": 1001`,
+			respError: "field runtime is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid original_language",
+			details:   `"original_language": "fra"`,
+			respError: "field original_language is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid countries",
+			details:   `"countries": ["fr"]`,
+			respError: "field countries is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Empty genre",
+			details:   `"genres": [""]`,
+			respError: "field genres is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Tag with a separator",
+			details:   `"tags": ["paris,france"]`,
+			respError: "field tags is not valid",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieSaverMock := mocks.NewMovieSaver(t)
+
+			if tc.respError == "" {
+				movieSaverMock.On("SaveMovie", mock.Anything, "Amélie", "", "2001-04-25", 8, []int(nil), tc.want).
+					Return(1, nil).
+					Once()
+			}
+
+			handler := save.New(slogdiscard.NewDiscardLogger(), movieSaverMock)
+
+			input := `{"title": "Amélie", "release_date": "2001-04-25", "rating": 8, ` + tc.details + `}`
+
+			req, err := http.NewRequest(http.MethodPost, "/api/v1/movies", strings.NewReader(input))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
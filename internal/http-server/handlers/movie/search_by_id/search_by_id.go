@@ -1,10 +1,16 @@
 package search_by_id
 
 import (
+	"errors"
+	"film_library/internal/lib/api/etag"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
 	"film_library/internal/storage/postgres"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
@@ -24,17 +30,28 @@ type MovieSearcherById interface {
 	GetMovie(movieId int) (postgres.Movie, error)
 }
 
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListFlagsSetter
+type ListFlagsSetter interface {
+	SetListFlags(userId int, movies []*postgres.Movie) error
+}
+
 // @Summary		Search a movie by movie_id
 // @Description	Search a movie by movie_id
 // @Tags			Movie
 // @Accept			json
 // @Produce		json
-// @Param			movie_id	path		int	true	"Movie ID"
-// @Success		200			{object}	Response
+// @Param			movie_id		path		int		true	"Movie ID"
+// @Param			If-None-Match	header		string	false	"ETag of a cached copy"
+// @Success		200				{object}	Response
+// @Header			200				{string}	ETag	"Version, community score and the caller's list flags of the movie"
+// @Success		304				"Cached copy is current"
 // @Failure		400			{object}	response.Response
 // @Failure		401			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
 // @Router			/movie/search_by_id [get]
-func New(log *slog.Logger, movieSearcher MovieSearcherById) http.HandlerFunc {
+// @Router			/api/v1/movies/{movie_id} [get]
+func New(log *slog.Logger, movieSearcher MovieSearcherById, listFlagsSetter ListFlagsSetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movie.search_by_id.New"
 
@@ -45,11 +62,11 @@ func New(log *slog.Logger, movieSearcher MovieSearcherById) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
@@ -59,22 +76,56 @@ func New(log *slog.Logger, movieSearcher MovieSearcherById) http.HandlerFunc {
 		if req.MovieId < 1 {
 			log.Error("invalid movie_id", slog.Int("movie_id", req.MovieId))
 
-			render.JSON(w, r, response.Error("field movie_id is not valid"))
+			response.ValidationFailed(w, r, "movie_id", "field movie_id is not valid")
 
 			return
 		}
 
 		movie, err := movieSearcher.GetMovie(req.MovieId)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("movie not found", slog.Int("movie_id", req.MovieId))
+
+			response.FromError(w, r, err, "movie not found")
+
+			return
+		}
+
 		if err != nil {
 			log.Error("movie search failed", sl.Err(err))
 
-			render.JSON(w, r, response.Error("movie search failed"))
+			response.FromError(w, r, err, "movie search failed")
 
 			return
 		}
 
 		log.Info("movie found", slog.Int("movie_id", req.MovieId))
 
+		// the list flags are the caller's own
+		w.Header().Set("Vary", "Authorization")
+		if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+			if userId, ok := token.UserId(claims); ok {
+				if err = listFlagsSetter.SetListFlags(userId, []*postgres.Movie{&movie}); err != nil {
+					log.Error("failed to set list flags", sl.Err(err))
+
+					response.FromError(w, r, err, "movie search failed")
+
+					return
+				}
+				w.Header().Set("Cache-Control", "private")
+			}
+		}
+
+		// votes and list changes do not bump the version, so the score and
+		// the list flags are part of the tag
+		tag := etag.FormatState(movie.Version, etag.State(movie.CommunityScore, movie.VoteCount, movie.Lists))
+		etag.SetTag(w, tag)
+
+		if etag.NotModifiedTag(r, tag) {
+			w.WriteHeader(http.StatusNotModified)
+
+			return
+		}
+
 		render.JSON(w, r, Response{
 			response.OK(),
 			movie,
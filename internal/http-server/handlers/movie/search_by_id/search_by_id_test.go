@@ -9,12 +9,19 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	searchById "film_library/internal/http-server/handlers/movie/search_by_id"
 	"film_library/internal/http-server/handlers/movie/search_by_id/mocks"
+	"film_library/internal/lib/api/etag"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
 )
 
 func TestSaveHandler(t *testing.T) {
@@ -22,21 +29,32 @@ func TestSaveHandler(t *testing.T) {
 		name      string
 		movieId   int
 		respError string
+		status    int
 		mockError error
 	}{
 		{
 			name:    "Success",
 			movieId: 1,
+			status:  http.StatusOK,
 		},
 		{
 			name:      "Invalid movie_id",
 			movieId:   -1,
 			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			movieId:   1,
+			respError: "movie not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.GetMovie: %w", storage.ErrNotFound),
 		},
 		{
 			name:      "GetMovie Error",
 			movieId:   1,
 			respError: "movie search failed",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -55,7 +73,7 @@ func TestSaveHandler(t *testing.T) {
 					Once()
 			}
 
-			handler := searchById.New(slogdiscard.NewDiscardLogger(), movieSearcherByIdMock)
+			handler := searchById.New(slogdiscard.NewDiscardLogger(), movieSearcherByIdMock, mocks.NewListFlagsSetter(t))
 
 			input := fmt.Sprintf(`{"movie_id": %d}`, tc.movieId)
 
@@ -65,7 +83,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -77,3 +95,164 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestPathParam(t *testing.T) {
+	movieSearcherByIdMock := mocks.NewMovieSearcherById(t)
+
+	movieSearcherByIdMock.On("GetMovie", 5).
+		Return(postgres.Movie{Id: 5}, nil).
+		Once()
+
+	router := chi.NewRouter()
+	router.Get("/api/v1/movies/{movie_id}", searchById.New(slogdiscard.NewDiscardLogger(), movieSearcherByIdMock, mocks.NewListFlagsSetter(t)))
+
+	req, err := http.NewRequest(http.MethodGet, "/api/v1/movies/5", nil)
+	require.NoError(t, err)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	require.Equal(t, http.StatusOK, rr.Code)
+
+	var resp searchById.Response
+
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+	require.Empty(t, resp.Error)
+	require.Equal(t, 5, resp.Movie.Id)
+}
+
+func TestETag(t *testing.T) {
+	score := 7.5
+	movie := postgres.Movie{Id: 1, Version: 3, CommunityScore: &score, VoteCount: 2}
+	current := etag.FormatState(3, etag.State(&score, 2, nil))
+
+	cases := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{
+			name:   "No cached copy",
+			status: http.StatusOK,
+		},
+		{
+			name:        "Current copy",
+			ifNoneMatch: "W/" + current,
+			status:      http.StatusNotModified,
+		},
+		{
+			name:        "Stale copy",
+			ifNoneMatch: etag.FormatState(2, etag.State(&score, 2, nil)),
+			status:      http.StatusOK,
+		},
+		{
+			name:        "New votes",
+			ifNoneMatch: etag.FormatState(3, etag.State(&score, 1, nil)),
+			status:      http.StatusOK,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieSearcherByIdMock := mocks.NewMovieSearcherById(t)
+
+			movieSearcherByIdMock.On("GetMovie", 1).
+				Return(movie, nil).
+				Once()
+
+			handler := searchById.New(slogdiscard.NewDiscardLogger(), movieSearcherByIdMock, mocks.NewListFlagsSetter(t))
+
+			req, err := http.NewRequest(http.MethodGet, "/movie/search_by_id?movie_id=1", nil)
+			require.NoError(t, err)
+			if tc.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tc.ifNoneMatch)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+			require.Equal(t, current, rr.Header().Get("ETag"))
+
+			if tc.status == http.StatusNotModified {
+				require.Empty(t, rr.Body.String())
+			}
+		})
+	}
+}
+
+func TestListFlags(t *testing.T) {
+	cases := []struct {
+		name      string
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success",
+			status: http.StatusOK,
+		},
+		{
+			name:      "SetListFlags Error",
+			respError: "movie search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieSearcherByIdMock := mocks.NewMovieSearcherById(t)
+			listFlagsSetterMock := mocks.NewListFlagsSetter(t)
+
+			movieSearcherByIdMock.On("GetMovie", 1).
+				Return(postgres.Movie{Id: 1}, nil).
+				Once()
+
+			listFlagsSetterMock.On("SetListFlags", 7, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(1).([]*postgres.Movie)[0].Lists = &postgres.ListFlags{Watchlist: true, ListIds: []int{}}
+				}).
+				Return(tc.mockError).
+				Once()
+
+			handler := searchById.New(slogdiscard.NewDiscardLogger(), movieSearcherByIdMock, listFlagsSetterMock)
+
+			req, err := http.NewRequest(http.MethodGet, "/movie/search_by_id?movie_id=1", nil)
+			require.NoError(t, err)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+			// the tag of the movie without the caller's flags
+			req.Header.Set("If-None-Match", etag.FormatState(0, etag.State(nil, 0, nil)))
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp searchById.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			if tc.respError == "" {
+				require.True(t, resp.Movie.Lists.Watchlist)
+				require.Equal(t, "private", rr.Header().Get("Cache-Control"))
+				require.Equal(t, "Authorization", rr.Header().Get("Vary"))
+			}
+		})
+	}
+}
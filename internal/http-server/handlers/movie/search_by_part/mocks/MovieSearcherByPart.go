@@ -13,34 +13,41 @@ type MovieSearcherByPart struct {
 	mock.Mock
 }
 
-// GetMoviesBySearchRequest provides a mock function with given fields: searchRequest
-func (_m *MovieSearcherByPart) GetMoviesBySearchRequest(searchRequest string) ([]postgres.Movie, error) {
-	ret := _m.Called(searchRequest)
+// GetMoviesBySearchRequest provides a mock function with given fields: searchRequest, page
+func (_m *MovieSearcherByPart) GetMoviesBySearchRequest(searchRequest string, page postgres.Page) ([]postgres.MovieSearchResult, postgres.PageInfo, error) {
+	ret := _m.Called(searchRequest, page)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMoviesBySearchRequest")
 	}
 
-	var r0 []postgres.Movie
-	var r1 error
-	if rf, ok := ret.Get(0).(func(string) ([]postgres.Movie, error)); ok {
-		return rf(searchRequest)
+	var r0 []postgres.MovieSearchResult
+	var r1 postgres.PageInfo
+	var r2 error
+	if rf, ok := ret.Get(0).(func(string, postgres.Page) ([]postgres.MovieSearchResult, postgres.PageInfo, error)); ok {
+		return rf(searchRequest, page)
 	}
-	if rf, ok := ret.Get(0).(func(string) []postgres.Movie); ok {
-		r0 = rf(searchRequest)
+	if rf, ok := ret.Get(0).(func(string, postgres.Page) []postgres.MovieSearchResult); ok {
+		r0 = rf(searchRequest, page)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]postgres.Movie)
+			r0 = ret.Get(0).([]postgres.MovieSearchResult)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(string) error); ok {
-		r1 = rf(searchRequest)
+	if rf, ok := ret.Get(1).(func(string, postgres.Page) postgres.PageInfo); ok {
+		r1 = rf(searchRequest, page)
 	} else {
-		r1 = ret.Error(1)
+		r1 = ret.Get(1).(postgres.PageInfo)
 	}
 
-	return r0, r1
+	if rf, ok := ret.Get(2).(func(string, postgres.Page) error); ok {
+		r2 = rf(searchRequest, page)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
 // NewMovieSearcherByPart creates a new instance of MovieSearcherByPart. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -1,40 +1,60 @@
 package search_by_part
 
 import (
+	"errors"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
 	"film_library/internal/storage/postgres"
 	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"strings"
 )
 
 type Request struct {
-	Part string `json:"part"`
+	Part      string `json:"part"`
+	Limit     int    `json:"limit"`
+	Cursor    string `json:"cursor"`
+	WithTotal bool   `json:"with_total"`
 }
 
 type Response struct {
 	response.Response
-	Movies []postgres.Movie `json:"movies"`
+	Movies []postgres.MovieSearchResult `json:"movies"`
+	postgres.PageInfo
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=MovieSearcherByPart
 type MovieSearcherByPart interface {
-	GetMoviesBySearchRequest(searchRequest string) ([]postgres.Movie, error)
+	GetMoviesBySearchRequest(searchRequest string, page postgres.Page) ([]postgres.MovieSearchResult, postgres.PageInfo, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ListFlagsSetter
+type ListFlagsSetter interface {
+	SetListFlags(userId int, movies []*postgres.Movie) error
 }
 
 // @Summary		Search a movie by part
-// @Description	Search a movie by part
+// @Description	Full-text search over titles, descriptions and actor names, tolerant to typos, most relevant first
 // @Tags			Movie
 // @Accept			json
 // @Produce		json
 // @Param			part	query		string	true	"Part"
+// @Param			limit		query		int		false	"Page size, 50 by default"
+// @Param			cursor		query		string	false	"next_cursor of the previous page"
+// @Param			with_total	query		bool	false	"Return the total count"
 // @Success		200		{object}	Response
 // @Failure		400		{object}	response.Response
 // @Failure		401		{object}	response.Response
+// @Failure		500		{object}	response.Response
 // @Router			/movie/search_by_part [get]
-func New(log *slog.Logger, movieSearcher MovieSearcherByPart) http.HandlerFunc {
+// @Router			/api/v1/movies/search [get]
+func New(log *slog.Logger, movieSearcher MovieSearcherByPart, listFlagsSetter ListFlagsSetter) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movie.search_by_part.New"
 
@@ -45,31 +65,76 @@ func New(log *slog.Logger, movieSearcher MovieSearcherByPart) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
 
 		log.Info("request body decoded", slog.Any("request", req))
 
-		movies, err := movieSearcher.GetMoviesBySearchRequest(req.Part)
+		if strings.TrimSpace(req.Part) == "" || len(req.Part) > 255 {
+			log.Error("invalid part", slog.String("part", req.Part))
+
+			response.ValidationFailed(w, r, "part", "field part is not valid")
+
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > postgres.MaxPageLimit {
+			log.Error("invalid limit", slog.Int("limit", req.Limit))
+
+			response.ValidationFailed(w, r, "limit", "field limit is not valid")
+
+			return
+		}
+
+		movies, page, err := movieSearcher.GetMoviesBySearchRequest(req.Part, postgres.Page{
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+			WithTotal: req.WithTotal,
+		})
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Error("invalid cursor", sl.Err(err))
+
+			response.ValidationFailed(w, r, "cursor", "field cursor is not valid")
+
+			return
+		}
+
 		if err != nil {
 			log.Error("movies search failed", sl.Err(err))
 
-			render.JSON(w, r, response.Error("movies search failed"))
+			response.FromError(w, r, err, "movies search failed")
 
 			return
 		}
 
+		if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+			if userId, ok := token.UserId(claims); ok {
+				ptrs := make([]*postgres.Movie, len(movies))
+				for i := range movies {
+					ptrs[i] = &movies[i].Movie
+				}
+				if err = listFlagsSetter.SetListFlags(userId, ptrs); err != nil {
+					log.Error("failed to set list flags", sl.Err(err))
+
+					response.FromError(w, r, err, "movies search failed")
+
+					return
+				}
+			}
+		}
+
 		log.Info("movies found", slog.Int("movie_count", len(movies)))
 
 		render.JSON(w, r, Response{
 			response.OK(),
 			movies,
+			page,
 		})
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"film_library/internal/storage"
 	"film_library/internal/storage/postgres"
 	"fmt"
 	"net/http"
@@ -19,19 +20,54 @@ import (
 
 func TestSaveHandler(t *testing.T) {
 	cases := []struct {
-		name      string
-		part      string
-		respError string
-		mockError error
+		name       string
+		part       string
+		limit      int
+		cursor     string
+		nextCursor string
+		respError  string
+		status     int
+		mockError  error
 	}{
 		{
-			name: "Success",
-			part: "test",
+			name:       "Success",
+			part:       "test",
+			nextCursor: "next",
+			status:     http.StatusOK,
+		},
+		{
+			name:   "Page",
+			part:   "test",
+			limit:  10,
+			cursor: "abc",
+			status: http.StatusOK,
+		},
+		{
+			name:      "Empty part",
+			part:      " ",
+			respError: "field part is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid limit",
+			part:      "test",
+			limit:     501,
+			respError: "field limit is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid cursor",
+			part:      "test",
+			cursor:    "abc",
+			respError: "field cursor is not valid",
+			status:    http.StatusBadRequest,
+			mockError: fmt.Errorf("storage.postgres.GetMoviesBySearchRequest: %w", storage.ErrInvalidCursor),
 		},
 		{
 			name:      "GetMoviesBySearchRequest Error",
 			part:      "test",
 			respError: "movies search failed",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -45,14 +81,14 @@ func TestSaveHandler(t *testing.T) {
 			movieSearcherByPartMock := mocks.NewMovieSearcherByPart(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				movieSearcherByPartMock.On("GetMoviesBySearchRequest", tc.part).
-					Return([]postgres.Movie{}, tc.mockError).
+				movieSearcherByPartMock.On("GetMoviesBySearchRequest", tc.part, postgres.Page{Limit: tc.limit, Cursor: tc.cursor}).
+					Return([]postgres.MovieSearchResult{}, postgres.PageInfo{NextCursor: tc.nextCursor}, tc.mockError).
 					Once()
 			}
 
-			handler := searchByPart.New(slogdiscard.NewDiscardLogger(), movieSearcherByPartMock)
+			handler := searchByPart.New(slogdiscard.NewDiscardLogger(), movieSearcherByPartMock, mocks.NewListFlagsSetter(t))
 
-			input := fmt.Sprintf(`{"part": "%s"}`, tc.part)
+			input := fmt.Sprintf(`{"part": "%s", "limit": %d, "cursor": "%s"}`, tc.part, tc.limit, tc.cursor)
 
 			req, err := http.NewRequest(http.MethodGet, "/movie/search_by_part", bytes.NewReader([]byte(input)))
 			require.NoError(t, err)
@@ -60,7 +96,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -69,6 +105,7 @@ func TestSaveHandler(t *testing.T) {
 			require.NoError(t, json.Unmarshal([]byte(body), &resp))
 
 			require.Equal(t, tc.respError, resp.Error)
+			require.Equal(t, tc.nextCursor, resp.NextCursor)
 		})
 	}
 }
@@ -2,83 +2,45 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	postgres "film_library/internal/storage/postgres"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // MovieUpdater is an autogenerated mock type for the MovieUpdater type
 type MovieUpdater struct {
 	mock.Mock
 }
 
-// UpdateMovieDescription provides a mock function with given fields: movieId, description
-func (_m *MovieUpdater) UpdateMovieDescription(movieId int, description string) error {
-	ret := _m.Called(movieId, description)
+// UpdateMovie provides a mock function with given fields: ctx, movieId, patch, version
+func (_m *MovieUpdater) UpdateMovie(ctx context.Context, movieId int, patch postgres.MoviePatch, version int) (int, error) {
+	ret := _m.Called(ctx, movieId, patch, version)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateMovieDescription")
+		panic("no return value specified for UpdateMovie")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(movieId, description)
-	} else {
-		r0 = ret.Error(0)
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, postgres.MoviePatch, int) (int, error)); ok {
+		return rf(ctx, movieId, patch, version)
 	}
-
-	return r0
-}
-
-// UpdateMovieRating provides a mock function with given fields: movieId, rating
-func (_m *MovieUpdater) UpdateMovieRating(movieId int, rating int) error {
-	ret := _m.Called(movieId, rating)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateMovieRating")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, int) error); ok {
-		r0 = rf(movieId, rating)
+	if rf, ok := ret.Get(0).(func(context.Context, int, postgres.MoviePatch, int) int); ok {
+		r0 = rf(ctx, movieId, patch, version)
 	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// UpdateMovieReleaseDate provides a mock function with given fields: movieId, releaseDate
-func (_m *MovieUpdater) UpdateMovieReleaseDate(movieId int, releaseDate string) error {
-	ret := _m.Called(movieId, releaseDate)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateMovieReleaseDate")
-	}
-
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(movieId, releaseDate)
-	} else {
-		r0 = ret.Error(0)
-	}
-
-	return r0
-}
-
-// UpdateMovieTitle provides a mock function with given fields: movieId, title
-func (_m *MovieUpdater) UpdateMovieTitle(movieId int, title string) error {
-	ret := _m.Called(movieId, title)
-
-	if len(ret) == 0 {
-		panic("no return value specified for UpdateMovieTitle")
+		r0 = ret.Get(0).(int)
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(int, string) error); ok {
-		r0 = rf(movieId, title)
+	if rf, ok := ret.Get(1).(func(context.Context, int, postgres.MoviePatch, int) error); ok {
+		r1 = rf(ctx, movieId, patch, version)
 	} else {
-		r0 = ret.Error(0)
+		r1 = ret.Error(1)
 	}
 
-	return r0
+	return r0, r1
 }
 
 // NewMovieUpdater creates a new instance of MovieUpdater. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
@@ -1,8 +1,16 @@
 package update
 
 import (
+	"context"
+	"errors"
+	movieSave "film_library/internal/http-server/handlers/movie/save"
+	"film_library/internal/lib/api/etag"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"fmt"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
 	"log/slog"
@@ -11,11 +19,17 @@ import (
 )
 
 type Request struct {
-	MovieId     int     `json:"movie_id"`
-	Title       *string `json:"title,omitempty"`
-	Description *string `json:"description,omitempty"`
-	ReleaseDate *string `json:"release_date,omitempty"`
-	Rating      *int    `json:"rating,omitempty"`
+	MovieId          int      `json:"movie_id"`
+	Title            *string  `json:"title,omitempty"`
+	Description      *string  `json:"description,omitempty" patch:"nullable"`
+	ReleaseDate      *string  `json:"release_date,omitempty"`
+	Rating           *int     `json:"rating,omitempty"`
+	Runtime          *int     `json:"runtime,omitempty" patch:"nullable"`
+	OriginalLanguage *string  `json:"original_language,omitempty" patch:"nullable"`
+	Certification    *string  `json:"certification,omitempty" patch:"nullable"`
+	Genres           []string `json:"genres,omitempty" patch:"nullable"`
+	Countries        []string `json:"countries,omitempty" patch:"nullable"`
+	Tags             []string `json:"tags,omitempty" patch:"nullable"`
 }
 
 type Response struct {
@@ -24,27 +38,36 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=MovieUpdater
 type MovieUpdater interface {
-	UpdateMovieTitle(movieId int, title string) error
-	UpdateMovieDescription(movieId int, description string) error
-	UpdateMovieReleaseDate(movieId int, releaseDate string) error
-	UpdateMovieRating(movieId int, rating int) error
+	UpdateMovie(ctx context.Context, movieId int, patch postgres.MoviePatch, version int) (int, error)
 }
 
 // @Summary		Update movie
-// @Description	Update movie by movie_id
+// @Description	Update movie by movie_id. Fields left out are kept, all given fields are changed at once. Given genres, countries and tags replace the current ones, zero runtime, empty original_language and certification clear them. PATCH also takes application/merge-patch+json bodies, where null clears any field but title, release_date and rating
 // @Tags			Movie
-// @Accept			json
+// @Accept			json,application/merge-patch+json
 // @Produce		json
-// @Param			movie_id		path		int		true	"Movie ID"
-// @Param			title			body		string	false	"Title"
-// @Param			description		body		string	false	"Description"
-// @Param			release_date	body		string	false	"Release Date"
-// @Param			rating			body		int		false	"Rating"
-// @Success		200				{object}	Response
-// @Failure		400				{object}	response.Response
-// @Failure		401				{object}	response.Response
-// @Failure		403				{object}	response.Response
+// @Param			movie_id			path		int			true	"Movie ID"
+// @Param			title				body		string		false	"Title"
+// @Param			description			body		string		false	"Description"
+// @Param			release_date		body		string		false	"Release Date"
+// @Param			rating				body		int			false	"Rating"
+// @Param			runtime				body		int			false	"Runtime, minutes"
+// @Param			original_language	body		string		false	"Original language, e.g. fr"
+// @Param			certification		body		string		false	"Age certification, e.g. PG-13 or 16+"
+// @Param			genres				body		[]string	false	"Genres, e.g. drama"
+// @Param			countries			body		[]string	false	"Production countries, e.g. FR"
+// @Param			tags				body		[]string	false	"Tags"
+// @Param			If-Match			header		string		false	"ETag the change is based on"
+// @Success		200					{object}	Response
+// @Header			200					{string}	ETag	"New version of the movie"
+// @Failure		400					{object}	response.Response
+// @Failure		401					{object}	response.Response
+// @Failure		403					{object}	response.Response
+// @Failure		404					{object}	response.Response
+// @Failure		412					{object}	response.Response
+// @Failure		500					{object}	response.Response
 // @Router			/movie/update [post]
+// @Router			/api/v1/movies/{movie_id} [patch]
 func New(log *slog.Logger, movieSaver MovieUpdater) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.movie.update.New"
@@ -56,11 +79,20 @@ func New(log *slog.Logger, movieSaver MovieUpdater) http.HandlerFunc {
 
 		var req Request
 
-		err := render.DecodeJSON(r.Body, &req)
+		err := request.Decode(r, &req)
+		var nullErr *request.NullFieldError
+		if errors.As(err, &nullErr) {
+			log.Error("field can not be removed", slog.String("field", nullErr.Field))
+
+			response.ValidationFailed(w, r, nullErr.Field, fmt.Sprintf("field %s is not valid", nullErr.Field))
+
+			return
+		}
+
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
@@ -70,64 +102,60 @@ func New(log *slog.Logger, movieSaver MovieUpdater) http.HandlerFunc {
 		if ok, field, msg := validateRequest(req); !ok {
 			log.Error("invalid request", field)
 
-			render.JSON(w, r, response.Error(msg))
+			response.ValidationFailed(w, r, field.Value.String(), msg)
 
 			return
 		}
 
-		if req.Title != nil {
-			err := movieSaver.UpdateMovieTitle(req.MovieId, *req.Title)
-			if err != nil {
-				log.Error("failed to update movie title", sl.Err(err))
-
-				render.JSON(w, r, response.Error("failed to update movie title"))
-
-				return
-			}
-		}
-
-		if req.Description != nil {
-			err := movieSaver.UpdateMovieDescription(req.MovieId, *req.Description)
-			if err != nil {
-				log.Error("failed to update movie description", sl.Err(err))
+		if req.Title == nil && req.Description == nil && req.ReleaseDate == nil && req.Rating == nil &&
+			req.Runtime == nil && req.OriginalLanguage == nil && req.Certification == nil &&
+			req.Genres == nil && req.Countries == nil && req.Tags == nil {
+			log.Error("no fields to update")
 
-				render.JSON(w, r, response.Error("failed to update movie description"))
+			response.BadRequest(w, r, "no fields to update")
 
-				return
-			}
+			return
 		}
 
-		if req.ReleaseDate != nil {
-			err := movieSaver.UpdateMovieReleaseDate(req.MovieId, *req.ReleaseDate)
-			if err != nil {
-				log.Error("failed to update movie release date", sl.Err(err))
+		version, err := movieSaver.UpdateMovie(r.Context(), req.MovieId, postgres.MoviePatch{
+			Title:            req.Title,
+			Description:      req.Description,
+			ReleaseDate:      req.ReleaseDate,
+			Rating:           req.Rating,
+			Runtime:          req.Runtime,
+			OriginalLanguage: req.OriginalLanguage,
+			Certification:    req.Certification,
+			Genres:           req.Genres,
+			Countries:        req.Countries,
+			Tags:             req.Tags,
+		}, etag.IfMatch(r))
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Error("movie not found", slog.Int("movie_id", req.MovieId))
+
+			response.FromError(w, r, err, "movie not found")
 
-				render.JSON(w, r, response.Error("failed to update movie release date"))
-
-				return
-			}
+			return
 		}
 
-		if req.Rating != nil {
-			err := movieSaver.UpdateMovieRating(req.MovieId, *req.Rating)
-			if err != nil {
-				log.Error("failed to update movie rating", sl.Err(err))
+		if errors.Is(err, storage.ErrVersionMismatch) {
+			log.Warn("movie has been changed", slog.Int("movie_id", req.MovieId))
 
-				render.JSON(w, r, response.Error("failed to update movie rating"))
+			response.FromError(w, r, err, "movie has been changed")
 
-				return
-			}
+			return
 		}
 
-		if req.Title == nil && req.Description == nil && req.ReleaseDate == nil && req.Rating == nil {
-			log.Error("no fields to update")
+		if err != nil {
+			log.Error("failed to update movie", sl.Err(err))
 
-			render.JSON(w, r, response.Error("no fields to update"))
+			response.FromError(w, r, err, "failed to update movie")
 
 			return
 		}
 
-		log.Info("movie updated", slog.Int("movie_id", req.MovieId))
+		log.Info("movie updated", slog.Int("movie_id", req.MovieId), slog.Int("version", version))
+
+		etag.Set(w, version)
 
 		render.JSON(w, r, Response{response.OK()})
 	}
@@ -151,5 +179,17 @@ func validateRequest(req Request) (bool, slog.Attr, string) {
 	if req.Rating != nil && (*req.Rating < 0 || *req.Rating > 10) {
 		return false, slog.String("field", "rating"), "field rating is not valid"
 	}
-	return true, slog.Attr{}, ""
+
+	// fields left out are zero, which is always valid
+	details := postgres.MovieDetails{Genres: req.Genres, Countries: req.Countries, Tags: req.Tags}
+	if req.Runtime != nil {
+		details.Runtime = *req.Runtime
+	}
+	if req.OriginalLanguage != nil {
+		details.OriginalLanguage = *req.OriginalLanguage
+	}
+	if req.Certification != nil {
+		details.Certification = *req.Certification
+	}
+	return movieSave.ValidateDetails(details)
 }
@@ -4,16 +4,22 @@ import (
 	"bytes"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
 	"testing"
 
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/movie/update"
 	"film_library/internal/http-server/handlers/movie/update/mocks"
+	"film_library/internal/lib/api/request"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
 )
 
 const bigTitle = "Lorem ipsum dolor sit amet, consectetuer adipiscing elit, sed diam nonummy nibh euismod tincidunt ut laoreet dolore magna aliquam erat volutpat. Ut wisi enim ad minim veniam, quis nostrud exerci tation ullamcorper suscipit lobortis nisl ut aliquip ex ea co"
@@ -28,51 +34,60 @@ func TestSaveHandler(t *testing.T) {
 		releaseDate string
 		rating      int
 		respError   string
+		status      int
 		mockError   error
 	}{
 		{
 			name:    "Success update title",
 			movieId: 1,
 			title:   "Best movie",
+			status:  http.StatusOK,
 		},
 		{
 			name:      "Invalid title",
 			movieId:   1,
 			title:     bigTitle,
 			respError: "field title is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:        "Success update description",
 			movieId:     1,
 			description: "some description",
+			status:      http.StatusOK,
 		},
 		{
 			name:        "Invalid description",
 			movieId:     1,
 			description: bigDesription,
 			respError:   "field description is not valid",
+			status:      http.StatusBadRequest,
 		},
 		{
 			name:        "Success update release_date",
 			movieId:     1,
 			releaseDate: "2000-01-01",
+			status:      http.StatusOK,
 		},
 		{
 			name:        "Invalid release_date",
 			movieId:     1,
 			releaseDate: "1900.01.01",
 			respError:   "field release_date is not valid",
+			status:      http.StatusBadRequest,
 		},
 		{
 			name:    "Success update rating",
 			movieId: 1,
 			rating:  10,
+			status:  http.StatusOK,
 		},
 		{
 			name:      "Invalid rating",
 			movieId:   1,
 			rating:    11,
 			respError: "field rating is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:        "Success update all",
@@ -81,20 +96,45 @@ func TestSaveHandler(t *testing.T) {
 			description: "some description",
 			releaseDate: "2000-01-01",
 			rating:      10,
+			status:      http.StatusOK,
 		},
 		{
 			name:      "Invalid movieId",
 			movieId:   0,
 			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
 		},
 		{
-			name:        "UpdateActor Error",
+			name:      "No fields",
+			movieId:   1,
+			respError: "no fields to update",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			movieId:   1,
+			title:     "Best movie",
+			respError: "movie not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.UpdateMovie: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "Version mismatch",
+			movieId:   1,
+			title:     "Best movie",
+			respError: "movie has been changed",
+			status:    http.StatusPreconditionFailed,
+			mockError: fmt.Errorf("storage.postgres.UpdateMovie: %w", storage.ErrVersionMismatch),
+		},
+		{
+			name:        "UpdateMovie Error",
 			movieId:     1,
 			title:       "Best movie",
 			description: "some description",
 			releaseDate: "2000-01-01",
 			rating:      10,
-			respError:   "failed to update movie title",
+			respError:   "failed to update movie",
+			status:      http.StatusInternalServerError,
 			mockError:   errors.New("unexpected error"),
 		},
 	}
@@ -108,18 +148,18 @@ func TestSaveHandler(t *testing.T) {
 			movieUpdaterMock := mocks.NewMovieUpdater(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				movieUpdaterMock.On("UpdateMovieTitle", tc.movieId, tc.title).
-					Return(tc.mockError).
-					Maybe()
-				movieUpdaterMock.On("UpdateMovieReleaseDate", tc.movieId, tc.releaseDate).
-					Return(tc.mockError).
-					Maybe()
-				movieUpdaterMock.On("UpdateMovieRating", tc.movieId, tc.rating).
-					Return(tc.mockError).
-					Maybe()
-				movieUpdaterMock.On("UpdateMovieDescription", tc.movieId, tc.description).
-					Return(tc.mockError).
-					Maybe()
+				patch := postgres.MoviePatch{
+					Title:       optional(tc.title),
+					Description: optional(tc.description),
+					ReleaseDate: optional(tc.releaseDate),
+				}
+				if tc.rating != 0 {
+					patch.Rating = &tc.rating
+				}
+
+				movieUpdaterMock.On("UpdateMovie", mock.Anything, tc.movieId, patch, 0).
+					Return(2, tc.mockError).
+					Once()
 			}
 
 			handler := update.New(slogdiscard.NewDiscardLogger(), movieUpdaterMock)
@@ -147,7 +187,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -159,3 +199,155 @@ func TestSaveHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestMergePatch(t *testing.T) {
+	zero, empty := 0, ""
+
+	cases := []struct {
+		name      string
+		body      string
+		patch     postgres.MoviePatch
+		respError string
+		status    int
+	}{
+		{
+			name:   "Success",
+			body:   `{"title": "Best movie"}`,
+			patch:  postgres.MoviePatch{Title: optional("Best movie")},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Null clears details",
+			body:   `{"runtime": null, "certification": null, "genres": null}`,
+			patch:  postgres.MoviePatch{Runtime: &zero, Certification: &empty, Genres: []string{}},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Null member",
+			body:      `{"rating": null}`,
+			respError: "field rating is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Null title",
+			body:      `{"title": null}`,
+			respError: "field title is not valid",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieUpdaterMock := mocks.NewMovieUpdater(t)
+
+			if tc.respError == "" {
+				movieUpdaterMock.On("UpdateMovie", mock.Anything, 7, tc.patch, 3).
+					Return(4, nil).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Patch("/api/v1/movies/{movie_id}", update.New(slogdiscard.NewDiscardLogger(), movieUpdaterMock))
+
+			req, err := http.NewRequest(http.MethodPatch, "/api/v1/movies/7", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", request.MergePatchContentType)
+			req.Header.Set("If-Match", `"3"`)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			if tc.respError == "" {
+				require.Equal(t, `"4"`, rr.Header().Get("ETag"))
+			}
+
+			var resp update.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func TestUpdateDetails(t *testing.T) {
+	zero := 0
+
+	cases := []struct {
+		name      string
+		body      string
+		patch     postgres.MoviePatch
+		respError string
+		status    int
+	}{
+		{
+			name: "Replace lists",
+			body: `{"movie_id": 1, "genres": ["drama"], "countries": ["FR", "BE"], "original_language": "fr"}`,
+			patch: postgres.MoviePatch{
+				Genres:           []string{"drama"},
+				Countries:        []string{"FR", "BE"},
+				OriginalLanguage: optional("fr"),
+			},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Clear tags and runtime",
+			body:   `{"movie_id": 1, "tags": [], "runtime": 0}`,
+			patch:  postgres.MoviePatch{Tags: []string{}, Runtime: &zero},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid runtime",
+			body:      `{"movie_id": 1, "runtime": -5}`,
+			respError: "field runtime is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid country",
+			body:      `{"movie_id": 1, "countries": ["France"]}`,
+			respError: "field countries is not valid",
+			status:    http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			movieUpdaterMock := mocks.NewMovieUpdater(t)
+
+			if tc.respError == "" {
+				movieUpdaterMock.On("UpdateMovie", mock.Anything, 1, tc.patch, 0).
+					Return(2, nil).
+					Once()
+			}
+
+			handler := update.New(slogdiscard.NewDiscardLogger(), movieUpdaterMock)
+
+			req, err := http.NewRequest(http.MethodPost, "/movie/update", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp update.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
+
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,105 @@
+package delete
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	ReviewId int `json:"review_id"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ReviewDeleter
+type ReviewDeleter interface {
+	DeleteReview(ctx context.Context, reviewId int, userId int) error
+}
+
+// @Summary		Delete a review
+// @Description	Delete an own review by review_id
+// @Tags			Review
+// @Accept			json
+// @Produce		json
+// @Param			review_id	path		int	true	"Review ID"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		403			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/reviews/{review_id} [delete]
+func New(log *slog.Logger, reviewDeleter ReviewDeleter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.review.delete.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.ReviewId < 1 {
+			log.Error("invalid review_id", slog.Int("review_id", req.ReviewId))
+
+			response.ValidationFailed(w, r, "review_id", "field review_id is not valid")
+
+			return
+		}
+
+		err = reviewDeleter.DeleteReview(r.Context(), req.ReviewId, userId)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Warn("review not found", slog.Int("review_id", req.ReviewId), slog.Int("user_id", userId))
+
+			response.FromError(w, r, err, "review not found")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to delete review", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to delete review")
+
+			return
+		}
+
+		log.Info("review deleted", slog.Int("review_id", req.ReviewId))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
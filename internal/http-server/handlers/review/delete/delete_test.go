@@ -0,0 +1,109 @@
+package delete_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/review/delete"
+	"film_library/internal/http-server/handlers/review/delete/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestDeleteHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		reviewId  int
+		noToken   bool
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:     "Success",
+			reviewId: 5,
+			status:   http.StatusOK,
+		},
+		{
+			name:      "No token",
+			reviewId:  5,
+			noToken:   true,
+			respError: "unauthorized",
+			status:    http.StatusUnauthorized,
+		},
+		{
+			name:      "Invalid review_id",
+			reviewId:  -1,
+			respError: "field review_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Review of another user",
+			reviewId:  5,
+			respError: "review not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.DeleteReview: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "DeleteReview Error",
+			reviewId:  5,
+			respError: "failed to delete review",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewDeleterMock := mocks.NewReviewDeleter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				reviewDeleterMock.On("DeleteReview", mock.Anything, tc.reviewId, 7).
+					Return(tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Delete("/api/v1/reviews/{review_id}", delete.New(slogdiscard.NewDiscardLogger(), reviewDeleterMock))
+
+			req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/reviews/%d", tc.reviewId), nil)
+			require.NoError(t, err)
+
+			if !tc.noToken {
+				accessToken, err := tokens.IssueAccess(7, nil, nil)
+				require.NoError(t, err)
+
+				parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+				require.NoError(t, err)
+
+				req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp delete.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
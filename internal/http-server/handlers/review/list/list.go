@@ -0,0 +1,127 @@
+package list
+
+import (
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	MovieId   int    `json:"movie_id"`
+	Limit     int    `json:"limit"`
+	Cursor    string `json:"cursor"`
+	WithTotal bool   `json:"with_total"`
+}
+
+type Response struct {
+	response.Response
+	Reviews []postgres.Review `json:"reviews"`
+	postgres.PageInfo
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ReviewsGetter
+type ReviewsGetter interface {
+	GetReviews(filter postgres.ReviewFilter, page postgres.Page) ([]postgres.Review, postgres.PageInfo, error)
+}
+
+// @Summary		List reviews of a movie
+// @Description	List the published reviews of a movie, most recent first
+// @Tags			Review
+// @Accept			json
+// @Produce		json
+// @Param			movie_id	path		int		true	"Movie ID"
+// @Param			limit		query		int		false	"Page size, 50 by default"
+// @Param			cursor		query		string	false	"next_cursor of the previous page"
+// @Param			with_total	query		bool	false	"Return the total count"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/movies/{movie_id}/reviews [get]
+func New(log *slog.Logger, reviewsGetter ReviewsGetter) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.review.list.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Any("request", req))
+
+		if req.MovieId < 1 {
+			log.Error("invalid movie_id", slog.Int("movie_id", req.MovieId))
+
+			response.ValidationFailed(w, r, "movie_id", "field movie_id is not valid")
+
+			return
+		}
+
+		if req.Limit < 0 || req.Limit > postgres.MaxPageLimit {
+			log.Error("invalid limit", slog.Int("limit", req.Limit))
+
+			response.ValidationFailed(w, r, "limit", "field limit is not valid")
+
+			return
+		}
+
+		reviews, page, err := reviewsGetter.GetReviews(postgres.ReviewFilter{
+			MovieId: req.MovieId,
+			Status:  postgres.ReviewPublished,
+		}, postgres.Page{
+			Limit:     req.Limit,
+			Cursor:    req.Cursor,
+			WithTotal: req.WithTotal,
+		})
+		if errors.Is(err, storage.ErrInvalidCursor) {
+			log.Error("invalid cursor", sl.Err(err))
+
+			response.ValidationFailed(w, r, "cursor", "field cursor is not valid")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Error("movie not found", slog.Int("movie_id", req.MovieId))
+
+			response.FromError(w, r, err, "movie not found")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("reviews search failed", sl.Err(err))
+
+			response.FromError(w, r, err, "reviews search failed")
+
+			return
+		}
+
+		log.Info("reviews found", slog.Int("reviews_count", len(reviews)))
+
+		render.JSON(w, r, Response{
+			response.OK(),
+			reviews,
+			page,
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package list_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/review/list"
+	"film_library/internal/http-server/handlers/review/list/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestListHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		movieId   int
+		query     string
+		page      postgres.Page
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:    "Success",
+			movieId: 1,
+			status:  http.StatusOK,
+		},
+		{
+			name:    "Page",
+			movieId: 1,
+			query:   "?limit=10&cursor=abc&with_total=true",
+			page:    postgres.Page{Limit: 10, Cursor: "abc", WithTotal: true},
+			status:  http.StatusOK,
+		},
+		{
+			name:      "Invalid movie_id",
+			movieId:   0,
+			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid limit",
+			movieId:   1,
+			query:     "?limit=1000",
+			respError: "field limit is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid cursor",
+			movieId:   1,
+			respError: "field cursor is not valid",
+			status:    http.StatusBadRequest,
+			mockError: fmt.Errorf("storage.postgres.GetReviews: %w", storage.ErrInvalidCursor),
+		},
+		{
+			name:      "Movie not found",
+			movieId:   1,
+			respError: "movie not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.GetReviews: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "GetReviews Error",
+			movieId:   1,
+			respError: "reviews search failed",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewsGetterMock := mocks.NewReviewsGetter(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				reviewsGetterMock.On("GetReviews", postgres.ReviewFilter{MovieId: tc.movieId, Status: postgres.ReviewPublished}, tc.page).
+					Return([]postgres.Review{{Id: 3, MovieId: tc.movieId, Score: 8}}, postgres.PageInfo{}, tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Get("/api/v1/movies/{movie_id}/reviews", list.New(slogdiscard.NewDiscardLogger(), reviewsGetterMock))
+
+			req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("/api/v1/movies/%d/reviews%s", tc.movieId, tc.query), nil)
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp list.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			if tc.respError == "" {
+				require.Len(t, resp.Reviews, 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package save
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	MovieId int    `json:"movie_id"`
+	Score   int    `json:"score"`
+	Body    string `json:"body"`
+}
+
+type Response struct {
+	response.Response
+	ReviewId int `json:"review_id"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ReviewSaver
+type ReviewSaver interface {
+	SaveReview(ctx context.Context, movieId int, userId int, score int, body string) (int, error)
+}
+
+// @Summary		Review a movie
+// @Description	Score a movie from 1 to 10, optionally with a text. Every user reviews a movie once and then edits the review
+// @Tags			Review
+// @Accept			json
+// @Produce		json
+// @Param			movie_id	path		int		true	"Movie ID"
+// @Param			score		body		int		true	"Score, 1 to 10"
+// @Param			body		body		string	false	"Text of the review"
+// @Success		201			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		403			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		409			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/movies/{movie_id}/reviews [post]
+func New(log *slog.Logger, reviewSaver ReviewSaver) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.review.save.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Int("movie_id", req.MovieId), slog.Int("score", req.Score))
+
+		if ok, field, msg := ValidateRequest(req); !ok {
+			log.Error("invalid request", field)
+
+			response.ValidationFailed(w, r, field.Value.String(), msg)
+
+			return
+		}
+
+		reviewId, err := reviewSaver.SaveReview(r.Context(), req.MovieId, userId, req.Score, req.Body)
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Error("movie not found", slog.Int("movie_id", req.MovieId))
+
+			response.FromError(w, r, err, "movie not found")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrConflict) {
+			log.Warn("movie already reviewed", slog.Int("movie_id", req.MovieId), slog.Int("user_id", userId))
+
+			response.FromError(w, r, err, "movie already reviewed")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to save review", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to save review")
+
+			return
+		}
+
+		log.Info("review saved", slog.Int("review_id", reviewId))
+
+		response.Render(w, r, http.StatusCreated, Response{
+			response.OK(),
+			reviewId,
+		})
+	}
+}
+
+const (
+	MinScore      = 1
+	MaxScore      = 10
+	MaxBodyLength = 5000
+)
+
+func ValidateRequest(req Request) (bool, slog.Attr, string) {
+	if req.MovieId < 1 {
+		return false, slog.String("field", "movie_id"), "field movie_id is not valid"
+	}
+	if req.Score < MinScore || req.Score > MaxScore {
+		return false, slog.String("field", "score"), "field score is not valid"
+	}
+	if len(req.Body) > MaxBodyLength {
+		return false, slog.String("field", "body"), "field body is not valid"
+	}
+	return true, slog.Attr{}, ""
+}
@@ -0,0 +1,160 @@
+package save_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/review/save"
+	"film_library/internal/http-server/handlers/review/save/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestSaveHandler(t *testing.T) {
+	cases := []struct {
+		name      string
+		movieId   int
+		score     int
+		body      string
+		noToken   bool
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:    "Success",
+			movieId: 1,
+			score:   8,
+			body:    "Slow, but worth it",
+			status:  http.StatusCreated,
+		},
+		{
+			name:    "Score only",
+			movieId: 1,
+			score:   1,
+			status:  http.StatusCreated,
+		},
+		{
+			name:      "No token",
+			movieId:   1,
+			score:     8,
+			noToken:   true,
+			respError: "unauthorized",
+			status:    http.StatusUnauthorized,
+		},
+		{
+			name:      "Invalid movie_id",
+			movieId:   0,
+			score:     8,
+			respError: "field movie_id is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Score too low",
+			movieId:   1,
+			score:     0,
+			respError: "field score is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Score too high",
+			movieId:   1,
+			score:     11,
+			respError: "field score is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Body too long",
+			movieId:   1,
+			score:     8,
+			body:      strings.Repeat("a", 5001),
+			respError: "field body is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Movie not found",
+			movieId:   1,
+			score:     8,
+			respError: "movie not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.SaveReview: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "Already reviewed",
+			movieId:   1,
+			score:     8,
+			respError: "movie already reviewed",
+			status:    http.StatusConflict,
+			mockError: fmt.Errorf("storage.postgres.SaveReview: %w", storage.ErrConflict),
+		},
+		{
+			name:      "SaveReview Error",
+			movieId:   1,
+			score:     8,
+			respError: "failed to save review",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewSaverMock := mocks.NewReviewSaver(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				reviewSaverMock.On("SaveReview", mock.Anything, tc.movieId, 7, tc.score, tc.body).
+					Return(3, tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Post("/api/v1/movies/{movie_id}/reviews", save.New(slogdiscard.NewDiscardLogger(), reviewSaverMock))
+
+			input := fmt.Sprintf(`{"score": %d, "body": "%s"}`, tc.score, tc.body)
+
+			req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("/api/v1/movies/%d/reviews", tc.movieId), bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			if !tc.noToken {
+				accessToken, err := tokens.IssueAccess(7, nil, nil)
+				require.NoError(t, err)
+
+				parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+				require.NoError(t, err)
+
+				req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+			}
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp save.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+			if tc.respError == "" {
+				require.Equal(t, 3, resp.ReviewId)
+			}
+		})
+	}
+}
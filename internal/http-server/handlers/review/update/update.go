@@ -0,0 +1,145 @@
+package update
+
+import (
+	"context"
+	"errors"
+	reviewSave "film_library/internal/http-server/handlers/review/save"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"fmt"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	ReviewId int     `json:"review_id"`
+	Score    *int    `json:"score,omitempty"`
+	Body     *string `json:"body,omitempty" patch:"nullable"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=ReviewUpdater
+type ReviewUpdater interface {
+	UpdateReview(ctx context.Context, reviewId int, userId int, patch postgres.ReviewPatch) error
+}
+
+// @Summary		Update a review
+// @Description	Update an own review by review_id. Fields left out are kept, an empty or null body removes the text. PATCH also takes application/merge-patch+json bodies
+// @Tags			Review
+// @Accept			json,application/merge-patch+json
+// @Produce		json
+// @Param			review_id	path		int		true	"Review ID"
+// @Param			score		body		int		false	"Score, 1 to 10"
+// @Param			body		body		string	false	"Text of the review"
+// @Success		200			{object}	Response
+// @Failure		400			{object}	response.Response
+// @Failure		401			{object}	response.Response
+// @Failure		403			{object}	response.Response
+// @Failure		404			{object}	response.Response
+// @Failure		500			{object}	response.Response
+// @Router			/api/v1/reviews/{review_id} [patch]
+func New(log *slog.Logger, reviewUpdater ReviewUpdater) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.review.update.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		_, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		var req Request
+
+		err = request.Decode(r, &req)
+		var nullErr *request.NullFieldError
+		if errors.As(err, &nullErr) {
+			log.Error("field can not be removed", slog.String("field", nullErr.Field))
+
+			response.ValidationFailed(w, r, nullErr.Field, fmt.Sprintf("field %s is not valid", nullErr.Field))
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.Int("review_id", req.ReviewId))
+
+		if ok, field, msg := validateRequest(req); !ok {
+			log.Error("invalid request", field)
+
+			response.ValidationFailed(w, r, field.Value.String(), msg)
+
+			return
+		}
+
+		if req.Score == nil && req.Body == nil {
+			log.Error("no fields to update")
+
+			response.BadRequest(w, r, "no fields to update")
+
+			return
+		}
+
+		err = reviewUpdater.UpdateReview(r.Context(), req.ReviewId, userId, postgres.ReviewPatch{
+			Score: req.Score,
+			Body:  req.Body,
+		})
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Error("review not found", slog.Int("review_id", req.ReviewId), slog.Int("user_id", userId))
+
+			response.FromError(w, r, err, "review not found")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to update review", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to update review")
+
+			return
+		}
+
+		log.Info("review updated", slog.Int("review_id", req.ReviewId))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
+
+func validateRequest(req Request) (bool, slog.Attr, string) {
+	if req.ReviewId < 1 {
+		return false, slog.String("field", "review_id"), "field review_id is not valid"
+	}
+	if req.Score != nil && (*req.Score < reviewSave.MinScore || *req.Score > reviewSave.MaxScore) {
+		return false, slog.String("field", "score"), "field score is not valid"
+	}
+	if req.Body != nil && len(*req.Body) > reviewSave.MaxBodyLength {
+		return false, slog.String("field", "body"), "field body is not valid"
+	}
+	return true, slog.Attr{}, ""
+}
@@ -0,0 +1,134 @@
+package update_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/review/update"
+	"film_library/internal/http-server/handlers/review/update/mocks"
+	"film_library/internal/lib/api/request"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestUpdateHandler(t *testing.T) {
+	score := 9
+	noBody := ""
+
+	cases := []struct {
+		name      string
+		body      string
+		patch     postgres.ReviewPatch
+		respError string
+		status    int
+		mockError error
+	}{
+		{
+			name:   "Success update score",
+			body:   `{"score": 9}`,
+			patch:  postgres.ReviewPatch{Score: &score},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Remove text",
+			body:   `{"body": ""}`,
+			patch:  postgres.ReviewPatch{Body: &noBody},
+			status: http.StatusOK,
+		},
+		{
+			name:   "Null text",
+			body:   `{"body": null}`,
+			patch:  postgres.ReviewPatch{Body: &noBody},
+			status: http.StatusOK,
+		},
+		{
+			name:      "Invalid score",
+			body:      `{"score": 12}`,
+			respError: "field score is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Null member",
+			body:      `{"score": null}`,
+			respError: "field score is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "No fields",
+			body:      `{}`,
+			respError: "no fields to update",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Not found",
+			body:      `{"score": 9}`,
+			patch:     postgres.ReviewPatch{Score: &score},
+			respError: "review not found",
+			status:    http.StatusNotFound,
+			mockError: fmt.Errorf("storage.postgres.UpdateReview: %w", storage.ErrNotFound),
+		},
+		{
+			name:      "UpdateReview Error",
+			body:      `{"score": 9}`,
+			patch:     postgres.ReviewPatch{Score: &score},
+			respError: "failed to update review",
+			status:    http.StatusInternalServerError,
+			mockError: errors.New("unexpected error"),
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reviewUpdaterMock := mocks.NewReviewUpdater(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				reviewUpdaterMock.On("UpdateReview", mock.Anything, 5, 7, tc.patch).
+					Return(tc.mockError).
+					Once()
+			}
+
+			router := chi.NewRouter()
+			router.Patch("/api/v1/reviews/{review_id}", update.New(slogdiscard.NewDiscardLogger(), reviewUpdaterMock))
+
+			req, err := http.NewRequest(http.MethodPatch, "/api/v1/reviews/5", bytes.NewReader([]byte(tc.body)))
+			require.NoError(t, err)
+			req.Header.Set("Content-Type", request.MergePatchContentType)
+
+			accessToken, err := tokens.IssueAccess(7, nil, nil)
+			require.NoError(t, err)
+
+			parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+			require.NoError(t, err)
+
+			req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp update.Response
+
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
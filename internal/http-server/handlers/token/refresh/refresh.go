@@ -0,0 +1,129 @@
+package refresh
+
+import (
+	"errors"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Request struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type Response struct {
+	response.Response
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=RefreshTokenRotator
+type RefreshTokenRotator interface {
+	RotateRefreshToken(tokenHash string, newTokenHash string, expiresAt time.Time) (int, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=UserRolesGetter
+type UserRolesGetter interface {
+	GetUserRoles(userId int) ([]string, []string, error)
+}
+
+// @Summary		Refresh tokens
+// @Description	Exchange a refresh token for a new access token and a new refresh token. Each refresh token can be used once. The new access token carries the current roles of the user
+// @Tags			User
+// @Accept			json
+// @Produce		json
+// @Param			refresh_token	body		string	true	"Refresh token"
+// @Success		200				{object}	Response
+// @Failure		400				{object}	response.Response
+// @Failure		500				{object}	response.Response
+// @Router			/token/refresh [post]
+func New(log *slog.Logger, refreshTokenRotator RefreshTokenRotator, userRolesGetter UserRolesGetter, tokens *token.Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.token.refresh.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		if req.RefreshToken == "" {
+			log.Error("invalid request", slog.String("field", "refresh_token"))
+
+			response.ValidationFailed(w, r, "refresh_token", "field refresh_token is required")
+
+			return
+		}
+
+		refreshToken, refreshHash, refreshExpiresAt, err := tokens.NewRefresh()
+		if err != nil {
+			log.Error("failed to generate refresh token", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to generate token")
+
+			return
+		}
+
+		userId, err := refreshTokenRotator.RotateRefreshToken(token.HashRefresh(req.RefreshToken), refreshHash, refreshExpiresAt)
+		if errors.Is(err, storage.ErrTokenNotFound) || errors.Is(err, storage.ErrTokenExpired) ||
+			errors.Is(err, storage.ErrTokenReused) {
+			log.Warn("refresh token rejected", sl.Err(err))
+
+			response.FromError(w, r, err, "invalid refresh token")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to rotate refresh token", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to refresh token")
+
+			return
+		}
+
+		roles, permissions, err := userRolesGetter.GetUserRoles(userId)
+		if err != nil {
+			log.Error("failed to get user roles", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to refresh token")
+
+			return
+		}
+
+		accessToken, err := tokens.IssueAccess(userId, roles, permissions)
+		if err != nil {
+			log.Error("failed to generate token", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to generate token")
+
+			return
+		}
+
+		log.Info("token refreshed", slog.Int("user_id", userId))
+
+		render.JSON(w, r, Response{
+			Response:     response.OK(),
+			Token:        accessToken,
+			RefreshToken: refreshToken,
+			ExpiresIn:    int(tokens.AccessTTL().Seconds()),
+		})
+	}
+}
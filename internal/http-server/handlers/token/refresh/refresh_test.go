@@ -0,0 +1,132 @@
+package refresh_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/token/refresh"
+	"film_library/internal/http-server/handlers/token/refresh/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestRefreshHandler(t *testing.T) {
+	cases := []struct {
+		name         string
+		refreshToken string
+		respError    string
+		status       int
+		mockError    error
+		rolesError   error
+	}{
+		{
+			name:         "Success",
+			refreshToken: "refresh",
+			status:       http.StatusOK,
+		},
+		{
+			name:         "Empty refresh_token",
+			refreshToken: "",
+			respError:    "field refresh_token is required",
+			status:       http.StatusBadRequest,
+		},
+		{
+			name:         "Unknown token",
+			refreshToken: "refresh",
+			respError:    "invalid refresh token",
+			status:       http.StatusUnauthorized,
+			mockError:    storage.ErrTokenNotFound,
+		},
+		{
+			name:         "Expired token",
+			refreshToken: "refresh",
+			respError:    "invalid refresh token",
+			status:       http.StatusUnauthorized,
+			mockError:    storage.ErrTokenExpired,
+		},
+		{
+			name:         "Reused token",
+			refreshToken: "refresh",
+			respError:    "invalid refresh token",
+			status:       http.StatusUnauthorized,
+			mockError:    fmt.Errorf("storage.postgres.RotateRefreshToken: %w", storage.ErrTokenReused),
+		},
+		{
+			name:         "GetUserRoles Error",
+			refreshToken: "refresh",
+			respError:    "failed to refresh token",
+			status:       http.StatusInternalServerError,
+			rolesError:   errors.New("unexpected error"),
+		},
+		{
+			name:         "RotateRefreshToken Error",
+			refreshToken: "refresh",
+			respError:    "failed to refresh token",
+			status:       http.StatusInternalServerError,
+			mockError:    errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			refreshTokenRotatorMock := mocks.NewRefreshTokenRotator(t)
+
+			if tc.respError == "" || tc.mockError != nil || tc.rolesError != nil {
+				refreshTokenRotatorMock.On("RotateRefreshToken", token.HashRefresh(tc.refreshToken),
+					mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					Return(1, tc.mockError).
+					Once()
+			}
+
+			userRolesGetterMock := mocks.NewUserRolesGetter(t)
+
+			if tc.respError == "" || tc.rolesError != nil {
+				userRolesGetterMock.On("GetUserRoles", 1).
+					Return([]string{"user"}, []string{}, tc.rolesError).
+					Once()
+			}
+
+			tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+			handler := refresh.New(slogdiscard.NewDiscardLogger(), refreshTokenRotatorMock, userRolesGetterMock, tokens)
+
+			input := fmt.Sprintf(`{"refresh_token": "%s"}`, tc.refreshToken)
+
+			req, err := http.NewRequest(http.MethodPost, "/token/refresh", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			body := rr.Body.String()
+
+			var resp refresh.Response
+
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.NotEmpty(t, resp.Token)
+				require.NotEmpty(t, resp.RefreshToken)
+				require.NotEqual(t, tc.refreshToken, resp.RefreshToken)
+			}
+		})
+	}
+}
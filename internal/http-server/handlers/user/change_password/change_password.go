@@ -0,0 +1,122 @@
+package change_password
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/password"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/render"
+	"log/slog"
+	"net/http"
+)
+
+type Request struct {
+	Username    string `json:"username"`
+	Password    string `json:"password"`
+	NewPassword string `json:"new_password"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=PasswordChanger
+type PasswordChanger interface {
+	ChangePassword(ctx context.Context, username string, oldPassword string, newPassword string) error
+}
+
+// @Summary		Change password
+// @Description	Change the password by username and current password. Also clears a password reset forced by an admin
+// @Tags			User
+// @Accept			json
+// @Produce		json
+// @Param			username		body		string	true	"Username"
+// @Param			password		body		string	true	"Current password"
+// @Param			new_password	body		string	true	"New password"
+// @Success		200				{object}	Response
+// @Failure		400				{object}	response.Response
+// @Failure		500				{object}	response.Response
+// @Router			/password/change [post]
+func New(log *slog.Logger, passwordChanger PasswordChanger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.user.change_password.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		log.Info("request body decoded", slog.String("username", req.Username))
+
+		if ok, field, msg := validateRequest(req); !ok {
+			log.Error("invalid request", field)
+
+			response.ValidationFailed(w, r, field.Value.String(), msg)
+
+			return
+		}
+
+		err = passwordChanger.ChangePassword(r.Context(), req.Username, req.Password, req.NewPassword)
+		if errors.Is(err, storage.ErrInvalidCredentials) {
+			log.Warn("invalid credentials", slog.String("username", req.Username))
+
+			response.FromError(w, r, err, "invalid username or password")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrUserDisabled) {
+			log.Warn("user is disabled", slog.String("username", req.Username))
+
+			response.FromError(w, r, err, "user is disabled")
+
+			return
+		}
+
+		if err != nil {
+			log.Error("failed to change password", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to change password")
+
+			return
+		}
+
+		log.Info("password changed", slog.String("username", req.Username))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
+
+func validateRequest(req Request) (bool, slog.Attr, string) {
+	if req.Username == "" {
+		return false, slog.String("field", "username"), "field username is required"
+	}
+
+	if req.Password == "" {
+		return false, slog.String("field", "password"), "field password is required"
+	}
+
+	if req.NewPassword == "" {
+		return false, slog.String("field", "new_password"), "field new_password is required"
+	}
+
+	if len(req.NewPassword) > password.MaxLength {
+		return false, slog.String("field", "new_password"), "field new_password is not valid"
+	}
+
+	return true, slog.Attr{}, ""
+}
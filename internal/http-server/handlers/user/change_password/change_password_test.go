@@ -0,0 +1,133 @@
+package change_password_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/user/change_password"
+	"film_library/internal/http-server/handlers/user/change_password/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
+)
+
+func TestChangePasswordHandler(t *testing.T) {
+	cases := []struct {
+		name        string
+		username    string
+		password    string
+		newPassword string
+		respError   string
+		status      int
+		mockError   error
+	}{
+		{
+			name:        "Success",
+			username:    "admin",
+			password:    "admin",
+			newPassword: "secret",
+			status:      http.StatusOK,
+		},
+		{
+			name:        "Empty username",
+			password:    "admin",
+			newPassword: "secret",
+			respError:   "field username is required",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "Empty password",
+			username:    "admin",
+			newPassword: "secret",
+			respError:   "field password is required",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:      "Empty new password",
+			username:  "admin",
+			password:  "admin",
+			respError: "field new_password is required",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:        "Too long new password",
+			username:    "admin",
+			password:    "admin",
+			newPassword: strings.Repeat("a", 73),
+			respError:   "field new_password is not valid",
+			status:      http.StatusBadRequest,
+		},
+		{
+			name:        "Invalid credentials",
+			username:    "admin",
+			password:    "wrong",
+			newPassword: "secret",
+			respError:   "invalid username or password",
+			status:      http.StatusUnauthorized,
+			mockError:   fmt.Errorf("storage.postgres.ChangePassword: %w", storage.ErrInvalidCredentials),
+		},
+		{
+			name:        "Disabled user",
+			username:    "admin",
+			password:    "admin",
+			newPassword: "secret",
+			respError:   "user is disabled",
+			status:      http.StatusForbidden,
+			mockError:   fmt.Errorf("storage.postgres.ChangePassword: %w", storage.ErrUserDisabled),
+		},
+		{
+			name:        "ChangePassword Error",
+			username:    "admin",
+			password:    "admin",
+			newPassword: "secret",
+			respError:   "failed to change password",
+			status:      http.StatusInternalServerError,
+			mockError:   errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			passwordChangerMock := mocks.NewPasswordChanger(t)
+
+			if tc.respError == "" || tc.mockError != nil {
+				passwordChangerMock.On("ChangePassword", mock.Anything, tc.username, tc.password, tc.newPassword).
+					Return(tc.mockError).
+					Once()
+			}
+
+			handler := change_password.New(slogdiscard.NewDiscardLogger(), passwordChangerMock)
+
+			input := fmt.Sprintf(`{"username": "%s", "password": "%s", "new_password": "%s"}`,
+				tc.username, tc.password, tc.newPassword)
+
+			req, err := http.NewRequest(http.MethodPost, "/password/change", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			body := rr.Body.String()
+
+			var resp change_password.Response
+
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
@@ -37,6 +37,45 @@ func (_m *UserAuthenticator) GetUser(username string, password string) (int, err
 	return r0, r1
 }
 
+// GetUserRoles provides a mock function with given fields: userId
+func (_m *UserAuthenticator) GetUserRoles(userId int) ([]string, []string, error) {
+	ret := _m.Called(userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserRoles")
+	}
+
+	var r0 []string
+	var r1 []string
+	var r2 error
+	if rf, ok := ret.Get(0).(func(int) ([]string, []string, error)); ok {
+		return rf(userId)
+	}
+	if rf, ok := ret.Get(0).(func(int) []string); ok {
+		r0 = rf(userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int) []string); ok {
+		r1 = rf(userId)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(int) error); ok {
+		r2 = rf(userId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // NewUserAuthenticator creates a new instance of UserAuthenticator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewUserAuthenticator(t interface {
@@ -1,13 +1,16 @@
 package signin
 
 import (
+	"errors"
 	"film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
 	"github.com/go-chi/chi/v5/middleware"
-	"github.com/go-chi/jwtauth/v5"
 	"github.com/go-chi/render"
 	"log/slog"
 	"net/http"
+	"time"
 )
 
 type Request struct {
@@ -17,16 +20,24 @@ type Request struct {
 
 type Response struct {
 	response.Response
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
 }
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=UserAuthenticator
 type UserAuthenticator interface {
 	GetUser(username string, password string) (int, error)
+	GetUserRoles(userId int) ([]string, []string, error)
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=RefreshTokenSaver
+type RefreshTokenSaver interface {
+	SaveRefreshToken(userId int, tokenHash string, expiresAt time.Time) error
 }
 
 // @Summary		Sign in a user
-// @Description	Sign in a user by username and password
+// @Description	Sign in a user by username and password. Returns a short-lived access token and a refresh token for /token/refresh
 // @Tags			User
 // @Accept			json
 // @Produce		json
@@ -34,8 +45,9 @@ type UserAuthenticator interface {
 // @Param			password	body		string	true	"Password"
 // @Success		200			{object}	Response
 // @Failure		400			{object}	response.Response
+// @Failure		500			{object}	response.Response
 // @Router			/signin [post]
-func New(log *slog.Logger, userAuthenticator UserAuthenticator, jwtKey string) http.HandlerFunc {
+func New(log *slog.Logger, userAuthenticator UserAuthenticator, refreshTokenSaver RefreshTokenSaver, tokens *token.Manager) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		const op = "handlers.user.signin.New"
 
@@ -50,65 +62,109 @@ func New(log *slog.Logger, userAuthenticator UserAuthenticator, jwtKey string) h
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to decode request"))
+			response.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
 
-		log.Info("request body decoded", slog.Any("request", req))
+		log.Info("request body decoded", slog.String("username", req.Username))
 
 		if ok, field, msg := validateRequest(req); !ok {
 			log.Error("invalid request", field)
 
-			render.JSON(w, r, response.Error(msg))
+			response.ValidationFailed(w, r, field.Value.String(), msg)
 
 			return
 		}
 
 		userId, err := userAuthenticator.GetUser(req.Username, req.Password)
+		if errors.Is(err, storage.ErrInvalidCredentials) {
+			log.Info("invalid credentials", slog.String("username", req.Username))
+
+			response.FromError(w, r, err, "invalid username or password")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrUserDisabled) {
+			log.Info("user is disabled", slog.String("username", req.Username))
+
+			response.FromError(w, r, err, "user is disabled")
+
+			return
+		}
+
+		if errors.Is(err, storage.ErrPasswordResetRequired) {
+			log.Info("password reset required", slog.String("username", req.Username))
+
+			response.FromError(w, r, err, "password reset required")
+
+			return
+		}
+
 		if err != nil {
 			log.Error("failed to authenticate user", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to authenticate user"))
+			response.FromError(w, r, err, "failed to authenticate user")
 
 			return
 		}
 
-		token, err := generateToken(userId, jwtKey)
+		roles, permissions, err := userAuthenticator.GetUserRoles(userId)
+		if err != nil {
+			log.Error("failed to get user roles", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to authenticate user")
+
+			return
+		}
+
+		accessToken, err := tokens.IssueAccess(userId, roles, permissions)
 		if err != nil {
 			log.Error("failed to generate token", sl.Err(err))
 
-			render.JSON(w, r, response.Error("failed to generate token"))
+			response.FromError(w, r, err, "failed to generate token")
+
+			return
+		}
+
+		refreshToken, refreshHash, refreshExpiresAt, err := tokens.NewRefresh()
+		if err != nil {
+			log.Error("failed to generate refresh token", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to generate token")
+
+			return
+		}
+
+		err = refreshTokenSaver.SaveRefreshToken(userId, refreshHash, refreshExpiresAt)
+		if err != nil {
+			log.Error("failed to save refresh token", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to generate token")
 
 			return
 		}
 
+		log.Info("user signed in", slog.Int("user_id", userId))
+
 		render.JSON(w, r, Response{
-			Response: response.OK(),
-			Token:    token,
+			Response:     response.OK(),
+			Token:        accessToken,
+			RefreshToken: refreshToken,
+			ExpiresIn:    int(tokens.AccessTTL().Seconds()),
 		})
 	}
 }
 
 func validateRequest(req Request) (bool, slog.Attr, string) {
 	if req.Username == "" {
-		return false, slog.String("username", req.Username), "field username is required"
+		return false, slog.String("field", "username"), "field username is required"
 	}
 
 	if req.Password == "" {
-		return false, slog.String("password", req.Password), "field password is required"
+		return false, slog.String("field", "password"), "field password is required"
 	}
 
 	return true, slog.Attr{}, ""
 }
-
-func generateToken(userId int, jwtKey string) (string, error) {
-	tokenAuth := jwtauth.New("HS256", []byte(jwtKey), nil)
-
-	_, tokenString, err := tokenAuth.Encode(map[string]interface{}{"user_id": userId})
-	if err != nil {
-		return "", err
-	}
-
-	return tokenString, nil
-}
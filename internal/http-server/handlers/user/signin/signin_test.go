@@ -8,46 +8,97 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/user/signin"
 	"film_library/internal/http-server/handlers/user/signin/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
 )
 
 func TestSaveHandler(t *testing.T) {
 	cases := []struct {
-		name      string
-		username  string
-		password  string
-		respError string
-		mockError error
+		name       string
+		username   string
+		password   string
+		respError  string
+		status     int
+		mockError  error
+		saveError  error
+		rolesError error
 	}{
 		{
 			name:     "Success",
 			username: "admin",
 			password: "admin",
+			status:   http.StatusOK,
 		},
 		{
 			name:      "Empty username",
 			username:  "",
 			password:  "admin",
 			respError: "field username is required",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "Empty password",
 			username:  "admin",
 			password:  "",
 			respError: "field password is required",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Invalid credentials",
+			username:  "admin",
+			password:  "wrong",
+			respError: "invalid username or password",
+			status:    http.StatusUnauthorized,
+			mockError: fmt.Errorf("storage.postgres.GetUser: %w", storage.ErrInvalidCredentials),
+		},
+		{
+			name:      "Disabled user",
+			username:  "admin",
+			password:  "admin",
+			respError: "user is disabled",
+			status:    http.StatusForbidden,
+			mockError: fmt.Errorf("storage.postgres.GetUser: %w", storage.ErrUserDisabled),
+		},
+		{
+			name:      "Password reset required",
+			username:  "admin",
+			password:  "admin",
+			respError: "password reset required",
+			status:    http.StatusForbidden,
+			mockError: fmt.Errorf("storage.postgres.GetUser: %w", storage.ErrPasswordResetRequired),
 		},
 		{
 			name:      "SaveUser Error",
 			username:  "admin",
 			password:  "admin",
 			respError: "failed to authenticate user",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
+		{
+			name:       "GetUserRoles Error",
+			username:   "admin",
+			password:   "admin",
+			respError:  "failed to authenticate user",
+			status:     http.StatusInternalServerError,
+			rolesError: errors.New("unexpected error"),
+		},
+		{
+			name:      "SaveRefreshToken Error",
+			username:  "admin",
+			password:  "admin",
+			respError: "failed to generate token",
+			status:    http.StatusInternalServerError,
+			saveError: errors.New("unexpected error"),
+		},
 	}
 
 	for _, tc := range cases {
@@ -58,13 +109,29 @@ func TestSaveHandler(t *testing.T) {
 
 			userAuthenticatorMock := mocks.NewUserAuthenticator(t)
 
-			if tc.respError == "" || tc.mockError != nil {
+			if tc.respError == "" || tc.mockError != nil || tc.saveError != nil || tc.rolesError != nil {
 				userAuthenticatorMock.On("GetUser", tc.username, tc.password).
 					Return(1, tc.mockError).
 					Once()
 			}
 
-			handler := signin.New(slogdiscard.NewDiscardLogger(), userAuthenticatorMock, "jwtKey")
+			if tc.respError == "" || tc.saveError != nil || tc.rolesError != nil {
+				userAuthenticatorMock.On("GetUserRoles", 1).
+					Return([]string{"admin"}, []string{"movie:write"}, tc.rolesError).
+					Once()
+			}
+
+			refreshTokenSaverMock := mocks.NewRefreshTokenSaver(t)
+
+			if tc.respError == "" || tc.saveError != nil {
+				refreshTokenSaverMock.On("SaveRefreshToken", 1, mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					Return(tc.saveError).
+					Once()
+			}
+
+			tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+			handler := signin.New(slogdiscard.NewDiscardLogger(), userAuthenticatorMock, refreshTokenSaverMock, tokens)
 
 			input := fmt.Sprintf(`{"username": "%s", "password": "%s"}`,
 				tc.username, tc.password)
@@ -75,7 +142,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -84,6 +151,12 @@ func TestSaveHandler(t *testing.T) {
 			require.NoError(t, json.Unmarshal([]byte(body), &resp))
 
 			require.Equal(t, tc.respError, resp.Error)
+
+			if tc.respError == "" {
+				require.NotEmpty(t, resp.Token)
+				require.NotEmpty(t, resp.RefreshToken)
+				require.Equal(t, 60, resp.ExpiresIn)
+			}
 		})
 	}
 }
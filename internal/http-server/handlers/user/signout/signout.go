@@ -0,0 +1,105 @@
+package signout
+
+import (
+	"errors"
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/go-chi/render"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+)
+
+type Request struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type Response struct {
+	response.Response
+}
+
+//go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=TokenRevoker
+type TokenRevoker interface {
+	RevokeAccessToken(jti string, expiresAt time.Time) error
+	RevokeRefreshTokenFamily(userId int, tokenHash string) error
+}
+
+// @Summary		Sign out
+// @Description	Revoke the access token of the request and, if refresh_token is given, every refresh token issued for the same signin
+// @Tags			User
+// @Accept			json
+// @Produce		json
+// @Param			refresh_token	body		string	false	"Refresh token"
+// @Success		200				{object}	Response
+// @Failure		400				{object}	response.Response
+// @Failure		401				{object}	response.Response
+// @Failure		500				{object}	response.Response
+// @Router			/signout [post]
+func New(log *slog.Logger, tokenRevoker TokenRevoker) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		const op = "handlers.user.signout.New"
+
+		log = log.With(
+			slog.String("op", op),
+			slog.String("request_id", middleware.GetReqID(r.Context())),
+		)
+
+		var req Request
+
+		err := render.DecodeJSON(r.Body, &req)
+		if err != nil && !errors.Is(err, io.EOF) {
+			log.Error("failed to decode request", sl.Err(err))
+
+			response.BadRequest(w, r, "failed to decode request")
+
+			return
+		}
+
+		accessToken, claims, err := jwtauth.FromContext(r.Context())
+		userId, ok := token.UserId(claims)
+		if err != nil || accessToken == nil || !ok {
+			log.Error("no valid token in context", slog.Any("error", err))
+
+			response.Unauthorized(w, r, "unauthorized")
+
+			return
+		}
+
+		if req.RefreshToken != "" {
+			err = tokenRevoker.RevokeRefreshTokenFamily(userId, token.HashRefresh(req.RefreshToken))
+			if errors.Is(err, storage.ErrTokenNotFound) {
+				log.Warn("refresh token not found", slog.Int("user_id", userId))
+
+				response.FromError(w, r, err, "invalid refresh token")
+
+				return
+			}
+
+			if err != nil {
+				log.Error("failed to revoke refresh token", sl.Err(err))
+
+				response.FromError(w, r, err, "failed to sign out")
+
+				return
+			}
+		}
+
+		err = tokenRevoker.RevokeAccessToken(accessToken.JwtID(), accessToken.Expiration())
+		if err != nil {
+			log.Error("failed to revoke access token", sl.Err(err))
+
+			response.FromError(w, r, err, "failed to sign out")
+
+			return
+		}
+
+		log.Info("user signed out", slog.Int("user_id", userId))
+
+		render.JSON(w, r, Response{response.OK()})
+	}
+}
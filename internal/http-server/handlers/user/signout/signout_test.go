@@ -0,0 +1,132 @@
+package signout_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/handlers/user/signout"
+	"film_library/internal/http-server/handlers/user/signout/mocks"
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/lib/token"
+	"film_library/internal/storage"
+)
+
+func TestSignoutHandler(t *testing.T) {
+	cases := []struct {
+		name         string
+		refreshToken string
+		noToken      bool
+		respError    string
+		status       int
+		familyError  error
+		revokeError  error
+		expectRevoke bool
+		expectFamily bool
+	}{
+		{
+			name:         "Success",
+			refreshToken: "refresh",
+			expectFamily: true,
+			expectRevoke: true,
+			status:       http.StatusOK,
+		},
+		{
+			name:         "Success without refresh token",
+			expectRevoke: true,
+			status:       http.StatusOK,
+		},
+		{
+			name:      "No token",
+			noToken:   true,
+			respError: "unauthorized",
+			status:    http.StatusUnauthorized,
+		},
+		{
+			name:         "Foreign refresh token",
+			refreshToken: "refresh",
+			respError:    "invalid refresh token",
+			status:       http.StatusUnauthorized,
+			familyError:  storage.ErrTokenNotFound,
+			expectFamily: true,
+		},
+		{
+			name:         "RevokeRefreshTokenFamily Error",
+			refreshToken: "refresh",
+			respError:    "failed to sign out",
+			status:       http.StatusInternalServerError,
+			familyError:  errors.New("unexpected error"),
+			expectFamily: true,
+		},
+		{
+			name:         "RevokeAccessToken Error",
+			respError:    "failed to sign out",
+			status:       http.StatusInternalServerError,
+			revokeError:  errors.New("unexpected error"),
+			expectRevoke: true,
+		},
+	}
+
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			tokenRevokerMock := mocks.NewTokenRevoker(t)
+
+			if tc.expectFamily {
+				tokenRevokerMock.On("RevokeRefreshTokenFamily", 7, token.HashRefresh(tc.refreshToken)).
+					Return(tc.familyError).
+					Once()
+			}
+
+			if tc.expectRevoke {
+				tokenRevokerMock.On("RevokeAccessToken", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+					Return(tc.revokeError).
+					Once()
+			}
+
+			handler := signout.New(slogdiscard.NewDiscardLogger(), tokenRevokerMock)
+
+			input := fmt.Sprintf(`{"refresh_token": "%s"}`, tc.refreshToken)
+
+			req, err := http.NewRequest(http.MethodPost, "/signout", bytes.NewReader([]byte(input)))
+			require.NoError(t, err)
+
+			if !tc.noToken {
+				accessToken, err := tokens.IssueAccess(7, nil, nil)
+				require.NoError(t, err)
+
+				parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), accessToken)
+				require.NoError(t, err)
+
+				req = req.WithContext(jwtauth.NewContext(req.Context(), parsed, nil))
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.status, rr.Code)
+
+			body := rr.Body.String()
+
+			var resp signout.Response
+
+			require.NoError(t, json.Unmarshal([]byte(body), &resp))
+
+			require.Equal(t, tc.respError, resp.Error)
+		})
+	}
+}
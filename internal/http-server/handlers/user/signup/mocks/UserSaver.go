@@ -2,24 +2,28 @@
 
 package mocks
 
-import mock "github.com/stretchr/testify/mock"
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
 
 // UserSaver is an autogenerated mock type for the UserSaver type
 type UserSaver struct {
 	mock.Mock
 }
 
-// SaveUser provides a mock function with given fields: username, password
-func (_m *UserSaver) SaveUser(username string, password string) error {
-	ret := _m.Called(username, password)
+// SaveUser provides a mock function with given fields: ctx, username, password
+func (_m *UserSaver) SaveUser(ctx context.Context, username string, password string) error {
+	ret := _m.Called(ctx, username, password)
 
 	if len(ret) == 0 {
 		panic("no return value specified for SaveUser")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(string, string) error); ok {
-		r0 = rf(username, password)
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, username, password)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -1,9 +1,11 @@
 package signup
 
 import (
+	"context"
 	"errors"
 	resp "film_library/internal/lib/api/response"
 	"film_library/internal/lib/logger/sl"
+	"film_library/internal/lib/password"
 	"film_library/internal/storage"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/render"
@@ -22,7 +24,7 @@ type Response struct {
 
 //go:generate go run github.com/vektra/mockery/v2@v2.42.1 --name=UserSaver
 type UserSaver interface {
-	SaveUser(username string, password string) error
+	SaveUser(ctx context.Context, username string, password string) error
 }
 
 // @Summary		Create a new user
@@ -32,8 +34,10 @@ type UserSaver interface {
 // @Produce		json
 // @Param			username	body		string	true	"Username"
 // @Param			password	body		string	true	"Password"
-// @Success		200			{object}	response.Response
-// @Failure		400			{object}	response.Response
+// @Success		201			{object}	resp.Response
+// @Failure		400			{object}	resp.Response
+// @Failure		409			{object}	resp.Response
+// @Failure		500			{object}	resp.Response
 // @Router			/signup [post]
 func New(log *slog.Logger, userSaver UserSaver) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -50,26 +54,26 @@ func New(log *slog.Logger, userSaver UserSaver) http.HandlerFunc {
 		if err != nil {
 			log.Error("failed to decode request", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to decode request"))
+			resp.BadRequest(w, r, "failed to decode request")
 
 			return
 		}
 
-		log.Info("request body decoded", slog.Any("request", req))
+		log.Info("request body decoded", slog.String("username", req.Username))
 
 		if ok, field, msg := validateRequest(req); !ok {
 			log.Error("invalid request", field)
 
-			render.JSON(w, r, resp.Error(msg))
+			resp.ValidationFailed(w, r, field.Value.String(), msg)
 
 			return
 		}
 
-		err = userSaver.SaveUser(req.Username, req.Password)
+		err = userSaver.SaveUser(r.Context(), req.Username, req.Password)
 		if errors.Is(err, storage.ErrUserExists) {
 			log.Error("user already exists", slog.String("username", req.Username))
 
-			render.JSON(w, r, resp.Error("user already exists"))
+			resp.FromError(w, r, err, "user already exists")
 
 			return
 		}
@@ -77,12 +81,12 @@ func New(log *slog.Logger, userSaver UserSaver) http.HandlerFunc {
 		if err != nil {
 			log.Error("failed to save user", sl.Err(err))
 
-			render.JSON(w, r, resp.Error("failed to save user"))
+			resp.FromError(w, r, err, "failed to save user")
 
 			return
 		}
 
-		render.JSON(w, r, Response{
+		resp.Render(w, r, http.StatusCreated, Response{
 			Response: resp.OK(),
 		})
 	}
@@ -90,11 +94,15 @@ func New(log *slog.Logger, userSaver UserSaver) http.HandlerFunc {
 
 func validateRequest(req Request) (bool, slog.Attr, string) {
 	if req.Username == "" {
-		return false, slog.String("username", req.Username), "field username is required"
+		return false, slog.String("field", "username"), "field username is required"
 	}
 
 	if req.Password == "" {
-		return false, slog.String("password", req.Password), "field password is required"
+		return false, slog.String("field", "password"), "field password is required"
+	}
+
+	if len(req.Password) > password.MaxLength {
+		return false, slog.String("field", "password"), "field password is not valid"
 	}
 
 	return true, slog.Attr{}, ""
@@ -7,13 +7,16 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
 	"film_library/internal/http-server/handlers/user/signup"
 	"film_library/internal/http-server/handlers/user/signup/mocks"
 	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage"
 )
 
 func TestSaveHandler(t *testing.T) {
@@ -22,30 +25,50 @@ func TestSaveHandler(t *testing.T) {
 		username  string
 		password  string
 		respError string
+		status    int
 		mockError error
 	}{
 		{
 			name:     "Success",
 			username: "admin",
 			password: "admin",
+			status:   http.StatusCreated,
 		},
 		{
 			name:      "Empty username",
 			username:  "",
 			password:  "admin",
 			respError: "field username is required",
+			status:    http.StatusBadRequest,
 		},
 		{
 			name:      "Empty password",
 			username:  "admin",
 			password:  "",
 			respError: "field password is required",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "Too long password",
+			username:  "admin",
+			password:  strings.Repeat("a", 73),
+			respError: "field password is not valid",
+			status:    http.StatusBadRequest,
+		},
+		{
+			name:      "User exists",
+			username:  "admin",
+			password:  "admin",
+			respError: "user already exists",
+			status:    http.StatusConflict,
+			mockError: storage.ErrUserExists,
 		},
 		{
 			name:      "SaveUser Error",
 			username:  "admin",
 			password:  "admin",
 			respError: "failed to save user",
+			status:    http.StatusInternalServerError,
 			mockError: errors.New("unexpected error"),
 		},
 	}
@@ -59,7 +82,7 @@ func TestSaveHandler(t *testing.T) {
 			userSaverMock := mocks.NewUserSaver(t)
 
 			if tc.respError == "" || tc.mockError != nil {
-				userSaverMock.On("SaveUser", tc.username, tc.password).
+				userSaverMock.On("SaveUser", mock.Anything, tc.username, tc.password).
 					Return(tc.mockError).
 					Once()
 			}
@@ -75,7 +98,7 @@ func TestSaveHandler(t *testing.T) {
 			rr := httptest.NewRecorder()
 			handler.ServeHTTP(rr, req)
 
-			require.Equal(t, rr.Code, http.StatusOK)
+			require.Equal(t, tc.status, rr.Code)
 
 			body := rr.Body.String()
 
@@ -1,44 +0,0 @@
-package admin_authenticator
-
-import (
-	"github.com/go-chi/jwtauth/v5"
-	"github.com/lestrrat-go/jwx/v2/jwt"
-	"net/http"
-)
-
-type AdminAuthenticator interface {
-	IsAdmin(userId int) (bool, error)
-}
-
-func New(ja *jwtauth.JWTAuth, adminAuthenticator AdminAuthenticator) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		hfn := func(w http.ResponseWriter, r *http.Request) {
-			token, claims, err := jwtauth.FromContext(r.Context())
-
-			if err != nil {
-				http.Error(w, err.Error(), http.StatusUnauthorized)
-				return
-			}
-
-			if token == nil || jwt.Validate(token, ja.ValidateOptions()...) != nil {
-				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
-				return
-			}
-
-			isAdmin, err := adminAuthenticator.IsAdmin(int(claims["user_id"].(float64)))
-			if err != nil {
-				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
-				return
-			}
-
-			if !isAdmin {
-				http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
-				return
-			}
-
-			// Token is authenticated, pass it through
-			next.ServeHTTP(w, r)
-		}
-		return http.HandlerFunc(hfn)
-	}
-}
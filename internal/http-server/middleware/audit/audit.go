@@ -0,0 +1,28 @@
+package audit
+
+import (
+	"film_library/internal/lib/audit"
+	"film_library/internal/lib/token"
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"net/http"
+)
+
+// New puts the audit.Actor of the request into its context so that the
+// changes it makes are attributed to it. The user is taken from a verified
+// access token, so it must run after jwtauth.Verifier; without one only the
+// request id is recorded.
+func New() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		hfn := func(w http.ResponseWriter, r *http.Request) {
+			actor := audit.Actor{RequestId: middleware.GetReqID(r.Context())}
+
+			if _, claims, err := jwtauth.FromContext(r.Context()); err == nil {
+				actor.UserId, _ = token.UserId(claims)
+			}
+
+			next.ServeHTTP(w, r.WithContext(audit.NewContext(r.Context(), actor)))
+		}
+		return http.HandlerFunc(hfn)
+	}
+}
@@ -0,0 +1,72 @@
+package audit_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/require"
+
+	mwAudit "film_library/internal/http-server/middleware/audit"
+	"film_library/internal/lib/audit"
+	"film_library/internal/lib/token"
+)
+
+func TestNew(t *testing.T) {
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+	expired := token.New("jwtKey", "film_library", -time.Minute, time.Hour)
+
+	cases := []struct {
+		name   string
+		issuer *token.Manager
+		userId int
+	}{
+		{
+			name:   "Signed in",
+			issuer: tokens,
+			userId: 5,
+		},
+		{
+			name:   "Expired token",
+			issuer: expired,
+		},
+		{
+			name: "No token",
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var actor audit.Actor
+			var ok bool
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				actor, ok = audit.FromContext(r.Context())
+			})
+
+			handler := middleware.RequestID(jwtauth.Verifier(tokens.JWTAuth())(mwAudit.New()(next)))
+
+			req, err := http.NewRequest(http.MethodPost, "/movie/save", nil)
+			require.NoError(t, err)
+
+			if tc.issuer != nil {
+				accessToken, err := tc.issuer.IssueAccess(5, nil, nil)
+				require.NoError(t, err)
+
+				req.Header.Set("Authorization", "Bearer "+accessToken)
+			}
+
+			handler.ServeHTTP(httptest.NewRecorder(), req)
+
+			require.True(t, ok)
+			require.Equal(t, tc.userId, actor.UserId)
+			require.NotEmpty(t, actor.RequestId)
+		})
+	}
+}
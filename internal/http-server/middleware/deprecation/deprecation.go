@@ -0,0 +1,19 @@
+package deprecation
+
+import (
+	"net/http"
+)
+
+// New marks responses of the verb-style routes, which are kept only for
+// old clients, with the Deprecation header. New clients should use the
+// /api/v1 resource routes.
+func New() func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		hfn := func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Deprecation", "true")
+
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(hfn)
+	}
+}
@@ -0,0 +1,59 @@
+package permission
+
+import (
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/token"
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+	"net/http"
+)
+
+const (
+	MovieWrite      = "movie:write"
+	MovieDelete     = "movie:delete"
+	ActorWrite      = "actor:write"
+	ActorDelete     = "actor:delete"
+	UserManage      = "user:manage"
+	AuditRead       = "audit:read"
+	TrashManage     = "trash:manage"
+	CatalogueImport = "catalogue:import"
+	CatalogueExport = "catalogue:export"
+	ReviewWrite     = "review:write"
+	ReviewModerate  = "review:moderate"
+)
+
+// RequirePermission lets the request through only if its verified access
+// token grants every one of the given permissions. It must run after
+// jwtauth.Verifier.
+func RequirePermission(ja *jwtauth.JWTAuth, permissions ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		hfn := func(w http.ResponseWriter, r *http.Request) {
+			t, claims, err := jwtauth.FromContext(r.Context())
+
+			if err != nil {
+				response.Unauthorized(w, r, "unauthorized")
+				return
+			}
+
+			if t == nil || jwt.Validate(t, ja.ValidateOptions()...) != nil {
+				response.Unauthorized(w, r, "unauthorized")
+				return
+			}
+
+			granted := make(map[string]struct{})
+			for _, p := range token.Permissions(claims) {
+				granted[p] = struct{}{}
+			}
+
+			for _, p := range permissions {
+				if _, ok := granted[p]; !ok {
+					response.Forbidden(w, r, "permission denied")
+					return
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(hfn)
+	}
+}
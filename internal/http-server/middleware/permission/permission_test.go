@@ -0,0 +1,93 @@
+package permission_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/http-server/middleware/permission"
+	"film_library/internal/lib/token"
+)
+
+func TestRequirePermission(t *testing.T) {
+	tokens := token.New("jwtKey", "film_library", time.Minute, time.Hour)
+	expired := token.New("jwtKey", "film_library", -time.Minute, time.Hour)
+
+	cases := []struct {
+		name        string
+		issuer      *token.Manager
+		permissions []string
+		required    []string
+		noToken     bool
+		code        int
+	}{
+		{
+			name:        "Granted",
+			issuer:      tokens,
+			permissions: []string{permission.MovieWrite, permission.MovieDelete},
+			required:    []string{permission.MovieWrite},
+			code:        http.StatusOK,
+		},
+		{
+			name:        "All required",
+			issuer:      tokens,
+			permissions: []string{permission.MovieWrite},
+			required:    []string{permission.MovieWrite, permission.MovieDelete},
+			code:        http.StatusForbidden,
+		},
+		{
+			name:        "No permissions",
+			issuer:      tokens,
+			permissions: nil,
+			required:    []string{permission.ActorDelete},
+			code:        http.StatusForbidden,
+		},
+		{
+			name:        "Expired token",
+			issuer:      expired,
+			permissions: []string{permission.ActorDelete},
+			required:    []string{permission.ActorDelete},
+			code:        http.StatusUnauthorized,
+		},
+		{
+			name:     "No token",
+			noToken:  true,
+			required: []string{permission.ActorDelete},
+			code:     http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range cases {
+		// tc := tc // go version < 1.22
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			})
+
+			handler := jwtauth.Verifier(tokens.JWTAuth())(
+				permission.RequirePermission(tokens.JWTAuth(), tc.required...)(next))
+
+			req, err := http.NewRequest(http.MethodPost, "/movie/save", nil)
+			require.NoError(t, err)
+
+			if !tc.noToken {
+				accessToken, err := tc.issuer.IssueAccess(1, nil, tc.permissions)
+				require.NoError(t, err)
+
+				req.Header.Set("Authorization", "Bearer "+accessToken)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			require.Equal(t, tc.code, rr.Code)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package token_denylist
+
+import (
+	"film_library/internal/lib/api/response"
+	"film_library/internal/lib/token"
+	"github.com/go-chi/jwtauth/v5"
+	"net/http"
+	"time"
+)
+
+type RevocationChecker interface {
+	IsAccessTokenRevoked(jti string, userId int, issuedAt time.Time) (bool, error)
+}
+
+// New rejects requests whose access token was revoked by /signout, or
+// together with all tokens of its user, e.g. when the user was disabled. It
+// must run after jwtauth.Verifier; requests without a verified token are
+// passed through for the authenticator to deal with.
+func New(revocationChecker RevocationChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		hfn := func(w http.ResponseWriter, r *http.Request) {
+			accessToken, claims, err := jwtauth.FromContext(r.Context())
+			if err != nil || accessToken == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userId, _ := token.UserId(claims)
+			revoked, err := revocationChecker.IsAccessTokenRevoked(accessToken.JwtID(), userId, accessToken.IssuedAt())
+			if err != nil {
+				response.RenderError(w, r, http.StatusInternalServerError, response.CodeInternal, "failed to check token")
+				return
+			}
+
+			if revoked {
+				response.RenderError(w, r, http.StatusUnauthorized, response.CodeInvalidToken, "token revoked")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(hfn)
+	}
+}
@@ -0,0 +1,126 @@
+package etag
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Format returns the strong entity tag of a resource at version.
+func Format(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// FormatState returns the tag of a resource at version whose
+// representation also holds state that does not change the version, such as
+// aggregates of other rows. If-None-Match compares the whole tag while
+// If-Match only reads the version from it.
+func FormatState(version int, state string) string {
+	return `"` + strconv.Itoa(version) + "." + state + `"`
+}
+
+// State returns a short digest of values for FormatState.
+func State(values ...interface{}) string {
+	h := fnv.New64a()
+	// values are plain data, which always encodes
+	_ = json.NewEncoder(h).Encode(values)
+
+	return fmt.Sprintf("%x", h.Sum64())
+}
+
+// Set sets the ETag header of w to the tag of version.
+func Set(w http.ResponseWriter, version int) {
+	w.Header().Set("ETag", Format(version))
+}
+
+// SetTag sets the ETag header of w to tag.
+func SetTag(w http.ResponseWriter, tag string) {
+	w.Header().Set("ETag", tag)
+}
+
+// IfMatch returns the version required by the If-Match header of r: 0 when
+// the header is missing or "*", the version of a single strong tag, and -1,
+// which no resource has, for anything else. Weak tags never match since
+// If-Match uses the strong comparison.
+func IfMatch(r *http.Request) int {
+	tags := parse(r.Header.Get("If-Match"))
+	if len(tags) == 0 {
+		return 0
+	}
+
+	for _, tag := range tags {
+		if tag == "*" {
+			return 0
+		}
+	}
+
+	if len(tags) > 1 {
+		return -1
+	}
+
+	version, ok := strongVersion(tags[0])
+	if !ok {
+		return -1
+	}
+
+	return version
+}
+
+// NotModified reports whether the If-None-Match header of r lists the tag
+// of version, in which case the client's copy is current and a 304 can be
+// sent instead of the resource.
+func NotModified(r *http.Request, version int) bool {
+	for _, tag := range parse(r.Header.Get("If-None-Match")) {
+		if tag == "*" {
+			return true
+		}
+
+		// If-None-Match uses the weak comparison
+		if v, ok := strongVersion(strings.TrimPrefix(tag, "W/")); ok && v == version {
+			return true
+		}
+	}
+
+	return false
+}
+
+// NotModifiedTag reports whether the If-None-Match header of r lists tag,
+// like NotModified.
+func NotModifiedTag(r *http.Request, tag string) bool {
+	for _, t := range parse(r.Header.Get("If-None-Match")) {
+		if t == "*" || strings.TrimPrefix(t, "W/") == tag {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parse(header string) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+
+	return tags
+}
+
+func strongVersion(tag string) (int, bool) {
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, false
+	}
+
+	// the version ends at the state of FormatState tags
+	value, _, _ := strings.Cut(tag[1:len(tag)-1], ".")
+	version, err := strconv.Atoi(value)
+	if err != nil || version < 1 {
+		return 0, false
+	}
+
+	return version, true
+}
@@ -0,0 +1,85 @@
+package etag_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/api/etag"
+)
+
+func TestIfMatch(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   int
+	}{
+		{name: "Missing", want: 0},
+		{name: "Any", header: "*", want: 0},
+		{name: "Strong", header: `"3"`, want: 3},
+		{name: "Weak", header: `W/"3"`, want: -1},
+		{name: "Several", header: `"3", "4"`, want: -1},
+		{name: "Malformed", header: "3", want: -1},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := http.NewRequest(http.MethodPatch, "/", nil)
+			require.NoError(t, err)
+			if tc.header != "" {
+				r.Header.Set("If-Match", tc.header)
+			}
+
+			require.Equal(t, tc.want, etag.IfMatch(r))
+		})
+	}
+}
+
+func TestNotModified(t *testing.T) {
+	cases := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "Missing", want: false},
+		{name: "Current", header: etag.Format(3), want: true},
+		{name: "Weak current", header: `W/"3"`, want: true},
+		{name: "Stale", header: `"2"`, want: false},
+		{name: "List", header: `"1", "3"`, want: true},
+		{name: "Any", header: "*", want: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := http.NewRequest(http.MethodGet, "/", nil)
+			require.NoError(t, err)
+			if tc.header != "" {
+				r.Header.Set("If-None-Match", tc.header)
+			}
+
+			require.Equal(t, tc.want, etag.NotModified(r, 3))
+		})
+	}
+}
+
+func TestStateTag(t *testing.T) {
+	tag := etag.FormatState(3, etag.State(7.5, 2))
+	require.NotEqual(t, tag, etag.FormatState(3, etag.State(7.5, 3)))
+
+	r, err := http.NewRequest(http.MethodGet, "/", nil)
+	require.NoError(t, err)
+
+	r.Header.Set("If-None-Match", "W/"+tag)
+	require.True(t, etag.NotModifiedTag(r, tag))
+	require.False(t, etag.NotModifiedTag(r, etag.FormatState(3, etag.State(7.5, 3))))
+	require.False(t, etag.NotModifiedTag(r, etag.Format(3)))
+
+	// the state does not take part in If-Match
+	r.Header.Set("If-Match", tag)
+	require.Equal(t, 3, etag.IfMatch(r))
+}
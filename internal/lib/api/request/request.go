@@ -0,0 +1,210 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/render"
+)
+
+// MergePatchContentType is the media type of JSON Merge Patch (RFC 7396)
+// bodies, accepted by Decode on PATCH routes.
+const MergePatchContentType = "application/merge-patch+json"
+
+// NullFieldError is returned by Decode for a merge patch that sets a member
+// to null, i.e. asks to remove it, when its field can not be removed.
+type NullFieldError struct {
+	Field string
+}
+
+func (e *NullFieldError) Error() string {
+	return fmt.Sprintf("request: field %s can not be removed", e.Field)
+}
+
+// Decode fills v, a pointer to a request struct, from the JSON body of r
+// and then from its chi URL parameters and query string. Parameters are
+// matched to fields by their json tag name and take precedence over the
+// body, so /movies/{movie_id} and ?movie_id= both fill MovieId. An empty
+// body is allowed. Slice fields accept repeated or comma-separated values.
+//
+// A merge patch body must be an object; members it leaves out keep their
+// zero value, so patchable fields should be pointers. A null member removes
+// a field tagged `patch:"nullable"`: a pointer is set to its zero value and
+// a slice or a map to an empty one, which the storage patches read as
+// clearing. Null for any other field is a NullFieldError.
+func Decode(r *http.Request, v interface{}) error {
+	var nulls map[string]bool
+	if r.Body != nil {
+		body := io.Reader(r.Body)
+
+		if isMergePatch(r) {
+			b, err := io.ReadAll(r.Body)
+			if err != nil {
+				return err
+			}
+			if nulls, err = mergePatchNulls(b); err != nil {
+				return err
+			}
+			body = bytes.NewReader(b)
+		}
+
+		if err := render.DecodeJSON(body, v); err != nil && !errors.Is(err, io.EOF) {
+			return err
+		}
+	}
+
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("request: expected pointer to struct, got %T", v)
+	}
+	rv = rv.Elem()
+
+	query := r.URL.Query()
+	rctx := chi.RouteContext(r.Context())
+
+	for i := 0; i < rv.NumField(); i++ {
+		field := rv.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if nulls[name] {
+			if err := setNull(rv.Field(i), field.Tag.Get("patch") == "nullable"); err != nil {
+				return &NullFieldError{Field: name}
+			}
+		}
+
+		var values []string
+		if rctx != nil {
+			if param := rctx.URLParam(name); param != "" {
+				values = []string{param}
+			}
+		}
+		if values == nil {
+			values = query[name]
+		}
+		if len(values) == 0 {
+			continue
+		}
+
+		if err := set(rv.Field(i), values); err != nil {
+			return fmt.Errorf("request: parameter %s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+func isMergePatch(r *http.Request) bool {
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+
+	return mediaType == MergePatchContentType
+}
+
+// mergePatchNulls returns the names of the members a merge patch sets to
+// null.
+func mergePatchNulls(b []byte) (map[string]bool, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(b, &members); err != nil || members == nil {
+		return nil, errors.New("request: merge patch must be a JSON object")
+	}
+
+	nulls := make(map[string]bool)
+	for name, value := range members {
+		if string(value) == "null" {
+			nulls[name] = true
+		}
+	}
+
+	return nulls, nil
+}
+
+func setNull(field reflect.Value, nullable bool) error {
+	if !nullable {
+		return errors.New("not nullable")
+	}
+
+	switch field.Kind() {
+	case reflect.Pointer:
+		field.Set(reflect.New(field.Type().Elem()))
+	case reflect.Slice:
+		field.Set(reflect.MakeSlice(field.Type(), 0, 0))
+	case reflect.Map:
+		field.Set(reflect.MakeMap(field.Type()))
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
+
+func set(field reflect.Value, values []string) error {
+	switch field.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(field.Type().Elem())
+		if err := set(elem.Elem(), values); err != nil {
+			return err
+		}
+		field.Set(elem)
+
+		return nil
+	case reflect.Slice:
+		var items []string
+		for _, value := range values {
+			for _, item := range strings.Split(value, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					items = append(items, item)
+				}
+			}
+		}
+
+		slice := reflect.MakeSlice(field.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setScalar(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		field.Set(slice)
+
+		return nil
+	default:
+		return setScalar(field, values[len(values)-1])
+	}
+}
+
+func setScalar(field reflect.Value, value string) error {
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(b)
+	default:
+		return fmt.Errorf("unsupported type %s", field.Type())
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package request_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/api/request"
+)
+
+type testRequest struct {
+	MovieId   int      `json:"movie_id"`
+	Title     *string  `json:"title,omitempty"`
+	ActorsIds []int    `json:"actors_ids"`
+	Active    bool     `json:"active"`
+	Runtime   *int     `json:"runtime,omitempty" patch:"nullable"`
+	Tags      []string `json:"tags,omitempty" patch:"nullable"`
+	ignored   string
+}
+
+func TestDecode(t *testing.T) {
+	title := "Heat"
+
+	cases := []struct {
+		name    string
+		url     string
+		body    string
+		params  map[string]string
+		want    testRequest
+		wantErr bool
+	}{
+		{
+			name: "Body only",
+			url:  "/movies",
+			body: `{"movie_id": 1, "title": "Heat", "actors_ids": [1, 2]}`,
+			want: testRequest{MovieId: 1, Title: &title, ActorsIds: []int{1, 2}},
+		},
+		{
+			name: "Empty body with query",
+			url:  "/movies?movie_id=3&actors_ids=1,2&actors_ids=3&active=true",
+			want: testRequest{MovieId: 3, ActorsIds: []int{1, 2, 3}, Active: true},
+		},
+		{
+			name:   "Path param overrides body and query",
+			url:    "/movies/5?movie_id=4",
+			body:   `{"movie_id": 1, "title": "Heat"}`,
+			params: map[string]string{"movie_id": "5"},
+			want:   testRequest{MovieId: 5, Title: &title},
+		},
+		{
+			name: "Pointer from query",
+			url:  "/movies?title=Heat",
+			want: testRequest{Title: &title},
+		},
+		{
+			name:    "Invalid number",
+			url:     "/movies?movie_id=abc",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid body",
+			url:     "/movies",
+			body:    `{"movie_id": "1"`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := http.NewRequest(http.MethodGet, tc.url, strings.NewReader(tc.body))
+			require.NoError(t, err)
+
+			if tc.params != nil {
+				rctx := chi.NewRouteContext()
+				for k, v := range tc.params {
+					rctx.URLParams.Add(k, v)
+				}
+				r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+			}
+
+			var got testRequest
+			err = request.Decode(r, &got)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestDecodeMergePatch(t *testing.T) {
+	title := "Heat"
+
+	cases := []struct {
+		name      string
+		body      string
+		want      testRequest
+		nullField string
+		wantErr   bool
+	}{
+		{
+			name: "Partial",
+			body: `{"title": "Heat"}`,
+			want: testRequest{MovieId: 5, Title: &title},
+		},
+		{
+			name:      "Null member",
+			body:      `{"title": null}`,
+			nullField: "title",
+			wantErr:   true,
+		},
+		{
+			name: "Null nullable members",
+			body: `{"runtime": null, "tags": null, "unknown": null}`,
+			want: testRequest{MovieId: 5, Runtime: new(int), Tags: []string{}},
+		},
+		{
+			name:    "Not an object",
+			body:    `["title"]`,
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r, err := http.NewRequest(http.MethodPatch, "/movies/5", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			r.Header.Set("Content-Type", request.MergePatchContentType+"; charset=utf-8")
+
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("movie_id", "5")
+			r = r.WithContext(context.WithValue(r.Context(), chi.RouteCtxKey, rctx))
+
+			var got testRequest
+			err = request.Decode(r, &got)
+			if tc.wantErr {
+				require.Error(t, err)
+
+				var nullErr *request.NullFieldError
+				if tc.nullField != "" {
+					require.ErrorAs(t, err, &nullErr)
+					require.Equal(t, tc.nullField, nullErr.Field)
+				}
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.want, got)
+		})
+	}
+}
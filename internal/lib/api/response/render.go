@@ -0,0 +1,80 @@
+package response
+
+import (
+	"errors"
+	"film_library/internal/storage"
+	"github.com/go-chi/render"
+	"net/http"
+)
+
+// errorStatuses maps storage errors to the status and code they are
+// reported with. Errors not listed are internal errors.
+var errorStatuses = []struct {
+	err    error
+	status int
+	code   string
+}{
+	{storage.ErrNotFound, http.StatusNotFound, CodeNotFound},
+	{storage.ErrUserNotFound, http.StatusNotFound, CodeNotFound},
+	{storage.ErrRoleNotFound, http.StatusNotFound, CodeNotFound},
+	{storage.ErrConflict, http.StatusConflict, CodeConflict},
+	{storage.ErrUserExists, http.StatusConflict, CodeConflict},
+	{storage.ErrLastAdmin, http.StatusConflict, CodeConflict},
+	{storage.ErrForeignKey, http.StatusBadRequest, CodeInvalidReference},
+	{storage.ErrInvalidValue, http.StatusBadRequest, CodeValidationFailed},
+	{storage.ErrInvalidCursor, http.StatusBadRequest, CodeValidationFailed},
+	{storage.ErrVersionMismatch, http.StatusPreconditionFailed, CodePreconditionFailed},
+	{storage.ErrInvalidCredentials, http.StatusUnauthorized, CodeInvalidCredentials},
+	{storage.ErrTokenNotFound, http.StatusUnauthorized, CodeInvalidToken},
+	{storage.ErrTokenExpired, http.StatusUnauthorized, CodeInvalidToken},
+	{storage.ErrTokenReused, http.StatusUnauthorized, CodeInvalidToken},
+	{storage.ErrUserDisabled, http.StatusForbidden, CodeUserDisabled},
+	{storage.ErrPasswordResetRequired, http.StatusForbidden, CodePasswordResetRequired},
+}
+
+// Render writes v as JSON with the given HTTP status.
+func Render(w http.ResponseWriter, r *http.Request, status int, v interface{}) {
+	render.Status(r, status)
+	render.JSON(w, r, v)
+}
+
+func RenderError(w http.ResponseWriter, r *http.Request, status int, code string, msg string, details ...FieldError) {
+	Render(w, r, status, ErrorWithCode(code, msg, details...))
+}
+
+func BadRequest(w http.ResponseWriter, r *http.Request, msg string) {
+	RenderError(w, r, http.StatusBadRequest, CodeBadRequest, msg)
+}
+
+// ValidationFailed reports a single invalid request field.
+func ValidationFailed(w http.ResponseWriter, r *http.Request, field string, msg string) {
+	RenderError(w, r, http.StatusBadRequest, CodeValidationFailed, msg, FieldError{Field: field, Message: msg})
+}
+
+func Unauthorized(w http.ResponseWriter, r *http.Request, msg string) {
+	RenderError(w, r, http.StatusUnauthorized, CodeUnauthorized, msg)
+}
+
+func Forbidden(w http.ResponseWriter, r *http.Request, msg string) {
+	RenderError(w, r, http.StatusForbidden, CodeForbidden, msg)
+}
+
+// FromError reports err with msg, choosing the status and code from the
+// storage error it wraps. Anything else is a 500. A violated constraint is
+// also reported in details against the field it guards.
+func FromError(w http.ResponseWriter, r *http.Request, err error, msg string) {
+	var details []FieldError
+	var constraintErr *storage.ConstraintError
+	if errors.As(err, &constraintErr) {
+		details = append(details, FieldError{Field: constraintErr.Field, Message: constraintErr.Err.Error()})
+	}
+
+	for _, s := range errorStatuses {
+		if errors.Is(err, s.err) {
+			RenderError(w, r, s.status, s.code, msg, details...)
+			return
+		}
+	}
+
+	RenderError(w, r, http.StatusInternalServerError, CodeInternal, msg)
+}
@@ -0,0 +1,104 @@
+package response_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/api/response"
+	"film_library/internal/storage"
+)
+
+func TestFromError(t *testing.T) {
+	cases := []struct {
+		name    string
+		err     error
+		status  int
+		code    string
+		details []response.FieldError
+	}{
+		{
+			name:   "Not found",
+			err:    fmt.Errorf("storage.postgres.GetMovie: %w", storage.ErrNotFound),
+			status: http.StatusNotFound,
+			code:   response.CodeNotFound,
+		},
+		{
+			name:   "Conflict",
+			err:    fmt.Errorf("storage.postgres.SaveActorMovie: %w", storage.ErrConflict),
+			status: http.StatusConflict,
+			code:   response.CodeConflict,
+		},
+		{
+			name:   "Foreign key",
+			err:    fmt.Errorf("storage.postgres.SaveActorMovie: %w", storage.ErrForeignKey),
+			status: http.StatusBadRequest,
+			code:   response.CodeInvalidReference,
+		},
+		{
+			name:   "Version mismatch",
+			err:    fmt.Errorf("storage.postgres.UpdateMovie: %w", storage.ErrVersionMismatch),
+			status: http.StatusPreconditionFailed,
+			code:   response.CodePreconditionFailed,
+		},
+		{
+			name:    "Constraint",
+			err:     fmt.Errorf("storage.postgres.SaveUser: %w", &storage.ConstraintError{Field: "username", Err: storage.ErrUserExists}),
+			status:  http.StatusConflict,
+			code:    response.CodeConflict,
+			details: []response.FieldError{{Field: "username", Message: storage.ErrUserExists.Error()}},
+		},
+		{
+			name:    "Invalid value",
+			err:     fmt.Errorf("storage.postgres.SaveMovie: %w", &storage.ConstraintError{Field: "rating", Err: storage.ErrInvalidValue}),
+			status:  http.StatusBadRequest,
+			code:    response.CodeValidationFailed,
+			details: []response.FieldError{{Field: "rating", Message: storage.ErrInvalidValue.Error()}},
+		},
+		{
+			name:   "Unexpected",
+			err:    errors.New("connection refused"),
+			status: http.StatusInternalServerError,
+			code:   response.CodeInternal,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rr := httptest.NewRecorder()
+
+			response.FromError(rr, req, tc.err, "request failed")
+
+			require.Equal(t, tc.status, rr.Code)
+
+			var resp response.Response
+			require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+			require.Equal(t, response.StatusError, resp.Status)
+			require.Equal(t, tc.code, resp.Code)
+			require.Equal(t, "request failed", resp.Error)
+			require.Equal(t, tc.details, resp.Details)
+		})
+	}
+}
+
+func TestValidationFailed(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	rr := httptest.NewRecorder()
+
+	response.ValidationFailed(rr, req, "movie_id", "field movie_id is not valid")
+
+	require.Equal(t, http.StatusBadRequest, rr.Code)
+
+	var resp response.Response
+	require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+	require.Equal(t, response.CodeValidationFailed, resp.Code)
+	require.Equal(t, []response.FieldError{{Field: "movie_id", Message: "field movie_id is not valid"}}, resp.Details)
+}
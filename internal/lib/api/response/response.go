@@ -2,8 +2,16 @@ package response
 
 // @Schema
 type Response struct {
-	Status string `json:"status"`
-	Error  string `json:"error,omitempty"`
+	Status  string       `json:"status"`
+	Error   string       `json:"error,omitempty"`
+	Code    string       `json:"code,omitempty"`
+	Details []FieldError `json:"details,omitempty"`
+}
+
+// FieldError describes why a single request field was rejected.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
 }
 
 const (
@@ -11,6 +19,24 @@ const (
 	StatusError = "Error"
 )
 
+// Machine-readable error codes. Clients should branch on these rather than
+// on the error message.
+const (
+	CodeBadRequest            = "bad_request"
+	CodeValidationFailed      = "validation_failed"
+	CodeUnauthorized          = "unauthorized"
+	CodeInvalidCredentials    = "invalid_credentials"
+	CodeInvalidToken          = "invalid_token"
+	CodeForbidden             = "forbidden"
+	CodeUserDisabled          = "user_disabled"
+	CodePasswordResetRequired = "password_reset_required"
+	CodeNotFound              = "not_found"
+	CodeConflict              = "conflict"
+	CodeInvalidReference      = "invalid_reference"
+	CodePreconditionFailed    = "precondition_failed"
+	CodeInternal              = "internal_error"
+)
+
 func OK() Response {
 	return Response{Status: StatusOK}
 }
@@ -18,3 +44,7 @@ func OK() Response {
 func Error(msg string) Response {
 	return Response{Status: StatusError, Error: msg}
 }
+
+func ErrorWithCode(code string, msg string, details ...FieldError) Response {
+	return Response{Status: StatusError, Error: msg, Code: code, Details: details}
+}
@@ -0,0 +1,27 @@
+package audit
+
+import (
+	"context"
+)
+
+// Actor is who makes a change and in which request. Storage records it in
+// the audit log with every change made under a context carrying it.
+type Actor struct {
+	// UserId is 0 for requests without a signed in user, e.g. signup.
+	UserId    int
+	RequestId string
+}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of ctx carrying actor.
+func NewContext(ctx context.Context, actor Actor) context.Context {
+	return context.WithValue(ctx, ctxKey{}, actor)
+}
+
+// FromContext returns the actor carried by ctx, if any.
+func FromContext(ctx context.Context) (Actor, bool) {
+	actor, ok := ctx.Value(ctxKey{}).(Actor)
+
+	return actor, ok
+}
@@ -0,0 +1,21 @@
+package audit_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/audit"
+)
+
+func TestContext(t *testing.T) {
+	_, ok := audit.FromContext(context.Background())
+	require.False(t, ok)
+
+	ctx := audit.NewContext(context.Background(), audit.Actor{UserId: 7, RequestId: "host/1"})
+
+	actor, ok := audit.FromContext(ctx)
+	require.True(t, ok)
+	require.Equal(t, audit.Actor{UserId: 7, RequestId: "host/1"}, actor)
+}
@@ -0,0 +1,77 @@
+package catalogue
+
+import (
+	"context"
+	"film_library/internal/storage/postgres"
+	"io"
+)
+
+type Exporter interface {
+	ExportCatalogue(ctx context.Context, fn func(row postgres.ExportRow) error) error
+}
+
+// Export writes every actor and then every movie of exporter to w in the
+// given format, which Import reads back.
+func Export(ctx context.Context, w io.Writer, format string, exporter Exporter) error {
+	writer, err := NewWriter(w, format)
+	if err != nil {
+		return err
+	}
+
+	err = exporter.ExportCatalogue(ctx, func(row postgres.ExportRow) error {
+		return writer.Write(toRecord(row))
+	})
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
+
+func toRecord(row postgres.ExportRow) Record {
+	if row.Actor != nil {
+		return Record{
+			Type:      TypeActor,
+			Name:      row.Actor.Name,
+			Gender:    row.Actor.Gender,
+			Birthdate: row.Actor.Birthdate,
+			Movies:    row.Actor.Movies,
+
+			Biography:   row.Actor.Biography,
+			Birthplace:  row.Actor.Birthplace,
+			Nationality: row.Actor.Nationality,
+			DeathDate:   row.Actor.DeathDate,
+			Aliases:     row.Actor.Aliases,
+			ExternalIds: row.Actor.ExternalIds,
+		}
+	}
+
+	return Record{
+		Type:        TypeMovie,
+		Title:       row.Movie.Title,
+		Description: row.Movie.Description,
+		ReleaseDate: row.Movie.ReleaseDate,
+		Rating:      row.Movie.Rating,
+		Credits:     toCredits(row.Movie.Credits),
+
+		Genres:           row.Movie.Genres,
+		Countries:        row.Movie.Countries,
+		Runtime:          row.Movie.Runtime,
+		OriginalLanguage: row.Movie.OriginalLanguage,
+		Certification:    row.Movie.Certification,
+		Tags:             row.Movie.Tags,
+	}
+}
+
+func toCredits(credits []postgres.Credit) []Credit {
+	list := make([]Credit, len(credits))
+	for i, credit := range credits {
+		list[i] = Credit{
+			Name:         credit.Name,
+			CreditType:   credit.CreditType,
+			Character:    credit.Character,
+			BillingOrder: credit.BillingOrder,
+		}
+	}
+	return list
+}
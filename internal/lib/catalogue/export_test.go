@@ -0,0 +1,112 @@
+package catalogue_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/catalogue"
+	"film_library/internal/storage/postgres"
+)
+
+type fakeExporter struct {
+	rows []postgres.ExportRow
+	err  error
+}
+
+func (f fakeExporter) ExportCatalogue(_ context.Context, fn func(row postgres.ExportRow) error) error {
+	for _, row := range f.rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return f.err
+}
+
+var godfatherDetails = postgres.MovieDetails{
+	Runtime:          175,
+	OriginalLanguage: "en",
+	Certification:    "R",
+	Genres:           []string{"crime", "drama"},
+	Countries:        []string{"US"},
+	Tags:             []string{"mafia"},
+}
+
+var brandoProfile = postgres.ActorProfile{
+	Biography:   "Born in Omaha, Nebraska",
+	Birthplace:  "Omaha",
+	Nationality: "US",
+	DeathDate:   "2004-07-01",
+	Aliases:     []string{"Bud"},
+	ExternalIds: map[string]string{"imdb": "nm0000008", "wikidata": "Q34012"},
+}
+
+var godfatherCredits = []postgres.Credit{
+	{Name: "Marlon Brando", CreditType: "actor", Character: "Vito Corleone: the Don", BillingOrder: 1},
+	{Name: "Al Pacino", CreditType: "actor", BillingOrder: 2},
+	{Name: "Francis Ford Coppola", CreditType: "director"},
+}
+
+var exportRows = []postgres.ExportRow{
+	{Actor: &postgres.ExportActor{Name: "Marlon Brando", Gender: "male", Birthdate: "1924-04-03", Movies: []string{"The Godfather"}, ActorProfile: brandoProfile}},
+	{Actor: &postgres.ExportActor{Name: "Al Pacino", Gender: "male", Birthdate: "1940-04-25", Movies: []string{"The Godfather", "Scarface"}}},
+	{Movie: &postgres.ExportMovie{Title: "The Godfather", Description: "Crime saga, \"part 1\"", ReleaseDate: "1972-03-24", Rating: 9, Credits: godfatherCredits, MovieDetails: godfatherDetails}},
+	{Movie: &postgres.ExportMovie{Title: "Scarface", ReleaseDate: "1983-12-09", Credits: []postgres.Credit{{Name: "Al Pacino", CreditType: "actor"}}}},
+	{Movie: &postgres.ExportMovie{Title: "Silent", ReleaseDate: "1900-01-01", Rating: 1}},
+}
+
+func TestExportRoundTrip(t *testing.T) {
+	for _, format := range []string{catalogue.FormatCSV, catalogue.FormatNDJSON, catalogue.FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			require.NoError(t, catalogue.Export(context.Background(), &buf, format, fakeExporter{rows: exportRows}))
+
+			records, errs := readAll(t, buf.String(), format)
+			require.Empty(t, errs)
+			require.Len(t, records, len(exportRows))
+			require.Equal(t, []string{"The Godfather", "Scarface"}, records[1].Movies)
+
+			importer := &fakeImporter{}
+			report, err := catalogue.Import(context.Background(), &buf, format, importer, catalogue.DefaultBatchSize)
+			require.NoError(t, err)
+			require.Equal(t, len(exportRows), report.Created)
+
+			rows := importer.batches[0]
+			require.Equal(t, postgres.ImportActor{Name: "Marlon Brando", Gender: "male", Birthdate: "1924-04-03", ActorProfile: brandoProfile}, *rows[0].Actor)
+			require.Equal(t, postgres.ImportActor{Name: "Al Pacino", Gender: "male", Birthdate: "1940-04-25"}, *rows[1].Actor)
+			require.Equal(t, postgres.ImportMovie{
+				Title:        "The Godfather",
+				Description:  "Crime saga, \"part 1\"",
+				ReleaseDate:  "1972-03-24",
+				Rating:       9,
+				Credits:      godfatherCredits,
+				MovieDetails: godfatherDetails,
+			}, *rows[2].Movie)
+			require.Zero(t, rows[3].Movie.MovieDetails.Runtime)
+			require.Empty(t, rows[4].Movie.Credits)
+		})
+	}
+}
+
+func TestExportEmpty(t *testing.T) {
+	for _, format := range []string{catalogue.FormatCSV, catalogue.FormatNDJSON, catalogue.FormatJSON} {
+		var buf bytes.Buffer
+		require.NoError(t, catalogue.Export(context.Background(), &buf, format, fakeExporter{}))
+
+		records, errs := readAll(t, buf.String(), format)
+		require.Empty(t, errs)
+		require.Empty(t, records)
+	}
+}
+
+func TestExportFails(t *testing.T) {
+	var buf bytes.Buffer
+	err := catalogue.Export(context.Background(), &buf, catalogue.FormatJSON, fakeExporter{err: errors.New("connection lost")})
+	require.Error(t, err)
+	require.Empty(t, buf.String())
+}
@@ -0,0 +1,235 @@
+package catalogue
+
+import (
+	"context"
+	"errors"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+	"fmt"
+	"io"
+	"strings"
+
+	actorMovieSave "film_library/internal/http-server/handlers/actor-movie/save"
+	actorSave "film_library/internal/http-server/handlers/actor/save"
+	movieSave "film_library/internal/http-server/handlers/movie/save"
+)
+
+const DefaultBatchSize = 500
+
+type Importer interface {
+	ImportBatch(ctx context.Context, rows []postgres.ImportRow) ([]postgres.ImportResult, error)
+}
+
+// Report sums up an import. Rows are numbered from 1, not counting the CSV
+// header.
+type Report struct {
+	Created   int        `json:"created"`
+	Updated   int        `json:"updated"`
+	Unchanged int        `json:"unchanged"`
+	Failed    int        `json:"failed"`
+	Errors    []RowError `json:"errors"`
+}
+
+func (r *Report) fail(err *RowError) {
+	r.Failed++
+	r.Errors = append(r.Errors, *err)
+}
+
+// Import reads the records of r, validates them like the save handlers do
+// and hands them to importer batchSize at a time, DefaultBatchSize if it
+// is not positive. Rows that fail are listed
+// in the report and skipped; an error is returned only when r can not be
+// read any further, wrapping ErrMalformed, or a batch fails as a whole,
+// together with the report of the batches imported until then.
+func Import(ctx context.Context, r io.Reader, format string, importer Importer, batchSize int) (Report, error) {
+	report := Report{Errors: []RowError{}}
+	if batchSize < 1 {
+		batchSize = DefaultBatchSize
+	}
+
+	reader, err := NewReader(r, format)
+	if err != nil {
+		return report, err
+	}
+
+	var batch []postgres.ImportRow
+	var rowNumbers []int
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		results, err := importer.ImportBatch(ctx, batch)
+		if err != nil {
+			return err
+		}
+
+		for i, result := range results {
+			switch {
+			case result.Err != nil:
+				report.fail(storageError(rowNumbers[i], result.Err))
+			case result.Action == postgres.ImportCreated:
+				report.Created++
+			case result.Action == postgres.ImportUpdated:
+				report.Updated++
+			default:
+				report.Unchanged++
+			}
+		}
+
+		batch, rowNumbers = batch[:0], rowNumbers[:0]
+		return nil
+	}
+
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			rowErr.Row = row
+			report.fail(rowErr)
+			continue
+		}
+		if err != nil {
+			return report, fmt.Errorf("%w: %w", ErrMalformed, err)
+		}
+
+		importRow, rowErr := toImportRow(record)
+		if rowErr != nil {
+			rowErr.Row = row
+			report.fail(rowErr)
+			continue
+		}
+
+		batch = append(batch, importRow)
+		rowNumbers = append(rowNumbers, row)
+		if len(batch) >= batchSize {
+			if err = flush(); err != nil {
+				return report, err
+			}
+		}
+	}
+
+	return report, flush()
+}
+
+func toImportRow(record Record) (postgres.ImportRow, *RowError) {
+	switch record.Type {
+	case TypeActor:
+		if ok, field, msg := actorSave.ValidateRequest(actorSave.Request{
+			Name:      record.Name,
+			Gender:    record.Gender,
+			Birthdate: record.Birthdate,
+
+			Biography:   record.Biography,
+			Birthplace:  record.Birthplace,
+			Nationality: record.Nationality,
+			DeathDate:   record.DeathDate,
+			Aliases:     record.Aliases,
+			ExternalIds: record.ExternalIds,
+		}); !ok {
+			return postgres.ImportRow{}, &RowError{Field: field.Value.String(), Message: msg}
+		}
+
+		return postgres.ImportRow{Actor: &postgres.ImportActor{
+			Name:      record.Name,
+			Gender:    record.Gender,
+			Birthdate: record.Birthdate,
+			ActorProfile: postgres.ActorProfile{
+				Biography:   record.Biography,
+				Birthplace:  record.Birthplace,
+				Nationality: record.Nationality,
+				DeathDate:   record.DeathDate,
+				Aliases:     record.Aliases,
+				ExternalIds: record.ExternalIds,
+			},
+		}}, nil
+	case TypeMovie:
+		if ok, field, msg := movieSave.ValidateRequest(movieSave.Request{
+			Title:       record.Title,
+			Description: record.Description,
+			ReleaseDate: record.ReleaseDate,
+			Rating:      record.Rating,
+
+			Runtime:          record.Runtime,
+			OriginalLanguage: record.OriginalLanguage,
+			Certification:    record.Certification,
+			Genres:           record.Genres,
+			Countries:        record.Countries,
+			Tags:             record.Tags,
+		}); !ok {
+			return postgres.ImportRow{}, &RowError{Field: field.Value.String(), Message: msg}
+		}
+
+		credits, rowErr := importCredits(record.Credits)
+		if rowErr != nil {
+			return postgres.ImportRow{}, rowErr
+		}
+
+		return postgres.ImportRow{Movie: &postgres.ImportMovie{
+			Title:       record.Title,
+			Description: record.Description,
+			ReleaseDate: record.ReleaseDate,
+			Rating:      record.Rating,
+			Cast:        record.Cast,
+			Credits:     credits,
+			MovieDetails: postgres.MovieDetails{
+				Runtime:          record.Runtime,
+				OriginalLanguage: record.OriginalLanguage,
+				Certification:    record.Certification,
+				Genres:           record.Genres,
+				Countries:        record.Countries,
+				Tags:             record.Tags,
+			},
+		}}, nil
+	}
+
+	return postgres.ImportRow{}, &RowError{Field: "type", Message: "field type is not valid"}
+}
+
+// importCredits validates credits like the credit handler does, acting
+// being the default credit type.
+func importCredits(credits []Credit) ([]postgres.Credit, *RowError) {
+	var list []postgres.Credit
+	for _, credit := range credits {
+		if credit.CreditType == "" {
+			credit.CreditType = postgres.CreditActor
+		}
+		ok, _ := actorMovieSave.ValidateCredit(actorMovieSave.Credit{
+			CreditType:   credit.CreditType,
+			Character:    credit.Character,
+			BillingOrder: credit.BillingOrder,
+		})
+		if !ok || strings.TrimSpace(credit.Name) == "" {
+			return nil, &RowError{Field: "credits", Message: "field credits is not valid"}
+		}
+
+		list = append(list, postgres.Credit{
+			Name:         credit.Name,
+			CreditType:   credit.CreditType,
+			Character:    credit.Character,
+			BillingOrder: credit.BillingOrder,
+		})
+	}
+	return list, nil
+}
+
+func storageError(row int, err error) *RowError {
+	var constraintErr *storage.ConstraintError
+	if errors.As(err, &constraintErr) {
+		return &RowError{Row: row, Field: constraintErr.Field, Message: constraintErr.Err.Error()}
+	}
+
+	// the underlying database error is only fit for the log
+	for _, target := range []error{storage.ErrConflict, storage.ErrForeignKey, storage.ErrInvalidValue} {
+		if errors.Is(err, target) {
+			return &RowError{Row: row, Message: target.Error()}
+		}
+	}
+
+	return &RowError{Row: row, Message: "row rejected"}
+}
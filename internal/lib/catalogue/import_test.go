@@ -0,0 +1,93 @@
+package catalogue_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/catalogue"
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+// fakeImporter creates every row except movies casting "Nobody", and
+// fails the batch number failBatch.
+type fakeImporter struct {
+	batches   [][]postgres.ImportRow
+	failBatch int
+}
+
+func (f *fakeImporter) ImportBatch(_ context.Context, rows []postgres.ImportRow) ([]postgres.ImportResult, error) {
+	f.batches = append(f.batches, append([]postgres.ImportRow(nil), rows...))
+	if len(f.batches) == f.failBatch {
+		return nil, errors.New("connection lost")
+	}
+
+	results := make([]postgres.ImportResult, len(rows))
+	for i, row := range rows {
+		results[i] = postgres.ImportResult{Id: i + 1, Action: postgres.ImportCreated}
+		if row.Movie != nil && len(row.Movie.Cast) > 0 && row.Movie.Cast[0] == "Nobody" {
+			results[i] = postgres.ImportResult{Err: &storage.ConstraintError{
+				Field: "cast",
+				Err:   fmt.Errorf("actor %q %w", "Nobody", storage.ErrNotFound),
+			}}
+		}
+	}
+
+	return results, nil
+}
+
+const input = `type,name,gender,birthdate,title,release_date,rating,cast
+actor,Marlon Brando,male,1924-04-03,,,,
+actor,Al Pacino,unknown,1940-04-25,,,,
+movie,,,,The Godfather,1972-03-24,9,Marlon Brando
+movie,,,,Nobody's movie,1972-03-24,5,Nobody
+series,,,,,,,
+movie,,,,The Godfather Part II,1974-12-12,11,
+`
+
+func TestImport(t *testing.T) {
+	importer := &fakeImporter{}
+
+	report, err := catalogue.Import(context.Background(), strings.NewReader(input), catalogue.FormatCSV, importer, 2)
+	require.NoError(t, err)
+
+	require.Equal(t, catalogue.Report{
+		Created: 2,
+		Failed:  4,
+		Errors: []catalogue.RowError{
+			{Row: 2, Field: "gender", Message: "field gender is not valid"},
+			{Row: 5, Field: "type", Message: "field type is not valid"},
+			{Row: 6, Field: "rating", Message: "field rating is not valid"},
+			{Row: 4, Field: "cast", Message: `actor "Nobody" not found`},
+		},
+	}, report)
+
+	require.Len(t, importer.batches, 2)
+	require.Equal(t, "Marlon Brando", importer.batches[0][0].Actor.Name)
+	require.Equal(t, "The Godfather", importer.batches[0][1].Movie.Title)
+	require.Equal(t, []string{"Nobody"}, importer.batches[1][0].Movie.Cast)
+}
+
+func TestImportBatchFails(t *testing.T) {
+	importer := &fakeImporter{failBatch: 2}
+
+	report, err := catalogue.Import(context.Background(), strings.NewReader(input), catalogue.FormatCSV, importer, 1)
+	require.Error(t, err)
+	require.NotErrorIs(t, err, catalogue.ErrMalformed)
+
+	require.Equal(t, 1, report.Created)
+	require.Equal(t, 1, report.Failed)
+}
+
+func TestImportMalformed(t *testing.T) {
+	importer := &fakeImporter{}
+
+	_, err := catalogue.Import(context.Background(), strings.NewReader(`[{"type":"actor"`), catalogue.FormatJSON, importer, 1)
+	require.ErrorIs(t, err, catalogue.ErrMalformed)
+	require.Empty(t, importer.batches)
+}
@@ -0,0 +1,338 @@
+package catalogue
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatJSON   = "json"
+)
+
+const (
+	TypeMovie = "movie"
+	TypeActor = "actor"
+)
+
+var (
+	ErrUnknownFormat = errors.New("unknown format")
+	ErrMalformed     = errors.New("malformed input")
+)
+
+// Record is a movie or an actor as it is imported and exported. Cast,
+// Credits and Movies hold names and titles rather than ids, so that a file
+// can be moved between libraries. Cast adds unranked actors and is not
+// exported, Credits holds the whole cast and crew. Movies is informative
+// and ignored on import. In CSV ExternalIds are listed as source:id and
+// Credits as credit_type:billing_order:name:character, with the billing
+// order and the character left empty when unset.
+type Record struct {
+	Type        string   `json:"type"`
+	Name        string   `json:"name,omitempty"`
+	Gender      string   `json:"gender,omitempty"`
+	Birthdate   string   `json:"birthdate,omitempty"`
+	Movies      []string `json:"movies,omitempty"`
+	Title       string   `json:"title,omitempty"`
+	Description string   `json:"description,omitempty"`
+	ReleaseDate string   `json:"release_date,omitempty"`
+	Rating      int      `json:"rating,omitempty"`
+	Cast        []string `json:"cast,omitempty"`
+	Credits     []Credit `json:"credits,omitempty"`
+
+	Genres           []string `json:"genres,omitempty"`
+	Countries        []string `json:"countries,omitempty"`
+	Runtime          int      `json:"runtime,omitempty"`
+	OriginalLanguage string   `json:"original_language,omitempty"`
+	Certification    string   `json:"certification,omitempty"`
+	Tags             []string `json:"tags,omitempty"`
+
+	Biography   string            `json:"biography,omitempty"`
+	Birthplace  string            `json:"birthplace,omitempty"`
+	Nationality string            `json:"nationality,omitempty"`
+	DeathDate   string            `json:"death_date,omitempty"`
+	Aliases     []string          `json:"aliases,omitempty"`
+	ExternalIds map[string]string `json:"external_ids,omitempty"`
+}
+
+// Credit is a cast or crew credit of a movie Record, naming the person.
+type Credit struct {
+	Name         string `json:"name"`
+	CreditType   string `json:"credit_type"`
+	Character    string `json:"character,omitempty"`
+	BillingOrder int    `json:"billing_order,omitempty"`
+}
+
+// Columns are the CSV columns, in the order they are exported. Lists are
+// joined with ListSeparator.
+var Columns = []string{"type", "name", "gender", "birthdate", "movies", "title", "description", "release_date", "rating", "cast",
+	"genres", "countries", "runtime", "original_language", "certification", "tags",
+	"biography", "birthplace", "nationality", "death_date", "aliases", "external_ids", "credits"}
+
+const ListSeparator = "|"
+
+// RowError is a row that could not be imported. Reading can go on after a
+// Reader returns one.
+type RowError struct {
+	Row     int    `json:"row"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"error"`
+}
+
+func (e *RowError) Error() string {
+	if e.Field == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+	}
+	return fmt.Sprintf("row %d: %s: %s", e.Row, e.Field, e.Message)
+}
+
+// Reader reads records one row at a time and returns io.EOF after the last
+// one.
+type Reader interface {
+	Read() (Record, error)
+}
+
+// NewReader returns a Reader of the given format.
+func NewReader(r io.Reader, format string) (Reader, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVReader(r), nil
+	case FormatNDJSON:
+		return newNDJSONReader(r), nil
+	case FormatJSON:
+		return newJSONReader(r), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+type csvReader struct {
+	r      *csv.Reader
+	header map[string]int
+}
+
+func newCSVReader(r io.Reader) *csvReader {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+
+	return &csvReader{r: cr}
+}
+
+func (c *csvReader) Read() (Record, error) {
+	if c.header == nil {
+		header, err := c.r.Read()
+		if errors.Is(err, io.EOF) {
+			return Record{}, io.EOF
+		}
+		if err != nil {
+			return Record{}, fmt.Errorf("failed to read header: %w", err)
+		}
+
+		c.header = make(map[string]int, len(header))
+		for i, column := range header {
+			c.header[strings.TrimSpace(column)] = i
+		}
+		if _, ok := c.header["type"]; !ok {
+			return Record{}, errors.New("header has no type column")
+		}
+	}
+
+	fields, err := c.r.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return Record{}, &RowError{Message: parseErr.Err.Error()}
+	}
+	if err != nil {
+		return Record{}, err
+	}
+	if len(fields) != len(c.header) {
+		return Record{}, &RowError{Message: "wrong number of fields"}
+	}
+
+	field := func(column string) string {
+		if i, ok := c.header[column]; ok {
+			return fields[i]
+		}
+		return ""
+	}
+
+	record := Record{
+		Type:        field("type"),
+		Name:        field("name"),
+		Gender:      field("gender"),
+		Birthdate:   field("birthdate"),
+		Movies:      splitList(field("movies")),
+		Title:       field("title"),
+		Description: field("description"),
+		ReleaseDate: field("release_date"),
+		Cast:        splitList(field("cast")),
+
+		Genres:           splitList(field("genres")),
+		Countries:        splitList(field("countries")),
+		OriginalLanguage: field("original_language"),
+		Certification:    field("certification"),
+		Tags:             splitList(field("tags")),
+
+		Biography:   field("biography"),
+		Birthplace:  field("birthplace"),
+		Nationality: field("nationality"),
+		DeathDate:   field("death_date"),
+		Aliases:     splitList(field("aliases")),
+	}
+
+	if rating := field("rating"); rating != "" {
+		if record.Rating, err = strconv.Atoi(rating); err != nil {
+			return Record{}, &RowError{Field: "rating", Message: "field rating is not valid"}
+		}
+	}
+	if runtime := field("runtime"); runtime != "" {
+		if record.Runtime, err = strconv.Atoi(runtime); err != nil {
+			return Record{}, &RowError{Field: "runtime", Message: "field runtime is not valid"}
+		}
+	}
+	for _, item := range splitList(field("external_ids")) {
+		source, id, ok := strings.Cut(item, ":")
+		if !ok {
+			return Record{}, &RowError{Field: "external_ids", Message: "field external_ids is not valid"}
+		}
+		if record.ExternalIds == nil {
+			record.ExternalIds = make(map[string]string)
+		}
+		record.ExternalIds[strings.TrimSpace(source)] = strings.TrimSpace(id)
+	}
+	for _, item := range splitList(field("credits")) {
+		credit, ok := parseCredit(item)
+		if !ok {
+			return Record{}, &RowError{Field: "credits", Message: "field credits is not valid"}
+		}
+		record.Credits = append(record.Credits, credit)
+	}
+
+	return record, nil
+}
+
+// parseCredit parses a credit_type:billing_order:name:character item. The
+// character comes last, so it may hold colons.
+func parseCredit(item string) (Credit, bool) {
+	parts := strings.SplitN(item, ":", 4)
+	if len(parts) < 3 {
+		return Credit{}, false
+	}
+
+	credit := Credit{CreditType: strings.TrimSpace(parts[0]), Name: strings.TrimSpace(parts[2])}
+	if order := strings.TrimSpace(parts[1]); order != "" {
+		var err error
+		if credit.BillingOrder, err = strconv.Atoi(order); err != nil {
+			return Credit{}, false
+		}
+	}
+	if len(parts) == 4 {
+		credit.Character = strings.TrimSpace(parts[3])
+	}
+
+	return credit, true
+}
+
+func splitList(s string) []string {
+	var list []string
+	for _, item := range strings.Split(s, ListSeparator) {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// maxLineSize bounds an NDJSON line, that is a single record.
+const maxLineSize = 1 << 20
+
+type ndjsonReader struct {
+	s *bufio.Scanner
+}
+
+func newNDJSONReader(r io.Reader) *ndjsonReader {
+	s := bufio.NewScanner(r)
+	s.Buffer(nil, maxLineSize)
+
+	return &ndjsonReader{s: s}
+}
+
+func (n *ndjsonReader) Read() (Record, error) {
+	for n.s.Scan() {
+		line := n.s.Bytes()
+		if len(strings.TrimSpace(string(line))) == 0 {
+			continue
+		}
+
+		var record Record
+		if err := json.Unmarshal(line, &record); err != nil {
+			return Record{}, decodeError(err)
+		}
+		return record, nil
+	}
+	if err := n.s.Err(); err != nil {
+		return Record{}, err
+	}
+
+	return Record{}, io.EOF
+}
+
+// jsonReader reads a JSON array of records without loading it whole.
+type jsonReader struct {
+	d       *json.Decoder
+	started bool
+}
+
+func newJSONReader(r io.Reader) *jsonReader {
+	return &jsonReader{d: json.NewDecoder(r)}
+}
+
+func (j *jsonReader) Read() (Record, error) {
+	if !j.started {
+		token, err := j.d.Token()
+		if err != nil {
+			return Record{}, err
+		}
+		if token != json.Delim('[') {
+			return Record{}, errors.New("expected a JSON array")
+		}
+		j.started = true
+	}
+
+	if !j.d.More() {
+		if _, err := j.d.Token(); err != nil {
+			return Record{}, err
+		}
+		return Record{}, io.EOF
+	}
+
+	var record Record
+	err := j.d.Decode(&record)
+	// the decoder skips past a value of the wrong type, anything else
+	// leaves it in the middle of the array
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return Record{}, decodeError(err)
+	}
+	if err != nil {
+		return Record{}, err
+	}
+
+	return record, nil
+}
+
+func decodeError(err error) *RowError {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &RowError{Field: typeErr.Field, Message: fmt.Sprintf("field %s is not valid", typeErr.Field)}
+	}
+
+	return &RowError{Message: "invalid JSON"}
+}
@@ -0,0 +1,228 @@
+package catalogue_test
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/catalogue"
+)
+
+func readAll(t *testing.T, input string, format string) ([]catalogue.Record, []error) {
+	t.Helper()
+
+	reader, err := catalogue.NewReader(strings.NewReader(input), format)
+	require.NoError(t, err)
+
+	var records []catalogue.Record
+	var errs []error
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return records, errs
+		}
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		records = append(records, record)
+	}
+}
+
+func TestReader(t *testing.T) {
+	brando := catalogue.Record{Type: "actor", Name: "Marlon Brando", Gender: "male", Birthdate: "1924-04-03"}
+	godfather := catalogue.Record{
+		Type:        "movie",
+		Title:       "The Godfather",
+		Description: "Crime saga, part 1",
+		ReleaseDate: "1972-03-24",
+		Rating:      9,
+		Cast:        []string{"Marlon Brando", "Al Pacino"},
+	}
+
+	cases := []struct {
+		name      string
+		format    string
+		input     string
+		records   []catalogue.Record
+		rowErrors []catalogue.RowError
+	}{
+		{
+			name:   "CSV",
+			format: catalogue.FormatCSV,
+			input: "type,name,gender,birthdate,title,description,release_date,rating,cast\n" +
+				"actor,Marlon Brando,male,1924-04-03,,,,,\n" +
+				`movie,,,,The Godfather,"Crime saga, part 1",1972-03-24,9,Marlon Brando | Al Pacino` + "\n",
+			records: []catalogue.Record{brando, godfather},
+		},
+		{
+			name:   "CSV with movie details",
+			format: catalogue.FormatCSV,
+			input: "type,title,genres,countries,runtime,original_language,certification,tags\n" +
+				"movie,Amelie,comedy|romance,FR | DE,122,fr,16+,paris\n" +
+				"movie,Amelie,,,two hours,,,\n",
+			records: []catalogue.Record{
+				{
+					Type:             "movie",
+					Title:            "Amelie",
+					Genres:           []string{"comedy", "romance"},
+					Countries:        []string{"FR", "DE"},
+					Runtime:          122,
+					OriginalLanguage: "fr",
+					Certification:    "16+",
+					Tags:             []string{"paris"},
+				},
+			},
+			rowErrors: []catalogue.RowError{
+				{Field: "runtime", Message: "field runtime is not valid"},
+			},
+		},
+		{
+			name:   "CSV with actor profile",
+			format: catalogue.FormatCSV,
+			input: "type,name,nationality,death_date,aliases,external_ids\n" +
+				"actor,Marlon Brando,US,2004-07-01,Bud,imdb:nm0000008 | wikidata:Q34012\n" +
+				"actor,Marlon Brando,,,,nm0000008\n",
+			records: []catalogue.Record{
+				{
+					Type:        "actor",
+					Name:        "Marlon Brando",
+					Nationality: "US",
+					DeathDate:   "2004-07-01",
+					Aliases:     []string{"Bud"},
+					ExternalIds: map[string]string{"imdb": "nm0000008", "wikidata": "Q34012"},
+				},
+			},
+			rowErrors: []catalogue.RowError{
+				{Field: "external_ids", Message: "field external_ids is not valid"},
+			},
+		},
+		{
+			name:   "CSV with credits",
+			format: catalogue.FormatCSV,
+			input: "type,title,credits\n" +
+				"movie,The Godfather,actor:1:Marlon Brando:Vito Corleone | director::Francis Ford Coppola\n" +
+				"movie,The Godfather,actor:first:Marlon Brando\n",
+			records: []catalogue.Record{
+				{
+					Type:  "movie",
+					Title: "The Godfather",
+					Credits: []catalogue.Credit{
+						{Name: "Marlon Brando", CreditType: "actor", Character: "Vito Corleone", BillingOrder: 1},
+						{Name: "Francis Ford Coppola", CreditType: "director"},
+					},
+				},
+			},
+			rowErrors: []catalogue.RowError{
+				{Field: "credits", Message: "field credits is not valid"},
+			},
+		},
+		{
+			name:   "CSV with columns in any order",
+			format: catalogue.FormatCSV,
+			input:  "name,type\nMarlon Brando,actor\n",
+			records: []catalogue.Record{
+				{Type: "actor", Name: "Marlon Brando"},
+			},
+		},
+		{
+			name:   "CSV with bad rows",
+			format: catalogue.FormatCSV,
+			input:  "type,title,rating\nmovie,Title,high\nmovie,Title\nmovie,Title,5\n",
+			records: []catalogue.Record{
+				{Type: "movie", Title: "Title", Rating: 5},
+			},
+			rowErrors: []catalogue.RowError{
+				{Field: "rating", Message: "field rating is not valid"},
+				{Message: "wrong number of fields"},
+			},
+		},
+		{
+			name:   "NDJSON",
+			format: catalogue.FormatNDJSON,
+			input: `{"type":"actor","name":"Marlon Brando","gender":"male","birthdate":"1924-04-03"}` + "\n\n" +
+				`{"type":"movie","rating":"high"}` + "\n" +
+				`{"type":` + "\n" +
+				`{"type":"movie","title":"The Godfather","description":"Crime saga, part 1","release_date":"1972-03-24","rating":9,"cast":["Marlon Brando","Al Pacino"]}`,
+			records: []catalogue.Record{brando, godfather},
+			rowErrors: []catalogue.RowError{
+				{Field: "rating", Message: "field rating is not valid"},
+				{Message: "invalid JSON"},
+			},
+		},
+		{
+			name:   "JSON",
+			format: catalogue.FormatJSON,
+			input: `[{"type":"actor","name":"Marlon Brando","gender":"male","birthdate":"1924-04-03"},
+				{"type":"movie","rating":"high"},
+				{"type":"movie","title":"The Godfather","description":"Crime saga, part 1","release_date":"1972-03-24","rating":9,"cast":["Marlon Brando","Al Pacino"]}]`,
+			records: []catalogue.Record{brando, godfather},
+			rowErrors: []catalogue.RowError{
+				{Field: "rating", Message: "field rating is not valid"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			records, errs := readAll(t, tc.input, tc.format)
+
+			require.Equal(t, tc.records, records)
+			require.Len(t, errs, len(tc.rowErrors))
+			for i, err := range errs {
+				var rowErr *catalogue.RowError
+				require.ErrorAs(t, err, &rowErr)
+				require.Equal(t, tc.rowErrors[i], *rowErr)
+			}
+		})
+	}
+}
+
+func TestReaderFatalErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{
+			name:   "CSV without type column",
+			format: catalogue.FormatCSV,
+			input:  "name,gender\nMarlon Brando,male\n",
+		},
+		{
+			name:   "JSON object",
+			format: catalogue.FormatJSON,
+			input:  `{"type":"actor"}`,
+		},
+		{
+			name:   "Broken JSON array",
+			format: catalogue.FormatJSON,
+			input:  `[{"type":"actor"`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			reader, err := catalogue.NewReader(strings.NewReader(tc.input), tc.format)
+			require.NoError(t, err)
+
+			_, err = reader.Read()
+			require.Error(t, err)
+
+			var rowErr *catalogue.RowError
+			require.False(t, errors.As(err, &rowErr))
+		})
+	}
+}
+
+func TestUnknownFormat(t *testing.T) {
+	_, err := catalogue.NewReader(strings.NewReader(""), "xml")
+	require.ErrorIs(t, err, catalogue.ErrUnknownFormat)
+}
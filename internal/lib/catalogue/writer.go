@@ -0,0 +1,166 @@
+package catalogue
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Writer writes records in one of the formats Reader reads. Close must be
+// called after the last record to complete the output.
+type Writer interface {
+	Write(record Record) error
+	Close() error
+}
+
+// NewWriter returns a Writer of the given format.
+func NewWriter(w io.Writer, format string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{w: csv.NewWriter(w)}, nil
+	case FormatNDJSON:
+		return &ndjsonWriter{e: json.NewEncoder(w)}, nil
+	case FormatJSON:
+		return &jsonWriter{w: w}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+}
+
+type csvWriter struct {
+	w             *csv.Writer
+	headerWritten bool
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+
+	return c.w.Write(Columns)
+}
+
+func (c *csvWriter) Write(record Record) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	rating, runtime := "", ""
+	if record.Type == TypeMovie {
+		rating = strconv.Itoa(record.Rating)
+	}
+	if record.Runtime != 0 {
+		runtime = strconv.Itoa(record.Runtime)
+	}
+
+	return c.w.Write([]string{
+		record.Type,
+		record.Name,
+		record.Gender,
+		record.Birthdate,
+		strings.Join(record.Movies, ListSeparator),
+		record.Title,
+		record.Description,
+		record.ReleaseDate,
+		rating,
+		strings.Join(record.Cast, ListSeparator),
+		strings.Join(record.Genres, ListSeparator),
+		strings.Join(record.Countries, ListSeparator),
+		runtime,
+		record.OriginalLanguage,
+		record.Certification,
+		strings.Join(record.Tags, ListSeparator),
+		record.Biography,
+		record.Birthplace,
+		record.Nationality,
+		record.DeathDate,
+		strings.Join(record.Aliases, ListSeparator),
+		strings.Join(joinExternalIds(record.ExternalIds), ListSeparator),
+		strings.Join(joinCredits(record.Credits), ListSeparator),
+	})
+}
+
+// joinCredits lists credits as parseCredit reads them.
+func joinCredits(credits []Credit) []string {
+	items := make([]string, len(credits))
+	for i, credit := range credits {
+		order := ""
+		if credit.BillingOrder != 0 {
+			order = strconv.Itoa(credit.BillingOrder)
+		}
+		items[i] = credit.CreditType + ":" + order + ":" + credit.Name
+		if credit.Character != "" {
+			items[i] += ":" + credit.Character
+		}
+	}
+	return items
+}
+
+// joinExternalIds lists ids as source:id, ordered by source.
+func joinExternalIds(external map[string]string) []string {
+	ids := make([]string, 0, len(external))
+	for source, id := range external {
+		ids = append(ids, source+":"+id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func (c *csvWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	c.w.Flush()
+	return c.w.Error()
+}
+
+type ndjsonWriter struct {
+	e *json.Encoder
+}
+
+func (n *ndjsonWriter) Write(record Record) error {
+	return n.e.Encode(record)
+}
+
+func (n *ndjsonWriter) Close() error {
+	return nil
+}
+
+// jsonWriter writes a JSON array, one record per line. The opening bracket
+// is held back until there is something to write.
+type jsonWriter struct {
+	w       io.Writer
+	started bool
+}
+
+func (j *jsonWriter) Write(record Record) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	prefix := ",\n"
+	if !j.started {
+		prefix = "[\n"
+		j.started = true
+	}
+
+	_, err = io.WriteString(j.w, prefix+string(b))
+	return err
+}
+
+func (j *jsonWriter) Close() error {
+	if !j.started {
+		_, err := io.WriteString(j.w, "[]\n")
+		return err
+	}
+
+	_, err := io.WriteString(j.w, "\n]\n")
+	return err
+}
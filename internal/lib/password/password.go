@@ -0,0 +1,68 @@
+package password
+
+import (
+	"crypto/subtle"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Cost is the bcrypt work factor for new hashes. Hashes created with a lower
+// cost are upgraded on the next successful Verify.
+const Cost = 12
+
+// MaxLength is the longest password bcrypt can hash without truncation.
+const MaxLength = 72
+
+var (
+	dummyOnce sync.Once
+	dummyHash []byte
+)
+
+func Hash(plain string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(plain), Cost)
+	if err != nil {
+		return "", err
+	}
+
+	return string(hash), nil
+}
+
+// Verify checks plain against a stored value. Rows written before hashing
+// was introduced hold the raw password; they are compared in constant time
+// and reported as needing a rehash, as are hashes with an outdated cost.
+func Verify(stored string, plain string) (ok bool, needsRehash bool) {
+	if !IsHash(stored) {
+		return subtle.ConstantTimeCompare([]byte(stored), []byte(plain)) == 1, true
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(stored), []byte(plain)) != nil {
+		return false, false
+	}
+
+	cost, err := bcrypt.Cost([]byte(stored))
+
+	return true, err != nil || cost < Cost
+}
+
+// VerifyDummy burns the same amount of time as Verify against a real hash.
+// It is used when the user does not exist so that signin timing does not
+// reveal which usernames are registered.
+func VerifyDummy(plain string) {
+	dummyOnce.Do(func() {
+		dummyHash, _ = bcrypt.GenerateFromPassword([]byte("film_library"), Cost)
+	})
+
+	_ = bcrypt.CompareHashAndPassword(dummyHash, []byte(plain))
+}
+
+func IsHash(stored string) bool {
+	if !strings.HasPrefix(stored, "$2a$") && !strings.HasPrefix(stored, "$2b$") && !strings.HasPrefix(stored, "$2y$") {
+		return false
+	}
+
+	_, err := bcrypt.Cost([]byte(stored))
+
+	return err == nil
+}
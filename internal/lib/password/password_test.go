@@ -0,0 +1,79 @@
+package password_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+
+	"film_library/internal/lib/password"
+)
+
+func TestHashVerify(t *testing.T) {
+	hash, err := password.Hash("secret")
+	require.NoError(t, err)
+	require.NotEqual(t, "secret", hash)
+	require.True(t, password.IsHash(hash))
+
+	ok, rehash := password.Verify(hash, "secret")
+	require.True(t, ok)
+	require.False(t, rehash)
+
+	ok, _ = password.Verify(hash, "Secret")
+	require.False(t, ok)
+
+	other, err := password.Hash("secret")
+	require.NoError(t, err)
+	require.NotEqual(t, hash, other, "hashes must be salted")
+}
+
+func TestVerifyLegacy(t *testing.T) {
+	cases := []struct {
+		name        string
+		stored      string
+		plain       string
+		ok          bool
+		needsRehash bool
+	}{
+		{
+			name:        "Plaintext match",
+			stored:      "admin",
+			plain:       "admin",
+			ok:          true,
+			needsRehash: true,
+		},
+		{
+			name:        "Plaintext mismatch",
+			stored:      "admin",
+			plain:       "admin1",
+			ok:          false,
+			needsRehash: true,
+		},
+		{
+			name:        "Plaintext that looks like a hash prefix",
+			stored:      "$2a$oops",
+			plain:       "$2a$oops",
+			ok:          true,
+			needsRehash: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			ok, needsRehash := password.Verify(tc.stored, tc.plain)
+			require.Equal(t, tc.ok, ok)
+			require.Equal(t, tc.needsRehash, needsRehash)
+		})
+	}
+}
+
+func TestVerifyOutdatedCost(t *testing.T) {
+	weak, err := bcrypt.GenerateFromPassword([]byte("secret"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	ok, rehash := password.Verify(string(weak), "secret")
+	require.True(t, ok)
+	require.True(t, rehash)
+}
@@ -0,0 +1,140 @@
+package token
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/lestrrat-go/jwx/v2/jwt"
+)
+
+const (
+	ClaimUserId      = "user_id"
+	ClaimRoles       = "roles"
+	ClaimPermissions = "permissions"
+)
+
+type Manager struct {
+	auth       *jwtauth.JWTAuth
+	issuer     string
+	accessTTL  time.Duration
+	refreshTTL time.Duration
+}
+
+// New returns a Manager issuing HS256 access tokens. Tokens it verifies
+// must carry exp, iat and jti claims and the configured issuer, so tokens
+// minted before expiry was introduced are rejected.
+func New(secret string, issuer string, accessTTL time.Duration, refreshTTL time.Duration) *Manager {
+	auth := jwtauth.New("HS256", []byte(secret), nil,
+		jwt.WithIssuer(issuer),
+		jwt.WithRequiredClaim(jwt.ExpirationKey),
+		jwt.WithRequiredClaim(jwt.IssuedAtKey),
+		jwt.WithRequiredClaim(jwt.JwtIDKey),
+	)
+
+	return &Manager{
+		auth:       auth,
+		issuer:     issuer,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
+	}
+}
+
+func (m *Manager) JWTAuth() *jwtauth.JWTAuth {
+	return m.auth
+}
+
+func (m *Manager) AccessTTL() time.Duration {
+	return m.accessTTL
+}
+
+// IssueAccess signs an access token carrying the user's roles and
+// permissions, so authorization does not hit the database per request.
+// Role changes therefore take effect when the token is next refreshed.
+func (m *Manager) IssueAccess(userId int, roles []string, permissions []string) (string, error) {
+	jti, err := randomString(16)
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+
+	_, tokenString, err := m.auth.Encode(map[string]interface{}{
+		ClaimUserId:       userId,
+		ClaimRoles:        roles,
+		ClaimPermissions:  permissions,
+		jwt.SubjectKey:    strconv.Itoa(userId),
+		jwt.IssuerKey:     m.issuer,
+		jwt.IssuedAtKey:   now.Unix(),
+		jwt.ExpirationKey: now.Add(m.accessTTL).Unix(),
+		jwt.JwtIDKey:      jti,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return tokenString, nil
+}
+
+// NewRefresh returns an opaque refresh token for the client together with
+// the hash that is stored server side and its expiry.
+func (m *Manager) NewRefresh() (string, string, time.Time, error) {
+	refreshToken, err := randomString(32)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+
+	return refreshToken, HashRefresh(refreshToken), time.Now().Add(m.refreshTTL), nil
+}
+
+func HashRefresh(refreshToken string) string {
+	sum := sha256.Sum256([]byte(refreshToken))
+
+	return hex.EncodeToString(sum[:])
+}
+
+func UserId(claims map[string]interface{}) (int, bool) {
+	userId, ok := claims[ClaimUserId].(float64)
+	if !ok {
+		return 0, false
+	}
+
+	return int(userId), true
+}
+
+func Roles(claims map[string]interface{}) []string {
+	return stringSlice(claims[ClaimRoles])
+}
+
+func Permissions(claims map[string]interface{}) []string {
+	return stringSlice(claims[ClaimPermissions])
+}
+
+func stringSlice(v interface{}) []string {
+	items, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	res := make([]string, 0, len(items))
+	for _, item := range items {
+		if s, ok := item.(string); ok {
+			res = append(res, s)
+		}
+	}
+
+	return res
+}
+
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,94 @@
+package token_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-chi/jwtauth/v5"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/token"
+)
+
+func TestIssueAccess(t *testing.T) {
+	tokens := token.New("secret", "film_library", time.Minute, time.Hour)
+
+	tokenString, err := tokens.IssueAccess(42, []string{"editor"}, []string{"movie:write", "actor:write"})
+	require.NoError(t, err)
+
+	parsed, err := jwtauth.VerifyToken(tokens.JWTAuth(), tokenString)
+	require.NoError(t, err)
+	require.NotEmpty(t, parsed.JwtID())
+	require.Equal(t, "film_library", parsed.Issuer())
+	require.WithinDuration(t, time.Now().Add(time.Minute), parsed.Expiration(), 2*time.Second)
+
+	claims, err := parsed.AsMap(context.Background())
+	require.NoError(t, err)
+
+	userId, ok := token.UserId(claims)
+	require.True(t, ok)
+	require.Equal(t, 42, userId)
+	require.Equal(t, []string{"editor"}, token.Roles(claims))
+	require.Equal(t, []string{"movie:write", "actor:write"}, token.Permissions(claims))
+}
+
+func TestVerifyRejects(t *testing.T) {
+	tokens := token.New("secret", "film_library", time.Minute, time.Hour)
+
+	cases := []struct {
+		name   string
+		issuer *token.Manager
+		legacy bool
+	}{
+		{
+			name:   "Expired",
+			issuer: token.New("secret", "film_library", -time.Minute, time.Hour),
+		},
+		{
+			name:   "Other issuer",
+			issuer: token.New("secret", "someone_else", time.Minute, time.Hour),
+		},
+		{
+			name:   "Other secret",
+			issuer: token.New("other", "film_library", time.Minute, time.Hour),
+		},
+		{
+			name:   "Legacy token without exp",
+			legacy: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var tokenString string
+			var err error
+			if tc.legacy {
+				_, tokenString, err = jwtauth.New("HS256", []byte("secret"), nil).
+					Encode(map[string]interface{}{"user_id": 1})
+			} else {
+				tokenString, err = tc.issuer.IssueAccess(1, nil, nil)
+			}
+			require.NoError(t, err)
+
+			_, err = jwtauth.VerifyToken(tokens.JWTAuth(), tokenString)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestNewRefresh(t *testing.T) {
+	tokens := token.New("secret", "film_library", time.Minute, time.Hour)
+
+	first, hash, expiresAt, err := tokens.NewRefresh()
+	require.NoError(t, err)
+	require.Equal(t, token.HashRefresh(first), hash)
+	require.NotEqual(t, first, hash)
+	require.WithinDuration(t, time.Now().Add(time.Hour), expiresAt, 2*time.Second)
+
+	second, _, _, err := tokens.NewRefresh()
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+}
@@ -0,0 +1,329 @@
+package migrator
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+)
+
+// lockKey identifies the film_library migration lock among other
+// pg_advisory_lock users of the same database.
+const lockKey int64 = 0x66696c6d6c6962
+
+var fileNameRe = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var (
+	ErrChecksumMismatch = errors.New("applied migration checksum does not match migration file")
+	ErrUnknownVersion   = errors.New("database contains migration that is not known to this build")
+)
+
+type Migration struct {
+	Version  int64
+	Name     string
+	Up       string
+	Down     string
+	Checksum string
+}
+
+type Status struct {
+	Version   int64
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	const op = "storage.migrator.New"
+
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load reads <version>_<name>.up.sql / <version>_<name>.down.sql pairs from
+// the root of fsys and returns them ordered by version.
+func Load(fsys fs.FS) ([]Migration, error) {
+	const op = "storage.migrator.Load"
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		match := fileNameRe.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("%s: invalid version in %s", op, entry.Name())
+		}
+
+		body, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("%s: version %d is used by %s and %s", op, version, m.Name, match[2])
+		}
+
+		switch match[3] {
+		case "up":
+			m.Up = string(body)
+			sum := sha256.Sum256(body)
+			m.Checksum = hex.EncodeToString(sum[:])
+		case "down":
+			m.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("%s: migration %d_%s must have both up and down files", op, m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// Up applies every pending migration, each in its own transaction, and
+// returns the ones that were applied.
+func (m *Migrator) Up() ([]Migration, error) {
+	const op = "storage.migrator.Up"
+
+	var applied []Migration
+
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			if _, ok := done[migration.Version]; ok {
+				continue
+			}
+
+			err = inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Up); err != nil {
+					return err
+				}
+
+				_, err := tx.ExecContext(ctx,
+					"INSERT INTO schema_migrations(version, name, checksum) VALUES ($1, $2, $3)",
+					migration.Version, migration.Name, migration.Checksum)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			applied = append(applied, migration)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return applied, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return applied, nil
+}
+
+// Down rolls back the most recently applied migration. It returns nil if
+// there is nothing to roll back.
+func (m *Migrator) Down() (*Migration, error) {
+	const op = "storage.migrator.Down"
+
+	var rolledBack *Migration
+
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for i := len(m.migrations) - 1; i >= 0; i-- {
+			migration := m.migrations[i]
+			if _, ok := done[migration.Version]; !ok {
+				continue
+			}
+
+			err = inTx(ctx, conn, func(tx *sql.Tx) error {
+				if _, err := tx.ExecContext(ctx, migration.Down); err != nil {
+					return err
+				}
+
+				_, err := tx.ExecContext(ctx, "DELETE FROM schema_migrations WHERE version=$1", migration.Version)
+				return err
+			})
+			if err != nil {
+				return fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+			}
+
+			rolledBack = &migration
+
+			return nil
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return rolledBack, nil
+}
+
+func (m *Migrator) Status() ([]Status, error) {
+	const op = "storage.migrator.Status"
+
+	var statuses []Status
+
+	err := m.withLock(func(ctx context.Context, conn *sql.Conn) error {
+		done, err := m.applied(ctx, conn)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range m.migrations {
+			status := Status{Version: migration.Version, Name: migration.Name}
+			if row, ok := done[migration.Version]; ok {
+				status.Applied = true
+				status.AppliedAt = row.appliedAt
+			}
+			statuses = append(statuses, status)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return statuses, nil
+}
+
+type appliedRow struct {
+	checksum  string
+	appliedAt time.Time
+}
+
+// applied returns the migrations recorded in schema_migrations and makes
+// sure they still match the embedded files.
+func (m *Migrator) applied(ctx context.Context, conn *sql.Conn) (map[int64]appliedRow, error) {
+	_, err := conn.ExecContext(ctx, `
+	CREATE TABLE IF NOT EXISTS schema_migrations(
+	    version BIGINT PRIMARY KEY,
+	    name VARCHAR(255) NOT NULL,
+	    checksum CHAR(64) NOT NULL,
+	    applied_at TIMESTAMPTZ NOT NULL DEFAULT now());
+	`)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := conn.QueryContext(ctx, "SELECT version, checksum, applied_at FROM schema_migrations")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	done := make(map[int64]appliedRow)
+	for rows.Next() {
+		var version int64
+		var row appliedRow
+		if err := rows.Scan(&version, &row.checksum, &row.appliedAt); err != nil {
+			return nil, err
+		}
+		done[version] = row
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	known := make(map[int64]Migration, len(m.migrations))
+	for _, migration := range m.migrations {
+		known[migration.Version] = migration
+	}
+
+	for version, row := range done {
+		migration, ok := known[version]
+		if !ok {
+			return nil, fmt.Errorf("version %d: %w", version, ErrUnknownVersion)
+		}
+		if migration.Checksum != row.checksum {
+			return nil, fmt.Errorf("migration %d_%s: %w", version, migration.Name, ErrChecksumMismatch)
+		}
+	}
+
+	return done, nil
+}
+
+// withLock runs fn on a single connection holding the migration advisory
+// lock, so replicas starting at the same time apply migrations one by one.
+func (m *Migrator) withLock(fn func(ctx context.Context, conn *sql.Conn) error) error {
+	ctx := context.Background()
+
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.ExecContext(ctx, "SELECT pg_advisory_lock($1)", lockKey); err != nil {
+		return err
+	}
+	defer conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", lockKey)
+
+	return fn(ctx, conn)
+}
+
+func inTx(ctx context.Context, conn *sql.Conn, fn func(tx *sql.Tx) error) error {
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,107 @@
+package migrator_test
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage/migrator"
+	"film_library/internal/storage/postgres/migrations"
+)
+
+func TestLoad(t *testing.T) {
+	cases := []struct {
+		name     string
+		fs       fstest.MapFS
+		versions []int64
+		wantErr  bool
+	}{
+		{
+			name: "Success",
+			fs: fstest.MapFS{
+				"0002_add_index.up.sql":   {Data: []byte("CREATE INDEX a ON b(c);")},
+				"0002_add_index.down.sql": {Data: []byte("DROP INDEX a;")},
+				"0001_init.up.sql":        {Data: []byte("CREATE TABLE b(c INT);")},
+				"0001_init.down.sql":      {Data: []byte("DROP TABLE b;")},
+				"README.md":               {Data: []byte("not a migration")},
+			},
+			versions: []int64{1, 2},
+		},
+		{
+			name: "Missing down",
+			fs: fstest.MapFS{
+				"0001_init.up.sql": {Data: []byte("CREATE TABLE b(c INT);")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Duplicate version",
+			fs: fstest.MapFS{
+				"0001_init.up.sql":    {Data: []byte("CREATE TABLE b(c INT);")},
+				"0001_init.down.sql":  {Data: []byte("DROP TABLE b;")},
+				"0001_other.up.sql":   {Data: []byte("CREATE TABLE d(c INT);")},
+				"0001_other.down.sql": {Data: []byte("DROP TABLE d;")},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Zero version",
+			fs: fstest.MapFS{
+				"0000_init.up.sql":   {Data: []byte("CREATE TABLE b(c INT);")},
+				"0000_init.down.sql": {Data: []byte("DROP TABLE b;")},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			loaded, err := migrator.Load(tc.fs)
+			if tc.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var versions []int64
+			for _, m := range loaded {
+				require.Len(t, m.Checksum, 64)
+				versions = append(versions, m.Version)
+			}
+			require.Equal(t, tc.versions, versions)
+		})
+	}
+}
+
+func TestChecksumStable(t *testing.T) {
+	fs := fstest.MapFS{
+		"0001_init.up.sql":   {Data: []byte("CREATE TABLE b(c INT);")},
+		"0001_init.down.sql": {Data: []byte("DROP TABLE b;")},
+	}
+
+	first, err := migrator.Load(fs)
+	require.NoError(t, err)
+
+	fs["0001_init.down.sql"] = &fstest.MapFile{Data: []byte("DROP TABLE IF EXISTS b;")}
+	second, err := migrator.Load(fs)
+	require.NoError(t, err)
+	require.Equal(t, first[0].Checksum, second[0].Checksum)
+
+	fs["0001_init.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE b(c BIGINT);")}
+	third, err := migrator.Load(fs)
+	require.NoError(t, err)
+	require.NotEqual(t, first[0].Checksum, third[0].Checksum)
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	loaded, err := migrator.Load(migrations.FS)
+	require.NoError(t, err)
+	require.NotEmpty(t, loaded)
+
+	for i, m := range loaded {
+		require.Equal(t, int64(i+1), m.Version, "migration versions must be contiguous")
+	}
+}
@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// loadActors fills Actors of every movie with a single query.
+func (s *Storage) loadActors(movies []Movie) error {
+	ids := make([]int, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.Id
+	}
+
+	actors, err := s.actorIds(ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range movies {
+		movies[i].Actors = actors[movies[i].Id]
+	}
+
+	return nil
+}
+
+// actorIds returns the ids of the actors cast in every given movie. The
+// crew is left to Movie.Crew.
+func (s *Storage) actorIds(movieIds []int) (map[int][]int, error) {
+	actors, err := associations[int](s.Db, `SELECT DISTINCT am.movie_id, am.actor_id FROM actor_movie am
+										  JOIN actors a ON a.actor_id = am.actor_id
+										  WHERE am.movie_id = ANY($1) AND am.credit_type = 'actor' AND a.deleted_at IS NULL
+										  ORDER BY am.movie_id, am.actor_id`, movieIds)
+	if err != nil {
+		return nil, fmt.Errorf("load actors: %w", err)
+	}
+
+	return actors, nil
+}
+
+// loadMovies fills Movies of every actor with a single query.
+func (s *Storage) loadMovies(actors []Actor) error {
+	ids := make([]int, len(actors))
+	for i, actor := range actors {
+		ids[i] = actor.Id
+	}
+
+	movies, err := associations[int](s.Db, `SELECT DISTINCT am.actor_id, am.movie_id FROM actor_movie am
+										  JOIN movies m ON m.movie_id = am.movie_id
+										  WHERE am.actor_id = ANY($1) AND m.deleted_at IS NULL
+										  ORDER BY am.actor_id, am.movie_id`, ids)
+	if err != nil {
+		return fmt.Errorf("load movies: %w", err)
+	}
+
+	for i := range actors {
+		actors[i].Movies = movies[actors[i].Id]
+	}
+
+	return nil
+}
+
+// associations runs a query returning (owner id, associated value) pairs
+// for the given owner ids and groups them by owner.
+func associations[T any](db *sql.DB, query string, ids []int) (map[int][]T, error) {
+	res := make(map[int][]T, len(ids))
+	if len(ids) == 0 {
+		return res, nil
+	}
+
+	rows, err := db.Query(query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var ownerId int
+		var value T
+		if err = rows.Scan(&ownerId, &value); err != nil {
+			return nil, err
+		}
+		res[ownerId] = append(res[ownerId], value)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
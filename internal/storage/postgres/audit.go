@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type AuditEvent struct {
+	Id         int64           `json:"event_id"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	UserId     *int            `json:"user_id"`
+	Action     string          `json:"action"`
+	EntityType string          `json:"entity_type"`
+	EntityId   int             `json:"entity_id"`
+	Before     json.RawMessage `json:"before" swaggertype:"object"`
+	After      json.RawMessage `json:"after" swaggertype:"object"`
+	RequestId  *string         `json:"request_id"`
+}
+
+// AuditFilter selects audit events. Zero fields match every event; From is
+// inclusive and To exclusive.
+type AuditFilter struct {
+	EntityType string
+	EntityId   int
+	UserId     int
+	From       time.Time
+	To         time.Time
+}
+
+const auditOrdering = "event_id_desc"
+
+// GetAuditEvents returns a page of the audit events matching filter, most
+// recent first.
+func (s *Storage) GetAuditEvents(filter AuditFilter, page Page) ([]AuditEvent, PageInfo, error) {
+	const op = "storage.postgres.GetAuditEvents"
+
+	var b queryBuilder
+	if filter.EntityType != "" {
+		b.where("entity_type = ?", filter.EntityType)
+	}
+	if filter.EntityId != 0 {
+		b.where("entity_id = ?", filter.EntityId)
+	}
+	if filter.UserId != 0 {
+		b.where("user_id = ?", filter.UserId)
+	}
+	if !filter.From.IsZero() {
+		b.where("occurred_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		b.where("occurred_at < ?", filter.To)
+	}
+
+	// the total counts every match, not only what follows the cursor
+	count := b.snapshot()
+
+	if page.Cursor != "" {
+		values, err := decodeCursor(page.Cursor, auditOrdering, 1)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		b.where("event_id < ?", values[0])
+	}
+	limit := b.arg(page.limit() + 1)
+
+	rows, err := s.Db.Query(`SELECT event_id, occurred_at, user_id, action, entity_type, entity_id, before, after, request_id
+								   FROM audit_events
+								   `+b.whereClause()+`
+								   ORDER BY event_id DESC
+								   LIMIT `+limit, b.args...)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		var event AuditEvent
+		// a nil json.RawMessage, from a NULL column, is encoded as null
+		err = rows.Scan(&event.Id, &event.OccurredAt, &event.UserId, &event.Action, &event.EntityType, &event.EntityId,
+			(*[]byte)(&event.Before), (*[]byte)(&event.After), &event.RequestId)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		events = append(events, event)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var info PageInfo
+	if len(events) > page.limit() {
+		events = events[:page.limit()]
+		info.NextCursor, err = encodeCursor(auditOrdering, events[len(events)-1].Id)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if page.WithTotal {
+		info.Total, err = s.count("SELECT count(*) FROM audit_events "+count.whereClause(), count.args...)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return events, info, nil
+}
@@ -0,0 +1,92 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/audit"
+	"film_library/internal/storage/postgres"
+)
+
+func TestAuditEvents(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.EnsureDefaultRoles())
+
+	ctx := audit.NewContext(context.Background(), audit.Actor{UserId: 42, RequestId: "host/1"})
+
+	actorId, err := s.SaveActor(ctx, "Actor", "male", "1980-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	movieId, err := s.SaveMovie(ctx, "Movie", "Description", "2000-01-01", 5, []int{actorId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	rating := 7
+	_, err = s.UpdateMovie(ctx, movieId, postgres.MoviePatch{Rating: &rating}, postgres.AnyVersion)
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteMovie(ctx, movieId, postgres.AnyVersion))
+
+	events, _, err := s.GetAuditEvents(postgres.AuditFilter{EntityType: "movie", EntityId: movieId}, postgres.Page{})
+	require.NoError(t, err)
+
+	actions := make([]string, len(events))
+	for i, event := range events {
+		actions[i] = event.Action
+		require.Equal(t, 42, *event.UserId)
+		require.Equal(t, "host/1", *event.RequestId)
+	}
+	require.Equal(t, []string{"movie.delete", "movie.update", "movie.cast_add", "movie.create"}, actions)
+
+	// updates keep only what changed
+	require.JSONEq(t, `{"deleted_at": null}`, string(events[0].Before))
+	require.JSONEq(t, `{"rating": 5}`, string(events[1].Before))
+	require.JSONEq(t, `{"rating": 7}`, string(events[1].After))
+	require.Nil(t, events[3].Before)
+
+	// the version bump of a cast change is not a change of the actor
+	events, info, err := s.GetAuditEvents(postgres.AuditFilter{EntityType: "actor", EntityId: actorId},
+		postgres.Page{WithTotal: true})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, "actor.create", events[0].Action)
+	require.Equal(t, 1, *info.Total)
+
+	events, _, err = s.GetAuditEvents(postgres.AuditFilter{UserId: 43}, postgres.Page{})
+	require.NoError(t, err)
+	require.Empty(t, events)
+}
+
+func TestAuditUserEvents(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.EnsureDefaultRoles())
+
+	require.NoError(t, s.SaveUser(context.Background(), "user", "password"))
+	require.NoError(t, s.ChangePassword(context.Background(), "user", "password", "new password"))
+
+	events, _, err := s.GetAuditEvents(postgres.AuditFilter{EntityType: "user"}, postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, events, 3)
+
+	require.Equal(t, "user.update", events[0].Action)
+	require.JSONEq(t, `{"password": "changed"}`, string(events[0].After))
+	require.Equal(t, "user.role_grant", events[1].Action)
+	require.Contains(t, string(events[1].After), `"role": "user"`)
+	require.Equal(t, "user.create", events[2].Action)
+	require.NotContains(t, string(events[2].After), "password")
+	require.Nil(t, events[2].UserId)
+}
+
+func TestAuditEventsAppendOnly(t *testing.T) {
+	s := newTestStorage(t)
+
+	_, err := s.SaveActor(context.Background(), "Actor", "male", "1980-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	_, err = s.Db.Exec("UPDATE audit_events SET action = 'actor.delete'")
+	require.Error(t, err)
+
+	_, err = s.Db.Exec("DELETE FROM audit_events")
+	require.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestConstraints(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.EnsureDefaultRoles())
+
+	require.NoError(t, s.SaveUser(context.Background(), "user", "password"))
+	require.ErrorIs(t, s.SaveUser(context.Background(), "user", "password"), storage.ErrUserExists)
+
+	_, err := s.SaveActor(context.Background(), "Actor", "robot", "1980-01-01", postgres.ActorProfile{})
+	require.ErrorIs(t, err, storage.ErrInvalidValue)
+
+	_, err = s.SaveMovie(context.Background(), "Movie", "Description", "2000-01-01", 11, nil, postgres.MovieDetails{})
+	require.ErrorIs(t, err, storage.ErrInvalidValue)
+
+	var constraintErr *storage.ConstraintError
+	require.ErrorAs(t, err, &constraintErr)
+	require.Equal(t, "rating", constraintErr.Field)
+
+	// lists page by the dates, so they can not be missing
+	_, err = s.Db.Exec("INSERT INTO movies(title, description, rating) VALUES ('Undated', '', 5)")
+	require.Error(t, err)
+	_, err = s.Db.Exec("INSERT INTO actors(name, gender) VALUES ('Ageless', 'male')")
+	require.Error(t, err)
+
+	actorId, err := s.SaveActor(context.Background(), "Actor", "male", "1980-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+	movieId, err := s.SaveMovie(context.Background(), "Movie", "Description", "2000-01-01", 5, []int{actorId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	// adding an actor twice keeps a single link
+	require.NoError(t, s.SaveActorMovie(context.Background(), movieId, []postgres.Credit{{ActorId: actorId, CreditType: postgres.CreditActor}}))
+	actors, err := s.GetActorsByMovie(movieId)
+	require.NoError(t, err)
+	require.Equal(t, []int{actorId}, actors)
+
+	err = s.SaveActorMovie(context.Background(), movieId, []postgres.Credit{{ActorId: actorId + 1, CreditType: postgres.CreditActor}})
+	require.ErrorIs(t, err, storage.ErrForeignKey)
+	require.ErrorAs(t, err, &constraintErr)
+	require.Equal(t, "actor_id", constraintErr.Field)
+}
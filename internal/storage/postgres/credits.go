@@ -0,0 +1,116 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+const (
+	CreditActor    = "actor"
+	CreditDirector = "director"
+	CreditWriter   = "writer"
+	CreditProducer = "producer"
+	CreditComposer = "composer"
+)
+
+// CreditTypes lists the credit types in the order crew is listed.
+var CreditTypes = []string{CreditActor, CreditDirector, CreditWriter, CreditProducer, CreditComposer}
+
+// Credit is the part a person has in a movie. Only actors play a
+// Character. BillingOrder ranks the credits of a type, 0 means unranked.
+// Name is read only.
+type Credit struct {
+	ActorId      int    `json:"actor_id"`
+	Name         string `json:"name"`
+	CreditType   string `json:"credit_type"`
+	Character    string `json:"character,omitempty"`
+	BillingOrder int    `json:"billing_order,omitempty"`
+}
+
+// actorCredits turns a cast given by actor ids into unranked acting credits.
+func actorCredits(actorsIds []int) []Credit {
+	credits := make([]Credit, len(actorsIds))
+	for i, actorId := range actorsIds {
+		credits[i] = Credit{ActorId: actorId, CreditType: CreditActor}
+	}
+	return credits
+}
+
+func saveCredits(tx *sql.Tx, movieId int, credits []Credit) error {
+	_, err := addCredits(tx, movieId, credits)
+	return err
+}
+
+// addCredits saves credits like saveCredits and reports whether any was
+// new or changed.
+func addCredits(tx *sql.Tx, movieId int, credits []Credit) (bool, error) {
+	// adding a credit again is a no-op, unless it sets a character or a
+	// billing order, which replace the current ones
+	stmt, err := tx.Prepare(`INSERT INTO actor_movie(movie_id, actor_id, credit_type, character_name, billing_order)
+								   VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, 0))
+								   ON CONFLICT (movie_id, actor_id, credit_type) DO UPDATE SET
+								   character_name = COALESCE(EXCLUDED.character_name, actor_movie.character_name),
+								   billing_order = COALESCE(EXCLUDED.billing_order, actor_movie.billing_order)
+								   WHERE (EXCLUDED.character_name IS NOT NULL
+								   AND EXCLUDED.character_name IS DISTINCT FROM actor_movie.character_name)
+								   OR (EXCLUDED.billing_order IS NOT NULL
+								   AND EXCLUDED.billing_order IS DISTINCT FROM actor_movie.billing_order)`)
+	if err != nil {
+		return false, err
+	}
+	defer stmt.Close()
+
+	var changed int64
+	for _, credit := range credits {
+		res, err := stmt.Exec(movieId, credit.ActorId, credit.CreditType, credit.Character, credit.BillingOrder)
+		if err != nil {
+			return false, mapError(err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		changed += n
+	}
+
+	return changed > 0, nil
+}
+
+// GetCredits returns the cast of a movie by billing order and then its
+// crew by credit type and billing order. Unranked credits come last, by
+// name.
+func (s *Storage) GetCredits(movieId int) ([]Credit, []Credit, error) {
+	const op = "storage.postgres.GetCredits"
+
+	rows, err := s.Db.Query(`SELECT am.actor_id, a.name, am.credit_type,
+								   COALESCE(am.character_name, ''), COALESCE(am.billing_order, 0)
+								   FROM actor_movie am
+								   JOIN actors a ON a.actor_id = am.actor_id
+								   WHERE am.movie_id=$1 AND a.deleted_at IS NULL
+								   ORDER BY array_position($2::text[], am.credit_type::text),
+								   am.billing_order NULLS LAST, a.name, am.actor_id`, movieId, CreditTypes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	cast, crew := []Credit{}, []Credit{}
+	for rows.Next() {
+		var credit Credit
+		err = rows.Scan(&credit.ActorId, &credit.Name, &credit.CreditType, &credit.Character, &credit.BillingOrder)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+
+		if credit.CreditType == CreditActor {
+			cast = append(cast, credit)
+		} else {
+			crew = append(crew, credit)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return cast, crew, nil
+}
@@ -0,0 +1,64 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestCredits(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	pacinoId, err := s.SaveActor(ctx, "Al Pacino", "male", "1940-04-25", postgres.ActorProfile{})
+	require.NoError(t, err)
+	brandoId, err := s.SaveActor(ctx, "Marlon Brando", "male", "1924-04-03", postgres.ActorProfile{})
+	require.NoError(t, err)
+	coppolaId, err := s.SaveActor(ctx, "Francis Ford Coppola", "male", "1939-04-07", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	movieId, err := s.SaveMovie(ctx, "The Godfather", "", "1972-03-24", 9, []int{pacinoId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	require.NoError(t, s.SaveActorMovie(ctx, movieId, []postgres.Credit{
+		{ActorId: brandoId, CreditType: postgres.CreditActor, Character: "Vito Corleone", BillingOrder: 1},
+		{ActorId: coppolaId, CreditType: postgres.CreditWriter},
+		{ActorId: coppolaId, CreditType: postgres.CreditDirector, BillingOrder: 1},
+		// adding a credit again sets only what is given
+		{ActorId: pacinoId, CreditType: postgres.CreditActor, BillingOrder: 2},
+		{ActorId: pacinoId, CreditType: postgres.CreditActor, Character: "Michael Corleone"},
+	}))
+
+	movie, err := s.GetMovie(movieId)
+	require.NoError(t, err)
+	require.Equal(t, []postgres.Credit{
+		{ActorId: brandoId, Name: "Marlon Brando", CreditType: "actor", Character: "Vito Corleone", BillingOrder: 1},
+		{ActorId: pacinoId, Name: "Al Pacino", CreditType: "actor", Character: "Michael Corleone", BillingOrder: 2},
+	}, movie.Cast)
+	require.Equal(t, []postgres.Credit{
+		{ActorId: coppolaId, Name: "Francis Ford Coppola", CreditType: "director", BillingOrder: 1},
+		{ActorId: coppolaId, Name: "Francis Ford Coppola", CreditType: "writer"},
+	}, movie.Crew)
+	// actors stays the cast only
+	require.ElementsMatch(t, []int{pacinoId, brandoId}, movie.Actors)
+
+	coppola, err := s.GetActor(coppolaId)
+	require.NoError(t, err)
+	require.Equal(t, []int{movieId}, coppola.Movies)
+
+	err = s.SaveActorMovie(ctx, movieId, []postgres.Credit{{ActorId: coppolaId, CreditType: postgres.CreditDirector, Character: "Himself"}})
+	require.ErrorIs(t, err, storage.ErrInvalidValue)
+	var constraintErr *storage.ConstraintError
+	require.ErrorAs(t, err, &constraintErr)
+	require.Equal(t, "character", constraintErr.Field)
+
+	// removing a person drops all their credits
+	require.NoError(t, s.DeleteActorMovie(ctx, movieId, []int{coppolaId}))
+	movie, err = s.GetMovie(movieId)
+	require.NoError(t, err)
+	require.Empty(t, movie.Crew)
+}
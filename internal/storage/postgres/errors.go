@@ -0,0 +1,73 @@
+package postgres
+
+import (
+	"errors"
+	"film_library/internal/storage"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Postgres error codes, see https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	codeNotNullViolation    = "23502"
+	codeForeignKeyViolation = "23503"
+	codeUniqueViolation     = "23505"
+	codeCheckViolation      = "23514"
+)
+
+// constraints maps the named constraints of the schema to the request
+// field they guard and the storage error reported when they are violated.
+var constraints = map[string]storage.ConstraintError{
+	"users_username_key":        {Field: "username", Err: storage.ErrUserExists},
+	"actor_movie_pkey":          {Field: "actor_id", Err: storage.ErrConflict},
+	"actor_movie_movie_id_fkey": {Field: "movie_id", Err: storage.ErrForeignKey},
+	"actor_movie_actor_id_fkey": {Field: "actor_id", Err: storage.ErrForeignKey},
+	"movies_rating_check":       {Field: "rating", Err: storage.ErrInvalidValue},
+	"actors_gender_check":       {Field: "gender", Err: storage.ErrInvalidValue},
+
+	"movies_runtime_check":            {Field: "runtime", Err: storage.ErrInvalidValue},
+	"movies_original_language_fkey":   {Field: "original_language", Err: storage.ErrForeignKey},
+	"movies_certification_fkey":       {Field: "certification", Err: storage.ErrForeignKey},
+	"movie_genre_genre_name_fkey":     {Field: "genres", Err: storage.ErrForeignKey},
+	"movie_country_country_code_fkey": {Field: "countries", Err: storage.ErrForeignKey},
+
+	"actor_movie_credit_type_check":    {Field: "credit_type", Err: storage.ErrInvalidValue},
+	"actor_movie_character_name_check": {Field: "character", Err: storage.ErrInvalidValue},
+	"actor_movie_billing_order_check":  {Field: "billing_order", Err: storage.ErrInvalidValue},
+
+	"actors_nationality_fkey":                   {Field: "nationality", Err: storage.ErrForeignKey},
+	"actors_death_date_check":                   {Field: "death_date", Err: storage.ErrInvalidValue},
+	"actor_external_ids_source_check":           {Field: "external_ids", Err: storage.ErrInvalidValue},
+	"actor_external_ids_source_external_id_key": {Field: "external_ids", Err: storage.ErrConflict},
+
+	"reviews_movie_id_user_id_key": {Field: "movie_id", Err: storage.ErrConflict},
+	"reviews_score_check":          {Field: "score", Err: storage.ErrInvalidValue},
+	"reviews_status_check":         {Field: "status", Err: storage.ErrInvalidValue},
+
+	"user_lists_user_id_name_key": {Field: "name", Err: storage.ErrConflict},
+	"user_lists_name_check":       {Field: "name", Err: storage.ErrInvalidValue},
+}
+
+// mapError wraps err with the storage error matching its constraint or
+// Postgres error code, keeping the original error in the chain for logging.
+func mapError(err error) error {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	if c, ok := constraints[pgErr.ConstraintName]; ok {
+		return fmt.Errorf("%w: %w", &c, err)
+	}
+
+	switch pgErr.Code {
+	case codeUniqueViolation:
+		return fmt.Errorf("%w: %w", storage.ErrConflict, err)
+	case codeForeignKeyViolation:
+		return fmt.Errorf("%w: %w", storage.ErrForeignKey, err)
+	case codeCheckViolation, codeNotNullViolation:
+		return fmt.Errorf("%w: %w", storage.ErrInvalidValue, err)
+	}
+
+	return err
+}
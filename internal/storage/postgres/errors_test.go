@@ -0,0 +1,86 @@
+package postgres
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+)
+
+func TestMapError(t *testing.T) {
+	cases := []struct {
+		name  string
+		err   error
+		want  error
+		field string
+	}{
+		{
+			name:  "Username taken",
+			err:   &pgconn.PgError{Code: codeUniqueViolation, ConstraintName: "users_username_key"},
+			want:  storage.ErrUserExists,
+			field: "username",
+		},
+		{
+			name:  "Missing actor",
+			err:   &pgconn.PgError{Code: codeForeignKeyViolation, ConstraintName: "actor_movie_actor_id_fkey"},
+			want:  storage.ErrForeignKey,
+			field: "actor_id",
+		},
+		{
+			name:  "Rating out of range",
+			err:   &pgconn.PgError{Code: codeCheckViolation, ConstraintName: "movies_rating_check"},
+			want:  storage.ErrInvalidValue,
+			field: "rating",
+		},
+		{
+			name: "Unknown check",
+			err:  &pgconn.PgError{Code: codeCheckViolation, ConstraintName: "other_check"},
+			want: storage.ErrInvalidValue,
+		},
+		{
+			name: "Unique violation",
+			err:  &pgconn.PgError{Code: codeUniqueViolation},
+			want: storage.ErrConflict,
+		},
+		{
+			name: "Foreign key violation",
+			err:  fmt.Errorf("exec: %w", &pgconn.PgError{Code: codeForeignKeyViolation}),
+			want: storage.ErrForeignKey,
+		},
+		{
+			name: "Other Postgres error",
+			err:  &pgconn.PgError{Code: "42P01"},
+		},
+		{
+			name: "Not a Postgres error",
+			err:  errors.New("unexpected error"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mapError(tc.err)
+			require.ErrorIs(t, got, tc.err)
+
+			if tc.want != nil {
+				require.ErrorIs(t, got, tc.want)
+
+				var constraintErr *storage.ConstraintError
+				if tc.field != "" {
+					require.ErrorAs(t, got, &constraintErr)
+					require.Equal(t, tc.field, constraintErr.Field)
+				} else {
+					require.False(t, errors.As(got, &constraintErr))
+				}
+			} else {
+				require.Equal(t, tc.err, got)
+			}
+		})
+	}
+}
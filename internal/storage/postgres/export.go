@@ -0,0 +1,162 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// exportFetchSize is the number of rows fetched from an export cursor at a
+// time.
+const exportFetchSize = 500
+
+// ExportActor is an actor with the titles of the movies they play in.
+type ExportActor struct {
+	Name      string
+	Gender    string
+	Birthdate string
+	Movies    []string
+	ActorProfile
+}
+
+// ExportMovie is a movie with its cast in billing order and then its
+// crew. Credits name the people, their ActorId is not set.
+type ExportMovie struct {
+	Title       string
+	Description string
+	ReleaseDate string
+	Rating      int
+	Credits     []Credit
+	MovieDetails
+}
+
+// ExportRow is either an actor or a movie.
+type ExportRow struct {
+	Actor *ExportActor
+	Movie *ExportMovie
+}
+
+const exportActors = `
+	SELECT a.name, a.gender, to_char(a.birthdate, 'YYYY-MM-DD'),
+	COALESCE(array_agg(m.title ORDER BY m.release_date, m.movie_id) FILTER (WHERE m.movie_id IS NOT NULL), '{}'),
+	COALESCE(a.biography, ''), COALESCE(a.birthplace, ''), COALESCE(a.nationality, ''),
+	COALESCE(to_char(a.death_date, 'YYYY-MM-DD'), ''),
+	ARRAY(SELECT alias FROM actor_aliases al WHERE al.actor_id = a.actor_id ORDER BY alias),
+	ARRAY(SELECT source || ':' || external_id FROM actor_external_ids e WHERE e.actor_id = a.actor_id ORDER BY source)
+	FROM actors a
+	LEFT JOIN actor_movie am ON am.actor_id = a.actor_id AND am.credit_type = 'actor'
+	LEFT JOIN movies m ON m.movie_id = am.movie_id AND m.deleted_at IS NULL
+	WHERE a.deleted_at IS NULL
+	GROUP BY a.actor_id
+	ORDER BY a.actor_id`
+
+const exportMovies = `
+	SELECT m.title, m.description, to_char(m.release_date, 'YYYY-MM-DD'), m.rating,
+	COALESCE((SELECT json_agg(json_build_object('name', a.name, 'credit_type', am.credit_type,
+			'character', COALESCE(am.character_name, ''), 'billing_order', COALESCE(am.billing_order, 0))
+		ORDER BY am.credit_type <> 'actor', am.credit_type, am.billing_order NULLS LAST, a.name, a.actor_id)
+		FROM actor_movie am
+		JOIN actors a ON a.actor_id = am.actor_id
+		WHERE am.movie_id = m.movie_id AND a.deleted_at IS NULL), '[]'),
+	COALESCE(m.runtime, 0), COALESCE(m.original_language, ''), COALESCE(m.certification, ''),
+	ARRAY(SELECT genre_name FROM movie_genre g WHERE g.movie_id = m.movie_id ORDER BY genre_name),
+	ARRAY(SELECT country_code::text FROM movie_country c WHERE c.movie_id = m.movie_id ORDER BY country_code),
+	ARRAY(SELECT tag_name FROM movie_tag t WHERE t.movie_id = m.movie_id ORDER BY tag_name)
+	FROM movies m
+	WHERE m.deleted_at IS NULL
+	ORDER BY m.movie_id`
+
+// ExportCatalogue calls fn with every actor and then every movie that is
+// not in the trash, all from one snapshot of the database. Rows are read
+// through server-side cursors, so only a few hundred are held in memory
+// at a time. Actors come first so that the export can be imported back.
+func (s *Storage) ExportCatalogue(ctx context.Context, fn func(row ExportRow) error) error {
+	const op = "storage.postgres.ExportCatalogue"
+
+	tx, err := s.Db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	// nothing to commit
+	defer tx.Rollback()
+
+	typeMap := pgtype.NewMap()
+
+	err = exportCursor(ctx, tx, "export_actors", exportActors, func(rows *sql.Rows) error {
+		var actor ExportActor
+		var external []string
+		err := rows.Scan(&actor.Name, &actor.Gender, &actor.Birthdate, typeMap.SQLScanner(&actor.Movies),
+			&actor.Biography, &actor.Birthplace, &actor.Nationality, &actor.DeathDate,
+			typeMap.SQLScanner(&actor.Aliases), typeMap.SQLScanner(&external))
+		if err != nil {
+			return err
+		}
+		actor.ExternalIds = parseExternalIds(external)
+		return fn(ExportRow{Actor: &actor})
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = exportCursor(ctx, tx, "export_movies", exportMovies, func(rows *sql.Rows) error {
+		var movie ExportMovie
+		var credits []byte
+		err := rows.Scan(&movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &credits,
+			&movie.Runtime, &movie.OriginalLanguage, &movie.Certification,
+			typeMap.SQLScanner(&movie.Genres), typeMap.SQLScanner(&movie.Countries), typeMap.SQLScanner(&movie.Tags))
+		if err != nil {
+			return err
+		}
+		if err = json.Unmarshal(credits, &movie.Credits); err != nil {
+			return err
+		}
+		return fn(ExportRow{Movie: &movie})
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// exportCursor declares a cursor named name for query and calls scan on
+// each of its rows, fetching them exportFetchSize at a time.
+func exportCursor(ctx context.Context, tx *sql.Tx, name string, query string, scan func(rows *sql.Rows) error) error {
+	if _, err := tx.ExecContext(ctx, fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR %s", name, query)); err != nil {
+		return err
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM %s", exportFetchSize, name)
+	for {
+		n, err := fetchRows(ctx, tx, fetch, scan)
+		if err != nil {
+			return err
+		}
+		if n < exportFetchSize {
+			break
+		}
+	}
+
+	_, err := tx.ExecContext(ctx, "CLOSE "+name)
+	return err
+}
+
+func fetchRows(ctx context.Context, tx *sql.Tx, fetch string, scan func(rows *sql.Rows) error) (int, error) {
+	rows, err := tx.QueryContext(ctx, fetch)
+	if err != nil {
+		return 0, err
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		if err = scan(rows); err != nil {
+			return n, err
+		}
+		n++
+	}
+
+	return n, rows.Err()
+}
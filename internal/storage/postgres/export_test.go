@@ -0,0 +1,49 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage/postgres"
+)
+
+func TestExportCatalogue(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	brandoId, err := s.SaveActor(ctx, "Marlon Brando", "male", "1924-04-03", postgres.ActorProfile{})
+	require.NoError(t, err)
+	pacinoId, err := s.SaveActor(ctx, "Al Pacino", "male", "1940-04-25", postgres.ActorProfile{})
+	require.NoError(t, err)
+	coppolaId, err := s.SaveActor(ctx, "Francis Ford Coppola", "male", "1939-04-07", postgres.ActorProfile{})
+	require.NoError(t, err)
+	godfatherId, err := s.SaveMovie(ctx, "The Godfather", "Crime saga", "1972-03-24", 9, []int{pacinoId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+	require.NoError(t, s.SaveActorMovie(ctx, godfatherId, []postgres.Credit{
+		{ActorId: brandoId, CreditType: postgres.CreditActor, Character: "Vito Corleone", BillingOrder: 1},
+		{ActorId: coppolaId, CreditType: postgres.CreditDirector},
+	}))
+	scarfaceId, err := s.SaveMovie(ctx, "Scarface", "", "1983-12-09", 8, []int{pacinoId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteMovie(ctx, scarfaceId, postgres.AnyVersion))
+
+	var rows []postgres.ExportRow
+	require.NoError(t, s.ExportCatalogue(ctx, func(row postgres.ExportRow) error {
+		rows = append(rows, row)
+		return nil
+	}))
+
+	require.Equal(t, []postgres.ExportRow{
+		{Actor: &postgres.ExportActor{Name: "Marlon Brando", Gender: "male", Birthdate: "1924-04-03", Movies: []string{"The Godfather"}}},
+		{Actor: &postgres.ExportActor{Name: "Al Pacino", Gender: "male", Birthdate: "1940-04-25", Movies: []string{"The Godfather"}}},
+		{Actor: &postgres.ExportActor{Name: "Francis Ford Coppola", Gender: "male", Birthdate: "1939-04-07", Movies: []string{}}},
+		{Movie: &postgres.ExportMovie{Title: "The Godfather", Description: "Crime saga", ReleaseDate: "1972-03-24", Rating: 9, Credits: []postgres.Credit{
+			{Name: "Marlon Brando", CreditType: postgres.CreditActor, Character: "Vito Corleone", BillingOrder: 1},
+			{Name: "Al Pacino", CreditType: postgres.CreditActor},
+			{Name: "Francis Ford Coppola", CreditType: postgres.CreditDirector},
+		}}},
+	}, rows)
+}
@@ -0,0 +1,300 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"film_library/internal/storage"
+	"fmt"
+)
+
+const (
+	ImportCreated   = "created"
+	ImportUpdated   = "updated"
+	ImportUnchanged = "unchanged"
+)
+
+// importLockKey is the advisory lock held by imports. Natural keys are not
+// unique in the schema, so concurrent imports of the same rows would
+// otherwise both create them.
+const importLockKey = 7_260_150_017
+
+type ImportActor struct {
+	Name      string
+	Gender    string
+	Birthdate string
+	ActorProfile
+}
+
+// ImportMovie is a movie to import. Cast holds the names of actors to add
+// as unranked acting credits and Credits cast and crew credits by Name.
+// Each name must match exactly one actor.
+type ImportMovie struct {
+	Title       string
+	Description string
+	ReleaseDate string
+	Rating      int
+	Cast        []string
+	Credits     []Credit
+	MovieDetails
+}
+
+// ImportRow is either an actor or a movie.
+type ImportRow struct {
+	Actor *ImportActor
+	Movie *ImportMovie
+}
+
+// ImportResult is the outcome of an ImportRow. Err is set when the row was
+// rejected, the rest of its batch is imported nonetheless.
+type ImportResult struct {
+	Id     int
+	Action string
+	Err    error
+}
+
+// ImportBatch creates the rows that do not exist yet and updates the ones
+// that do, matching actors by name and birthdate and movies by title and
+// release date. Credits, genres, countries and tags are added to a movie,
+// never removed from it, and empty details keep the known ones. Like
+// SaveActorMovie, a known credit takes the character and billing order
+// given for it.
+// Likewise aliases and external ids are added to an actor and an empty
+// profile keeps the known one.
+//
+// Rows are imported in order in one transaction, so an actor can be cast in
+// a movie later in the same batch. A row rejected by a constraint or an
+// unknown cast member is reported in its ImportResult, any other error
+// aborts the whole batch.
+func (s *Storage) ImportBatch(ctx context.Context, rows []ImportRow) ([]ImportResult, error) {
+	const op = "storage.postgres.ImportBatch"
+
+	results := make([]ImportResult, len(rows))
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := tx.Exec("SELECT pg_advisory_xact_lock($1)", importLockKey); err != nil {
+			return err
+		}
+
+		for i, row := range rows {
+			if _, err := tx.Exec("SAVEPOINT import_row"); err != nil {
+				return err
+			}
+
+			result, err := importRow(tx, row)
+			if err != nil {
+				if !isRowError(err) {
+					return err
+				}
+				if _, err := tx.Exec("ROLLBACK TO SAVEPOINT import_row"); err != nil {
+					return err
+				}
+				results[i] = ImportResult{Err: err}
+				continue
+			}
+
+			if _, err = tx.Exec("RELEASE SAVEPOINT import_row"); err != nil {
+				return err
+			}
+			results[i] = result
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return results, nil
+}
+
+func importRow(tx *sql.Tx, row ImportRow) (ImportResult, error) {
+	switch {
+	case row.Actor != nil:
+		return importActor(tx, *row.Actor)
+	case row.Movie != nil:
+		return importMovie(tx, *row.Movie)
+	}
+
+	return ImportResult{}, errors.New("empty import row")
+}
+
+func importActor(tx *sql.Tx, actor ImportActor) (ImportResult, error) {
+	var result ImportResult
+	err := tx.QueryRow(`SELECT actor_id FROM actors
+							   WHERE name=$1 AND birthdate=$2::date AND deleted_at IS NULL
+							   ORDER BY actor_id LIMIT 1`, actor.Name, actor.Birthdate).Scan(&result.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		result.Action = ImportCreated
+		result.Id, err = insertActor(tx, actor.Name, actor.Gender, actor.Birthdate, actor.ActorProfile)
+		return result, err
+	}
+	if err != nil {
+		return result, mapError(err)
+	}
+
+	res, err := tx.Exec(`UPDATE actors SET
+							   gender = $2,
+							   biography = COALESCE(NULLIF($3, ''), biography),
+							   birthplace = COALESCE(NULLIF($4, ''), birthplace),
+							   nationality = COALESCE(NULLIF($5, ''), nationality),
+							   death_date = COALESCE(NULLIF($6, '')::date, death_date)
+							   WHERE actor_id=$1 AND (gender, biography, birthplace, nationality, death_date) IS DISTINCT FROM
+							   ($2, COALESCE(NULLIF($3, ''), biography), COALESCE(NULLIF($4, ''), birthplace),
+							   COALESCE(NULLIF($5, ''), nationality), COALESCE(NULLIF($6, '')::date, death_date))`,
+		result.Id, actor.Gender, actor.Biography, actor.Birthplace, actor.Nationality, actor.DeathDate)
+	if err != nil {
+		return result, mapError(err)
+	}
+
+	result.Action, err = updateAction(res)
+	if err != nil {
+		return result, err
+	}
+
+	added, err := addActorProfile(tx, result.Id, actor.ActorProfile)
+	if added {
+		result.Action = ImportUpdated
+	}
+
+	return result, err
+}
+
+func importMovie(tx *sql.Tx, movie ImportMovie) (ImportResult, error) {
+	names := make([]string, len(movie.Credits))
+	for i, credit := range movie.Credits {
+		names[i] = credit.Name
+	}
+	actors, err := findActors(tx, append(names, movie.Cast...))
+	if err != nil {
+		return ImportResult{}, err
+	}
+
+	actorsIds, err := resolveCast(actors, "cast", movie.Cast)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	credits := actorCredits(actorsIds)
+
+	creditsIds, err := resolveCast(actors, "credits", names)
+	if err != nil {
+		return ImportResult{}, err
+	}
+	for i, credit := range movie.Credits {
+		credit.ActorId = creditsIds[i]
+		credits = append(credits, credit)
+	}
+
+	var result ImportResult
+	err = tx.QueryRow(`SELECT movie_id FROM movies
+							  WHERE title=$1 AND release_date=$2::date AND deleted_at IS NULL
+							  ORDER BY movie_id LIMIT 1`, movie.Title, movie.ReleaseDate).Scan(&result.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		result.Action = ImportCreated
+		result.Id, err = insertMovie(tx, movie.Title, movie.Description, movie.ReleaseDate, movie.Rating, movie.MovieDetails)
+		if err != nil {
+			return result, err
+		}
+
+		return result, saveCredits(tx, result.Id, credits)
+	}
+	if err != nil {
+		return result, mapError(err)
+	}
+
+	res, err := tx.Exec(`UPDATE movies SET description=$2, rating=$3,
+							   runtime = COALESCE(NULLIF($4, 0), runtime),
+							   original_language = COALESCE(NULLIF($5, ''), original_language),
+							   certification = COALESCE(NULLIF($6, ''), certification)
+							   WHERE movie_id=$1 AND (description, rating, runtime, original_language, certification) IS DISTINCT FROM
+							   ($2, $3, COALESCE(NULLIF($4, 0), runtime), COALESCE(NULLIF($5, ''), original_language), COALESCE(NULLIF($6, ''), certification))`,
+		result.Id, movie.Description, movie.Rating, movie.Runtime, movie.OriginalLanguage, movie.Certification)
+	if err != nil {
+		return result, mapError(err)
+	}
+
+	result.Action, err = updateAction(res)
+	if err != nil {
+		return result, err
+	}
+
+	creditsAdded, err := addCredits(tx, result.Id, credits)
+	if err != nil {
+		return result, err
+	}
+	if creditsAdded {
+		result.Action = ImportUpdated
+	}
+
+	detailsAdded, err := addMovieDetails(tx, result.Id, movie.MovieDetails)
+	if detailsAdded {
+		result.Action = ImportUpdated
+	}
+
+	return result, err
+}
+
+// findActors looks up the actors with any of the given names in a single
+// query and returns their ids by name.
+func findActors(tx *sql.Tx, names []string) (map[string][]int, error) {
+	actors := make(map[string][]int)
+	if len(names) == 0 {
+		return actors, nil
+	}
+
+	rows, err := tx.Query("SELECT name, actor_id FROM actors WHERE name = ANY($1) AND deleted_at IS NULL ORDER BY actor_id", names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+		var actorId int
+		if err := rows.Scan(&name, &actorId); err != nil {
+			return nil, err
+		}
+		actors[name] = append(actors[name], actorId)
+	}
+
+	return actors, rows.Err()
+}
+
+// resolveCast returns the ids of the actors with the given names, which
+// are listed in field, out of the actors found by findActors.
+func resolveCast(actors map[string][]int, field string, names []string) ([]int, error) {
+	actorsIds := make([]int, 0, len(names))
+	for _, name := range names {
+		switch matches := actors[name]; {
+		case len(matches) == 0:
+			return nil, &storage.ConstraintError{Field: field, Err: fmt.Errorf("actor %q %w", name, storage.ErrNotFound)}
+		case len(matches) > 1:
+			return nil, &storage.ConstraintError{Field: field, Err: fmt.Errorf("actor %q %w", name, storage.ErrAmbiguous)}
+		}
+
+		actorsIds = append(actorsIds, actors[name][0])
+	}
+
+	return actorsIds, nil
+}
+
+func updateAction(res sql.Result) (string, error) {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return "", err
+	}
+	if n == 0 {
+		return ImportUnchanged, nil
+	}
+
+	return ImportUpdated, nil
+}
+
+// isRowError reports whether err rejects a single row rather than the
+// whole import.
+func isRowError(err error) bool {
+	var constraintErr *storage.ConstraintError
+	return errors.As(err, &constraintErr) ||
+		errors.Is(err, storage.ErrConflict) ||
+		errors.Is(err, storage.ErrForeignKey) ||
+		errors.Is(err, storage.ErrInvalidValue)
+}
@@ -0,0 +1,84 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestImportBatch(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	brando := postgres.ImportActor{Name: "Marlon Brando", Gender: "male", Birthdate: "1924-04-03"}
+	godfather := postgres.ImportMovie{
+		Title:       "The Godfather",
+		Description: "Crime saga",
+		ReleaseDate: "1972-03-24",
+		Rating:      9,
+		Cast:        []string{"Marlon Brando"},
+	}
+
+	results, err := s.ImportBatch(ctx, []postgres.ImportRow{
+		{Actor: &brando},
+		{Movie: &godfather},
+		{Movie: &postgres.ImportMovie{Title: "Apocalypse Now", ReleaseDate: "1979-08-15", Rating: 8, Cast: []string{"Martin Sheen"}}},
+		{Movie: &postgres.ImportMovie{Title: "Too good", ReleaseDate: "1979-08-15", Rating: 11}},
+	})
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+	require.Equal(t, postgres.ImportCreated, results[0].Action)
+	require.Equal(t, postgres.ImportCreated, results[1].Action)
+	require.ErrorIs(t, results[2].Err, storage.ErrNotFound)
+	require.ErrorIs(t, results[3].Err, storage.ErrInvalidValue)
+
+	movie, err := s.GetMovie(results[1].Id)
+	require.NoError(t, err)
+	require.Equal(t, []int{results[0].Id}, movie.Actors)
+
+	// importing the same rows again changes nothing
+	godfather.Rating = 10
+	results, err = s.ImportBatch(ctx, []postgres.ImportRow{{Actor: &brando}, {Movie: &godfather}})
+	require.NoError(t, err)
+	require.Equal(t, postgres.ImportUnchanged, results[0].Action)
+	require.Equal(t, postgres.ImportUpdated, results[1].Action)
+	require.Equal(t, movie.Id, results[1].Id)
+
+	movie, err = s.GetMovie(movie.Id)
+	require.NoError(t, err)
+	require.Equal(t, 10, movie.Rating)
+
+	// credits keep their type, character and billing order
+	coppola := postgres.ImportActor{Name: "Francis Ford Coppola", Gender: "male", Birthdate: "1939-04-07"}
+	godfather.Credits = []postgres.Credit{
+		{Name: "Marlon Brando", CreditType: postgres.CreditActor, Character: "Vito Corleone", BillingOrder: 1},
+		{Name: "Francis Ford Coppola", CreditType: postgres.CreditDirector},
+	}
+	results, err = s.ImportBatch(ctx, []postgres.ImportRow{{Actor: &coppola}, {Movie: &godfather}})
+	require.NoError(t, err)
+	require.Equal(t, postgres.ImportUpdated, results[1].Action)
+
+	cast, crew, err := s.GetCredits(movie.Id)
+	require.NoError(t, err)
+	require.Equal(t, []postgres.Credit{
+		{ActorId: movie.Actors[0], Name: "Marlon Brando", CreditType: postgres.CreditActor, Character: "Vito Corleone", BillingOrder: 1},
+	}, cast)
+	require.Equal(t, []postgres.Credit{
+		{ActorId: results[0].Id, Name: "Francis Ford Coppola", CreditType: postgres.CreditDirector},
+	}, crew)
+
+	results, err = s.ImportBatch(ctx, []postgres.ImportRow{{Movie: &godfather}})
+	require.NoError(t, err)
+	require.Equal(t, postgres.ImportUnchanged, results[0].Action)
+
+	_, err = s.SaveActor(ctx, "Marlon Brando", "male", "1950-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	results, err = s.ImportBatch(ctx, []postgres.ImportRow{{Movie: &godfather}})
+	require.NoError(t, err)
+	require.ErrorIs(t, results[0].Err, storage.ErrAmbiguous)
+}
@@ -0,0 +1,449 @@
+package postgres
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/base64"
+	"errors"
+	"film_library/internal/storage"
+	"fmt"
+	"time"
+)
+
+const (
+	ListWatchlist  = "watchlist"
+	ListFavourites = "favourites"
+	ListWatched    = "watched"
+	ListCustom     = "custom"
+)
+
+// List is a movie list of a user. Every user has a watchlist, favourites
+// and watched list, created with the user, and any number of custom ones.
+type List struct {
+	Id         int       `json:"list_id"`
+	Kind       string    `json:"kind"`
+	Name       string    `json:"name"`
+	ItemCount  int       `json:"item_count"`
+	ShareToken string    `json:"share_token,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ListItem struct {
+	MovieId     int    `json:"movie_id"`
+	Title       string `json:"title"`
+	ReleaseDate string `json:"release_date"`
+	Position    int    `json:"position"`
+	// WatchedAt is the day the movie was watched, on the watched list only
+	WatchedAt string    `json:"watched_at,omitempty"`
+	AddedAt   time.Time `json:"added_at"`
+}
+
+// ListFlags tells which lists of the calling user hold a movie. ListIds
+// are the custom lists.
+type ListFlags struct {
+	Watchlist bool   `json:"watchlist"`
+	Favourite bool   `json:"favourite"`
+	Watched   bool   `json:"watched"`
+	WatchedAt string `json:"watched_at,omitempty"`
+	ListIds   []int  `json:"list_ids"`
+}
+
+const listColumns = `l.list_id, l.kind, l.name, COALESCE(l.share_token, ''), l.created_at,
+					 (SELECT count(*) FROM list_items li JOIN movies m ON m.movie_id = li.movie_id
+					  WHERE li.list_id = l.list_id AND m.deleted_at IS NULL)`
+
+func scanList(row interface{ Scan(...interface{}) error }) (List, error) {
+	var list List
+	err := row.Scan(&list.Id, &list.Kind, &list.Name, &list.ShareToken, &list.CreatedAt, &list.ItemCount)
+
+	return list, err
+}
+
+// GetLists returns the lists of userId, the built-in ones first.
+func (s *Storage) GetLists(userId int) ([]List, error) {
+	const op = "storage.postgres.GetLists"
+
+	rows, err := s.Db.Query(`SELECT `+listColumns+` FROM user_lists l
+								   WHERE l.user_id=$1
+								   ORDER BY l.kind = 'custom', l.list_id`, userId)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	lists := []List{}
+	for rows.Next() {
+		list, err := scanList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		lists = append(lists, list)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return lists, nil
+}
+
+// SaveList creates a custom list of userId. List names are unique per user.
+func (s *Storage) SaveList(ctx context.Context, userId int, name string) (int, error) {
+	const op = "storage.postgres.SaveList"
+
+	var listId int
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRow("INSERT INTO user_lists(user_id, name) VALUES($1, $2) RETURNING list_id", userId, name).
+			Scan(&listId)
+
+		return mapError(err)
+	})
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return listId, nil
+}
+
+// DeleteList removes a custom list of userId with its items. The built-in
+// lists cannot be removed, that is a storage.ErrConflict.
+func (s *Storage) DeleteList(ctx context.Context, listId int, userId int) error {
+	const op = "storage.postgres.DeleteList"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		kind, err := listKind(tx, listId, userId)
+		if err != nil {
+			return err
+		}
+		if kind != ListCustom {
+			return &storage.ConstraintError{Field: "list_id", Err: storage.ErrConflict}
+		}
+
+		_, err = tx.Exec("DELETE FROM user_lists WHERE list_id=$1", listId)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// listKind locks a list of userId for the rest of tx and returns its kind.
+// Lists of other users are storage.ErrNotFound.
+func listKind(tx *sql.Tx, listId int, userId int) (string, error) {
+	var kind string
+	err := tx.QueryRow("SELECT kind FROM user_lists WHERE list_id=$1 AND user_id=$2 FOR UPDATE", listId, userId).
+		Scan(&kind)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", storage.ErrNotFound
+	}
+
+	return kind, err
+}
+
+// AddListItem appends a movie to a list of userId. Adding a movie that is
+// already on the list keeps its position. watchedAt is only accepted by the
+// watched list, which defaults it to today.
+func (s *Storage) AddListItem(ctx context.Context, listId int, userId int, movieId int, watchedAt string) error {
+	const op = "storage.postgres.AddListItem"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		kind, err := listKind(tx, listId, userId)
+		if err != nil {
+			return err
+		}
+		if kind != ListWatched && watchedAt != "" {
+			return &storage.ConstraintError{Field: "watched_at", Err: storage.ErrInvalidValue}
+		}
+
+		res, err := tx.Exec(`INSERT INTO list_items(list_id, movie_id, position, watched_at)
+								   SELECT $1, movie_id,
+								   		  (SELECT COALESCE(max(position), 0) + 1 FROM list_items WHERE list_id=$1),
+								   		  CASE WHEN $3::boolean THEN COALESCE(NULLIF($4::text, '')::date, current_date) END
+								   FROM movies WHERE movie_id=$2 AND deleted_at IS NULL
+								   ON CONFLICT (list_id, movie_id) DO UPDATE SET watched_at = EXCLUDED.watched_at`,
+			listId, movieId, kind == ListWatched, watchedAt)
+		if err != nil {
+			return mapError(err)
+		}
+
+		return expectAffected(res, &storage.ConstraintError{Field: "movie_id", Err: storage.ErrNotFound})
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RemoveListItem takes a movie off a list of userId.
+func (s *Storage) RemoveListItem(ctx context.Context, listId int, userId int, movieId int) error {
+	const op = "storage.postgres.RemoveListItem"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.Exec(`DELETE FROM list_items li USING user_lists l
+								   WHERE l.list_id = li.list_id AND li.list_id=$1 AND l.user_id=$2 AND li.movie_id=$3`,
+			listId, userId, movieId)
+		if err != nil {
+			return err
+		}
+
+		return expectAffected(res, storage.ErrNotFound)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ReorderList puts the items of a list of userId in the order of movieIds,
+// which must name every item of the list once. A list changed in the
+// meantime is a storage.ErrConflict.
+func (s *Storage) ReorderList(ctx context.Context, listId int, userId int, movieIds []int) error {
+	const op = "storage.postgres.ReorderList"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		if _, err := listKind(tx, listId, userId); err != nil {
+			return err
+		}
+
+		var items, named int
+		err := tx.QueryRow(`SELECT count(*), count(*) FILTER (WHERE li.movie_id = ANY($2))
+								   FROM list_items li
+								   JOIN movies m ON m.movie_id = li.movie_id
+								   WHERE li.list_id=$1 AND m.deleted_at IS NULL`, listId, movieIds).Scan(&items, &named)
+		if err != nil {
+			return err
+		}
+		if items != len(movieIds) || named != len(movieIds) {
+			return &storage.ConstraintError{Field: "movie_ids", Err: storage.ErrConflict}
+		}
+
+		// movies in the trash go after the others, keeping their order
+		_, err = tx.Exec(`UPDATE list_items li SET position = o.position
+								FROM (SELECT i.movie_id, row_number() OVER (ORDER BY g.ord NULLS LAST, i.position) AS position
+									  FROM list_items i
+									  LEFT JOIN unnest($2::int[]) WITH ORDINALITY AS g(movie_id, ord) ON g.movie_id = i.movie_id
+									  WHERE i.list_id=$1) o
+								WHERE li.list_id=$1 AND li.movie_id = o.movie_id`, listId, movieIds)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ShareList returns the share token of a list of userId, creating one if
+// the list is not shared yet.
+func (s *Storage) ShareList(ctx context.Context, listId int, userId int) (string, error) {
+	const op = "storage.postgres.ShareList"
+
+	shareToken, err := newShareToken()
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.WithTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRow(`UPDATE user_lists SET share_token = COALESCE(share_token, $3)
+								  WHERE list_id=$1 AND user_id=$2
+								  RETURNING share_token`, listId, userId, shareToken).Scan(&shareToken)
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+
+		return err
+	})
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", op, err)
+	}
+
+	return shareToken, nil
+}
+
+// UnshareList revokes the share token of a list of userId, so that its
+// link stops working.
+func (s *Storage) UnshareList(ctx context.Context, listId int, userId int) error {
+	const op = "storage.postgres.UnshareList"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.Exec("UPDATE user_lists SET share_token = NULL WHERE list_id=$1 AND user_id=$2", listId, userId)
+		if err != nil {
+			return err
+		}
+
+		return expectAffected(res, storage.ErrNotFound)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func newShareToken() (string, error) {
+	b := make([]byte, 24)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+const listItemsOrdering = "position"
+
+// GetListItems returns a page of the items of a list of userId in list
+// order. Movies in the trash are left out.
+func (s *Storage) GetListItems(listId int, userId int, page Page) (List, []ListItem, PageInfo, error) {
+	const op = "storage.postgres.GetListItems"
+
+	list, err := scanList(s.Db.QueryRow(`SELECT `+listColumns+` FROM user_lists l
+											   WHERE l.list_id=$1 AND l.user_id=$2`, listId, userId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return List{}, nil, PageInfo{}, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+	}
+	if err != nil {
+		return List{}, nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	items, info, err := s.listItems(list, page)
+	if err != nil {
+		return List{}, nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return list, items, info, nil
+}
+
+// GetSharedList returns a page of the items of the list shared with
+// shareToken, for anyone who has the link.
+func (s *Storage) GetSharedList(shareToken string, page Page) (List, []ListItem, PageInfo, error) {
+	const op = "storage.postgres.GetSharedList"
+
+	list, err := scanList(s.Db.QueryRow(`SELECT `+listColumns+` FROM user_lists l
+											   WHERE l.share_token=$1`, shareToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return List{}, nil, PageInfo{}, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+	}
+	if err != nil {
+		return List{}, nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	items, info, err := s.listItems(list, page)
+	if err != nil {
+		return List{}, nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return list, items, info, nil
+}
+
+func (s *Storage) listItems(list List, page Page) ([]ListItem, PageInfo, error) {
+	afterPosition := int64(0)
+	if page.Cursor != "" {
+		values, err := decodeCursor(page.Cursor, listItemsOrdering, 1)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		afterPosition, _ = values[0].(int64)
+	}
+
+	rows, err := s.Db.Query(`SELECT m.movie_id, m.title, to_char(m.release_date, 'YYYY-MM-DD'), li.position,
+								   COALESCE(to_char(li.watched_at, 'YYYY-MM-DD'), ''), li.added_at
+								   FROM list_items li
+								   JOIN movies m ON m.movie_id = li.movie_id
+								   WHERE li.list_id=$1 AND li.position > $2 AND m.deleted_at IS NULL
+								   ORDER BY li.position
+								   LIMIT $3`, list.Id, afterPosition, page.limit()+1)
+	if err != nil {
+		return nil, PageInfo{}, err
+	}
+	defer rows.Close()
+
+	items := []ListItem{}
+	for rows.Next() {
+		var item ListItem
+		err = rows.Scan(&item.MovieId, &item.Title, &item.ReleaseDate, &item.Position, &item.WatchedAt, &item.AddedAt)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PageInfo{}, err
+	}
+
+	var info PageInfo
+	if len(items) > page.limit() {
+		items = items[:page.limit()]
+		info.NextCursor, err = encodeCursor(listItemsOrdering, items[len(items)-1].Position)
+		if err != nil {
+			return nil, PageInfo{}, err
+		}
+	}
+
+	if page.WithTotal {
+		total := list.ItemCount
+		info.Total = &total
+	}
+
+	return items, info, nil
+}
+
+// SetListFlags fills Lists of every movie with the lists of userId that
+// hold it, with a single query.
+func (s *Storage) SetListFlags(userId int, movies []*Movie) error {
+	const op = "storage.postgres.SetListFlags"
+
+	ids := make([]int, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.Id
+	}
+
+	rows, err := s.Db.Query(`SELECT li.movie_id, l.list_id, l.kind, COALESCE(to_char(li.watched_at, 'YYYY-MM-DD'), '')
+								   FROM list_items li
+								   JOIN user_lists l ON l.list_id = li.list_id
+								   WHERE l.user_id=$1 AND li.movie_id = ANY($2)
+								   ORDER BY li.movie_id, l.list_id`, userId, ids)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	flags := make(map[int]*ListFlags, len(movies))
+	for _, movie := range movies {
+		if flags[movie.Id] == nil {
+			flags[movie.Id] = &ListFlags{ListIds: []int{}}
+		}
+		movie.Lists = flags[movie.Id]
+	}
+
+	for rows.Next() {
+		var movieId, listId int
+		var kind, watchedAt string
+		if err = rows.Scan(&movieId, &listId, &kind, &watchedAt); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		f := flags[movieId]
+		switch kind {
+		case ListWatchlist:
+			f.Watchlist = true
+		case ListFavourites:
+			f.Favourite = true
+		case ListWatched:
+			f.Watched = true
+			f.WatchedAt = watchedAt
+		default:
+			f.ListIds = append(f.ListIds, listId)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,119 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestLists(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.EnsureDefaultRoles())
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveUser(ctx, "alice", "password"))
+	require.NoError(t, s.SaveUser(ctx, "bob", "password"))
+	alice, err := s.GetUser("alice", "password")
+	require.NoError(t, err)
+	bob, err := s.GetUser("bob", "password")
+	require.NoError(t, err)
+
+	// every user starts with the built-in lists
+	lists, err := s.GetLists(alice)
+	require.NoError(t, err)
+	require.Len(t, lists, 3)
+	require.Equal(t, postgres.ListWatchlist, lists[0].Kind)
+	require.Equal(t, postgres.ListWatched, lists[2].Kind)
+	watchlist, watched := lists[0].Id, lists[2].Id
+
+	noir, err := s.SaveList(ctx, alice, "Noir")
+	require.NoError(t, err)
+	_, err = s.SaveList(ctx, alice, "Noir")
+	require.ErrorIs(t, err, storage.ErrConflict)
+	_, err = s.SaveList(ctx, bob, "Noir")
+	require.NoError(t, err)
+
+	var movies []int
+	for _, title := range []string{"Double Indemnity", "Laura", "Gilda"} {
+		movieId, err := s.SaveMovie(ctx, title, "", "1944-09-06", 8, nil, postgres.MovieDetails{})
+		require.NoError(t, err)
+		movies = append(movies, movieId)
+	}
+
+	for _, movieId := range movies {
+		require.NoError(t, s.AddListItem(ctx, noir, alice, movieId, ""))
+	}
+	// adding twice keeps the position
+	require.NoError(t, s.AddListItem(ctx, noir, alice, movies[0], ""))
+	require.NoError(t, s.AddListItem(ctx, watchlist, alice, movies[1], ""))
+	require.NoError(t, s.AddListItem(ctx, watched, alice, movies[2], "2024-05-01"))
+
+	require.ErrorIs(t, s.AddListItem(ctx, watchlist, alice, movies[0], "2024-05-01"), storage.ErrInvalidValue)
+	require.ErrorIs(t, s.AddListItem(ctx, noir, bob, movies[0], ""), storage.ErrNotFound)
+	require.ErrorIs(t, s.AddListItem(ctx, noir, alice, movies[2]+1, ""), storage.ErrNotFound)
+
+	_, items, _, err := s.GetListItems(noir, alice, postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	require.Equal(t, movies[0], items[0].MovieId)
+
+	require.ErrorIs(t, s.ReorderList(ctx, noir, alice, []int{movies[2], movies[0]}), storage.ErrConflict)
+	require.NoError(t, s.ReorderList(ctx, noir, alice, []int{movies[2], movies[0], movies[1]}))
+
+	list, items, info, err := s.GetListItems(noir, alice, postgres.Page{Limit: 2, WithTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, 3, list.ItemCount)
+	require.Equal(t, 3, *info.Total)
+	require.Equal(t, []int{movies[2], movies[0]}, []int{items[0].MovieId, items[1].MovieId})
+
+	_, items, _, err = s.GetListItems(noir, alice, postgres.Page{Limit: 2, Cursor: info.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, movies[1], items[0].MovieId)
+
+	_, _, _, err = s.GetListItems(noir, bob, postgres.Page{})
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	// flags of the caller
+	movie, err := s.GetMovie(movies[2])
+	require.NoError(t, err)
+	require.NoError(t, s.SetListFlags(alice, []*postgres.Movie{&movie}))
+	require.True(t, movie.Lists.Watched)
+	require.Equal(t, "2024-05-01", movie.Lists.WatchedAt)
+	require.False(t, movie.Lists.Watchlist)
+	require.Equal(t, []int{noir}, movie.Lists.ListIds)
+
+	require.NoError(t, s.SetListFlags(bob, []*postgres.Movie{&movie}))
+	require.False(t, movie.Lists.Watched)
+	require.Empty(t, movie.Lists.ListIds)
+
+	// sharing
+	shareToken, err := s.ShareList(ctx, noir, alice)
+	require.NoError(t, err)
+	again, err := s.ShareList(ctx, noir, alice)
+	require.NoError(t, err)
+	require.Equal(t, shareToken, again)
+
+	list, items, _, err = s.GetSharedList(shareToken, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, "Noir", list.Name)
+	require.Len(t, items, 3)
+
+	require.NoError(t, s.UnshareList(ctx, noir, alice))
+	_, _, _, err = s.GetSharedList(shareToken, postgres.Page{})
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	require.ErrorIs(t, s.RemoveListItem(ctx, noir, bob, movies[0]), storage.ErrNotFound)
+	require.NoError(t, s.RemoveListItem(ctx, noir, alice, movies[0]))
+
+	require.ErrorIs(t, s.DeleteList(ctx, watchlist, alice), storage.ErrConflict)
+	require.NoError(t, s.DeleteList(ctx, noir, alice))
+
+	lists, err = s.GetLists(alice)
+	require.NoError(t, err)
+	require.Len(t, lists, 3)
+}
@@ -0,0 +1,225 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// MovieDetails is the metadata of a movie. Runtime is in minutes,
+// OriginalLanguage an ISO 639-1 code and Countries ISO 3166-1 alpha-2
+// codes. Zero values stand for unknown.
+type MovieDetails struct {
+	Runtime          int      `json:"runtime,omitempty"`
+	OriginalLanguage string   `json:"original_language,omitempty"`
+	Certification    string   `json:"certification,omitempty"`
+	Genres           []string `json:"genres"`
+	Countries        []string `json:"countries"`
+	Tags             []string `json:"tags"`
+}
+
+// MovieFilter narrows a list of movies down to those having all of the
+// given genres, countries and tags and the given language and
+// certification, released in the years from YearFrom to YearTo and rated
+// from RatingMin to RatingMax. ActorIds keeps the movies casting any of
+// the people as actors, or all of them with AllActors, crew credits aside,
+// and Contains those whose title or description contains the text. Zero
+// fields do not filter.
+type MovieFilter struct {
+	Genres           []string
+	Countries        []string
+	Tags             []string
+	OriginalLanguage string
+	Certification    string
+	YearFrom         int
+	YearTo           int
+	RatingMin        *int
+	RatingMax        *int
+	ActorIds         []int
+	AllActors        bool
+	Contains         string
+}
+
+// movieSet is a join table holding a list of codes of a movie.
+type movieSet struct {
+	table  string
+	column string
+	// lookup is the table the codes are created in on first use, if any
+	lookup string
+}
+
+var (
+	genreSet   = movieSet{table: "movie_genre", column: "genre_name"}
+	countrySet = movieSet{table: "movie_country", column: "country_code"}
+	tagSet     = movieSet{table: "movie_tag", column: "tag_name", lookup: "tags"}
+)
+
+// add adds values to the set of a movie and reports whether any of them
+// was new.
+func (m movieSet) add(tx *sql.Tx, movieId int, values []string) (bool, error) {
+	if len(values) == 0 {
+		return false, nil
+	}
+
+	if m.lookup != "" {
+		_, err := tx.Exec(fmt.Sprintf("INSERT INTO %s(%s) SELECT unnest($1::text[]) ON CONFLICT DO NOTHING", m.lookup, m.column), values)
+		if err != nil {
+			return false, mapError(err)
+		}
+	}
+
+	res, err := tx.Exec(fmt.Sprintf(`INSERT INTO %s(movie_id, %s)
+											SELECT $1, unnest($2::text[])
+											ON CONFLICT DO NOTHING`, m.table, m.column), movieId, values)
+	if err != nil {
+		return false, mapError(err)
+	}
+
+	n, err := res.RowsAffected()
+	return n > 0, err
+}
+
+// replace makes values the set of a movie and reports whether it changed.
+func (m movieSet) replace(tx *sql.Tx, movieId int, values []string) (bool, error) {
+	if values == nil {
+		// a nil array would be NULL and keep every row
+		values = []string{}
+	}
+
+	res, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE movie_id=$1 AND %s::text <> ALL($2::text[])", m.table, m.column),
+		movieId, values)
+	if err != nil {
+		return false, err
+	}
+
+	removed, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	added, err := m.add(tx, movieId, values)
+	return removed > 0 || added, err
+}
+
+// addMovieDetails adds the lists of details to a movie.
+func addMovieDetails(tx *sql.Tx, movieId int, details MovieDetails) (bool, error) {
+	changed := false
+	for _, set := range []struct {
+		set    movieSet
+		values []string
+	}{
+		{genreSet, details.Genres},
+		{countrySet, details.Countries},
+		{tagSet, details.Tags},
+	} {
+		added, err := set.set.add(tx, movieId, set.values)
+		if err != nil {
+			return false, err
+		}
+		changed = changed || added
+	}
+
+	return changed, nil
+}
+
+// replaceMovieDetails replaces the lists of a movie that are not nil.
+func replaceMovieDetails(tx *sql.Tx, movieId int, genres []string, countries []string, tags []string) error {
+	for _, set := range []struct {
+		set    movieSet
+		values []string
+	}{
+		{genreSet, genres},
+		{countrySet, countries},
+		{tagSet, tags},
+	} {
+		if set.values == nil {
+			continue
+		}
+		if _, err := set.set.replace(tx, movieId, set.values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// loadMetadata fills Genres, Countries and Tags of every movie with a query
+// per list.
+func (s *Storage) loadMetadata(movies []*Movie) error {
+	ids := make([]int, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.Id
+	}
+
+	for _, set := range []struct {
+		set   movieSet
+		field func(m *Movie) *[]string
+	}{
+		{genreSet, func(m *Movie) *[]string { return &m.Genres }},
+		{countrySet, func(m *Movie) *[]string { return &m.Countries }},
+		{tagSet, func(m *Movie) *[]string { return &m.Tags }},
+	} {
+		values, err := associations[string](s.Db, fmt.Sprintf(`SELECT movie_id, %[1]s::text FROM %[2]s
+																	  WHERE movie_id = ANY($1)
+																	  ORDER BY movie_id, %[1]s`, set.set.column, set.set.table), ids)
+		if err != nil {
+			return fmt.Errorf("load %s: %w", set.set.table, err)
+		}
+
+		for _, movie := range movies {
+			*set.field(movie) = values[movie.Id]
+		}
+	}
+
+	return nil
+}
+
+// conditions adds the WHERE conditions of f on the movies table to b.
+func (f MovieFilter) conditions(b *queryBuilder) {
+	for _, set := range []struct {
+		set    movieSet
+		values []string
+	}{
+		{genreSet, f.Genres},
+		{countrySet, f.Countries},
+		{tagSet, f.Tags},
+	} {
+		if len(set.values) == 0 {
+			continue
+		}
+		b.where(fmt.Sprintf("?::text[] <@ ARRAY(SELECT s.%s::text FROM %s s WHERE s.movie_id = movies.movie_id)",
+			set.set.column, set.set.table), set.values)
+	}
+
+	if f.OriginalLanguage != "" {
+		b.where("original_language = ?", f.OriginalLanguage)
+	}
+	if f.Certification != "" {
+		b.where("certification = ?", f.Certification)
+	}
+
+	if f.YearFrom != 0 {
+		b.where("release_date >= make_date(?::int, 1, 1)", f.YearFrom)
+	}
+	if f.YearTo != 0 {
+		b.where("release_date < make_date(?::int + 1, 1, 1)", f.YearTo)
+	}
+	if f.RatingMin != nil {
+		b.where("rating >= ?", *f.RatingMin)
+	}
+	if f.RatingMax != nil {
+		b.where("rating <= ?", *f.RatingMax)
+	}
+
+	if len(f.ActorIds) != 0 {
+		if f.AllActors {
+			b.where("?::int[] <@ ARRAY(SELECT am.actor_id FROM actor_movie am WHERE am.movie_id = movies.movie_id AND am.credit_type = 'actor')", f.ActorIds)
+		} else {
+			b.where("EXISTS(SELECT 1 FROM actor_movie am WHERE am.movie_id = movies.movie_id AND am.credit_type = 'actor' AND am.actor_id = ANY(?::int[]))", f.ActorIds)
+		}
+	}
+
+	if f.Contains != "" {
+		pattern := containsPattern(f.Contains)
+		b.where("(title ILIKE ? OR description ILIKE ?)", pattern, pattern)
+	}
+}
@@ -0,0 +1,147 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestMovieMetadata(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	amelieId, err := s.SaveMovie(ctx, "Amélie", "", "2001-04-25", 8, nil, postgres.MovieDetails{
+		Runtime:          122,
+		OriginalLanguage: "fr",
+		Certification:    "R",
+		Genres:           []string{"romance", "comedy"},
+		Countries:        []string{"FR", "DE"},
+		Tags:             []string{"paris", "whimsical"},
+	})
+	require.NoError(t, err)
+	leonId, err := s.SaveMovie(ctx, "Léon", "", "1994-09-14", 9, nil, postgres.MovieDetails{
+		Genres:    []string{"crime", "drama"},
+		Countries: []string{"FR"},
+	})
+	require.NoError(t, err)
+
+	amelie, err := s.GetMovie(amelieId)
+	require.NoError(t, err)
+	require.Equal(t, postgres.MovieDetails{
+		Runtime:          122,
+		OriginalLanguage: "fr",
+		Certification:    "R",
+		Genres:           []string{"comedy", "romance"},
+		Countries:        []string{"DE", "FR"},
+		Tags:             []string{"paris", "whimsical"},
+	}, amelie.MovieDetails)
+
+	movies, info, err := s.GetMovies(titleAsc, postgres.MovieFilter{Countries: []string{"FR"}}, postgres.Page{WithTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, *info.Total)
+	require.Equal(t, []string{"crime", "drama"}, movies[1].Genres)
+
+	movies, _, err = s.GetMovies(titleAsc, postgres.MovieFilter{Genres: []string{"drama", "crime"}, Countries: []string{"FR"}}, postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	require.Equal(t, leonId, movies[0].Id)
+
+	movies, _, err = s.GetMovies(titleAsc, postgres.MovieFilter{OriginalLanguage: "fr", Tags: []string{"paris"}}, postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	require.Equal(t, amelieId, movies[0].Id)
+
+	// lists given in a patch are replaced, the ones left out are kept
+	runtime := 0
+	_, err = s.UpdateMovie(ctx, amelieId, postgres.MoviePatch{Runtime: &runtime, Genres: []string{"comedy"}, Tags: []string{}}, postgres.AnyVersion)
+	require.NoError(t, err)
+
+	amelie, err = s.GetMovie(amelieId)
+	require.NoError(t, err)
+	require.Zero(t, amelie.Runtime)
+	require.Equal(t, "fr", amelie.OriginalLanguage)
+	require.Equal(t, []string{"comedy"}, amelie.Genres)
+	require.Equal(t, []string{"DE", "FR"}, amelie.Countries)
+	require.Empty(t, amelie.Tags)
+
+	var constraintErr *storage.ConstraintError
+
+	_, err = s.UpdateMovie(ctx, amelieId, postgres.MoviePatch{Genres: []string{"mockumentary"}}, postgres.AnyVersion)
+	require.ErrorIs(t, err, storage.ErrForeignKey)
+	require.ErrorAs(t, err, &constraintErr)
+	require.Equal(t, "genres", constraintErr.Field)
+
+	_, err = s.SaveMovie(ctx, "Movie", "", "2000-01-01", 5, nil, postgres.MovieDetails{Countries: []string{"XX"}})
+	require.ErrorAs(t, err, &constraintErr)
+	require.Equal(t, "countries", constraintErr.Field)
+
+	_, err = s.SaveMovie(ctx, "Movie", "", "2000-01-01", 5, nil, postgres.MovieDetails{Certification: "PG-18"})
+	require.ErrorAs(t, err, &constraintErr)
+	require.Equal(t, "certification", constraintErr.Field)
+}
+
+func TestMovieFilterAndSort(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	pacino, err := s.SaveActor(ctx, "Al Pacino", "male", "1940-04-25", postgres.ActorProfile{})
+	require.NoError(t, err)
+	deNiro, err := s.SaveActor(ctx, "Robert De Niro", "male", "1943-08-17", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	heat, err := s.SaveMovie(ctx, "Heat", "A heist in Los Angeles", "1995-12-15", 8, []int{pacino, deNiro}, postgres.MovieDetails{})
+	require.NoError(t, err)
+	scarface, err := s.SaveMovie(ctx, "Scarface", "", "1983-12-09", 8, []int{pacino}, postgres.MovieDetails{})
+	require.NoError(t, err)
+	casino, err := s.SaveMovie(ctx, "Casino", "", "1995-11-22", 8, []int{deNiro}, postgres.MovieDetails{})
+	require.NoError(t, err)
+	cats, err := s.SaveMovie(ctx, "Cats", "", "2019-12-20", 2, nil, postgres.MovieDetails{})
+	require.NoError(t, err)
+	// crew credits do not count as acting in the movie
+	require.NoError(t, s.SaveActorMovie(ctx, scarface, []postgres.Credit{{ActorId: deNiro, CreditType: postgres.CreditProducer}}))
+	require.NoError(t, s.SaveActorMovie(ctx, cats, []postgres.Credit{{ActorId: deNiro, CreditType: postgres.CreditDirector}}))
+
+	ids := func(movies []postgres.Movie) []int {
+		var ids []int
+		for _, m := range movies {
+			ids = append(ids, m.Id)
+		}
+		return ids
+	}
+
+	movies, _, err := s.GetMovies(titleAsc, postgres.MovieFilter{YearFrom: 1995, YearTo: 1995}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{casino, heat}, ids(movies))
+
+	minRating := 5
+	movies, _, err = s.GetMovies(titleAsc, postgres.MovieFilter{RatingMin: &minRating, ActorIds: []int{pacino, deNiro}}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{casino, heat, scarface}, ids(movies))
+
+	movies, _, err = s.GetMovies(titleAsc, postgres.MovieFilter{ActorIds: []int{pacino, deNiro}, AllActors: true}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{heat}, ids(movies))
+
+	movies, _, err = s.GetMovies(titleAsc, postgres.MovieFilter{Contains: "HEIST"}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{heat}, ids(movies))
+
+	// multi-key order, page by page
+	sort := []postgres.SortKey{{Field: postgres.SortRating, Desc: true}, {Field: postgres.SortReleaseDate, Desc: true}, {Field: postgres.SortTitle}}
+	movies, info, err := s.GetMovies(sort, postgres.MovieFilter{}, postgres.Page{Limit: 2})
+	require.NoError(t, err)
+	require.Equal(t, []int{heat, casino}, ids(movies))
+
+	movies, _, err = s.GetMovies(sort, postgres.MovieFilter{}, postgres.Page{Limit: 2, Cursor: info.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, movies, 2)
+	require.Equal(t, scarface, movies[0].Id)
+
+	// a cursor only fits the order it was issued for
+	_, _, err = s.GetMovies(titleAsc, postgres.MovieFilter{}, postgres.Page{Cursor: info.NextCursor})
+	require.ErrorIs(t, err, storage.ErrInvalidCursor)
+}
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS user_role;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS users;
+DROP TABLE IF EXISTS actor_movie;
+DROP TABLE IF EXISTS movies;
+DROP TABLE IF EXISTS actors;
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS actors(
+    actor_id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    gender VARCHAR(10),
+    birthdate DATE);
+
+CREATE TABLE IF NOT EXISTS movies(
+    movie_id SERIAL PRIMARY KEY,
+    title VARCHAR(150) NOT NULL,
+    description VARCHAR(1000) NOT NULL,
+    release_date DATE,
+    rating SMALLINT);
+
+CREATE TABLE IF NOT EXISTS actor_movie(
+    movie_id INTEGER REFERENCES movies(movie_id),
+    actor_id INTEGER REFERENCES actors(actor_id));
+
+CREATE TABLE IF NOT EXISTS users(
+    user_id SERIAL PRIMARY KEY,
+    username VARCHAR(255) NOT NULL,
+    password VARCHAR(255) NOT NULL);
+
+CREATE TABLE IF NOT EXISTS roles(
+    role_id SERIAL PRIMARY KEY,
+    role_name VARCHAR(255) NOT NULL);
+
+CREATE TABLE IF NOT EXISTS user_role(
+    user_id INTEGER REFERENCES users(user_id),
+    role_id INTEGER REFERENCES roles(role_id));
@@ -0,0 +1,14 @@
+CREATE TABLE refresh_tokens(
+    token_id BIGSERIAL PRIMARY KEY,
+    family_id UUID NOT NULL,
+    user_id INTEGER NOT NULL REFERENCES users(user_id),
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMPTZ NOT NULL,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now());
+
+CREATE INDEX refresh_tokens_family_id_idx ON refresh_tokens(family_id);
+
+CREATE TABLE revoked_access_tokens(
+    jti VARCHAR(64) PRIMARY KEY,
+    expires_at TIMESTAMPTZ NOT NULL);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS role_permission;
+DROP TABLE IF EXISTS permissions;
+
+DELETE FROM user_role WHERE role_id IN (SELECT role_id FROM roles WHERE role_name = 'editor');
+DELETE FROM roles WHERE role_name = 'editor';
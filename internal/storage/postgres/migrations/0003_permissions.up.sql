@@ -0,0 +1,27 @@
+CREATE TABLE permissions(
+    permission_id SERIAL PRIMARY KEY,
+    permission_name VARCHAR(255) NOT NULL UNIQUE);
+
+CREATE TABLE role_permission(
+    role_id INTEGER NOT NULL REFERENCES roles(role_id),
+    permission_id INTEGER NOT NULL REFERENCES permissions(permission_id),
+    PRIMARY KEY (role_id, permission_id));
+
+INSERT INTO permissions(permission_name) VALUES
+    ('movie:write'),
+    ('movie:delete'),
+    ('actor:write'),
+    ('actor:delete');
+
+INSERT INTO roles(role_name)
+SELECT r.role_name FROM (VALUES ('user'), ('editor'), ('admin')) AS r(role_name)
+WHERE NOT EXISTS (SELECT 1 FROM roles WHERE roles.role_name = r.role_name);
+
+-- admin gets everything, editor can create and update but not delete,
+-- user only reads, which needs no permission.
+INSERT INTO role_permission(role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r
+JOIN permissions p ON r.role_name = 'admin'
+    OR (r.role_name = 'editor' AND p.permission_name IN ('movie:write', 'actor:write'))
+ON CONFLICT DO NOTHING;
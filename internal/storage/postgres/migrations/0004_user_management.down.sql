@@ -0,0 +1,6 @@
+DELETE FROM role_permission WHERE permission_id IN (SELECT permission_id FROM permissions WHERE permission_name = 'user:manage');
+DELETE FROM permissions WHERE permission_name = 'user:manage';
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS password_reset_required,
+    DROP COLUMN IF EXISTS disabled_at;
@@ -0,0 +1,11 @@
+ALTER TABLE users
+    ADD COLUMN disabled_at TIMESTAMPTZ,
+    ADD COLUMN password_reset_required BOOLEAN NOT NULL DEFAULT false;
+
+INSERT INTO permissions(permission_name) VALUES ('user:manage');
+
+INSERT INTO role_permission(role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r, permissions p
+WHERE r.role_name = 'admin' AND p.permission_name = 'user:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS actor_movie_actor_id_idx;
+DROP INDEX IF EXISTS actor_movie_movie_id_idx;
@@ -0,0 +1,2 @@
+CREATE INDEX IF NOT EXISTS actor_movie_movie_id_idx ON actor_movie(movie_id);
+CREATE INDEX IF NOT EXISTS actor_movie_actor_id_idx ON actor_movie(actor_id);
@@ -0,0 +1,16 @@
+DROP INDEX IF EXISTS actors_name_trgm_idx;
+DROP INDEX IF EXISTS movies_title_trgm_idx;
+DROP INDEX IF EXISTS movies_search_vector_idx;
+
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+DROP TRIGGER IF EXISTS actor_movie_search_vector ON actor_movie;
+DROP TRIGGER IF EXISTS movies_search_vector ON movies;
+
+DROP FUNCTION IF EXISTS actors_search_vector_trigger();
+DROP FUNCTION IF EXISTS actor_movie_search_vector_trigger();
+DROP FUNCTION IF EXISTS movies_search_vector_trigger();
+DROP FUNCTION IF EXISTS movie_search_document(INTEGER, TEXT, TEXT);
+
+ALTER TABLE movies DROP COLUMN IF EXISTS search_vector;
+
+DROP TABLE IF EXISTS search_settings;
@@ -0,0 +1,78 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Single row holding the text search configuration used to build and query
+-- movies.search_vector. It is set from the search.language config on start.
+CREATE TABLE search_settings(
+    id BOOLEAN PRIMARY KEY DEFAULT true CHECK (id),
+    config REGCONFIG NOT NULL DEFAULT 'simple');
+
+INSERT INTO search_settings DEFAULT VALUES;
+
+ALTER TABLE movies ADD COLUMN search_vector TSVECTOR;
+
+CREATE FUNCTION movie_search_document(p_movie_id INTEGER, p_title TEXT, p_description TEXT) RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector(s.config, coalesce(p_title, '')), 'A')
+        || setweight(to_tsvector(s.config, coalesce((
+            SELECT string_agg(a.name, ' ')
+            FROM actor_movie am
+            JOIN actors a ON a.actor_id = am.actor_id
+            WHERE am.movie_id = p_movie_id), '')), 'B')
+        || setweight(to_tsvector(s.config, coalesce(p_description, '')), 'C')
+    FROM search_settings s
+$$ LANGUAGE sql STABLE;
+
+CREATE FUNCTION movies_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := movie_search_document(NEW.movie_id, NEW.title, NEW.description);
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movies_search_vector
+    BEFORE INSERT OR UPDATE OF title, description ON movies
+    FOR EACH ROW EXECUTE FUNCTION movies_search_vector_trigger();
+
+CREATE FUNCTION actor_movie_search_vector_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    changed INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD.movie_id;
+    ELSE
+        changed := NEW.movie_id;
+    END IF;
+
+    UPDATE movies SET search_vector = movie_search_document(movie_id, title, description)
+    WHERE movie_id = changed;
+
+    IF TG_OP = 'UPDATE' AND OLD.movie_id <> NEW.movie_id THEN
+        UPDATE movies SET search_vector = movie_search_document(movie_id, title, description)
+        WHERE movie_id = OLD.movie_id;
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER actor_movie_search_vector
+    AFTER INSERT OR UPDATE OR DELETE ON actor_movie
+    FOR EACH ROW EXECUTE FUNCTION actor_movie_search_vector_trigger();
+
+CREATE FUNCTION actors_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE movies SET search_vector = movie_search_document(movie_id, title, description)
+    WHERE movie_id IN (SELECT movie_id FROM actor_movie WHERE actor_id = NEW.actor_id);
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name ON actors
+    FOR EACH ROW EXECUTE FUNCTION actors_search_vector_trigger();
+
+UPDATE movies SET search_vector = movie_search_document(movie_id, title, description);
+
+CREATE INDEX movies_search_vector_idx ON movies USING GIN (search_vector);
+CREATE INDEX movies_title_trgm_idx ON movies USING GIN (title gin_trgm_ops);
+CREATE INDEX actors_name_trgm_idx ON actors USING GIN (name gin_trgm_ops);
@@ -0,0 +1,9 @@
+ALTER TABLE actors DROP CONSTRAINT IF EXISTS actors_gender_check;
+ALTER TABLE movies DROP CONSTRAINT IF EXISTS movies_rating_check;
+ALTER TABLE actors ALTER COLUMN birthdate DROP NOT NULL;
+ALTER TABLE movies ALTER COLUMN release_date DROP NOT NULL;
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_username_key;
+
+CREATE INDEX IF NOT EXISTS actor_movie_movie_id_idx ON actor_movie(movie_id);
+ALTER TABLE actor_movie DROP CONSTRAINT IF EXISTS actor_movie_pkey;
+ALTER TABLE actor_movie ALTER COLUMN movie_id DROP NOT NULL, ALTER COLUMN actor_id DROP NOT NULL;
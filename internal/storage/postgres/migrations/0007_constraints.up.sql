@@ -0,0 +1,35 @@
+DELETE FROM actor_movie WHERE movie_id IS NULL OR actor_id IS NULL;
+
+DELETE FROM actor_movie a USING actor_movie b
+WHERE a.ctid > b.ctid AND a.movie_id = b.movie_id AND a.actor_id = b.actor_id;
+
+-- the primary key index serves lookups by movie_id
+ALTER TABLE actor_movie ADD CONSTRAINT actor_movie_pkey PRIMARY KEY (movie_id, actor_id);
+DROP INDEX IF EXISTS actor_movie_movie_id_idx;
+
+-- the oldest account keeps a duplicate username, the others get their
+-- user_id appended, e.g. bob#42, to sign in with
+UPDATE users u SET username = left(u.username, 240) || '#' || u.user_id
+WHERE EXISTS(SELECT 1 FROM users o WHERE o.username = u.username AND o.user_id < u.user_id);
+
+ALTER TABLE users ADD CONSTRAINT users_username_key UNIQUE (username);
+
+-- lists order and page by the dates, so they must be set; unknown ones
+-- become 1900-01-01 to be corrected by hand
+UPDATE movies SET release_date = DATE '1900-01-01' WHERE release_date IS NULL;
+ALTER TABLE movies ALTER COLUMN release_date SET NOT NULL;
+UPDATE actors SET birthdate = DATE '1900-01-01' WHERE birthdate IS NULL;
+ALTER TABLE actors ALTER COLUMN birthdate SET NOT NULL;
+
+-- ratings were not checked before, out of range ones are clamped
+UPDATE movies SET rating = least(greatest(rating, 0), 10) WHERE rating NOT BETWEEN 0 AND 10;
+ALTER TABLE movies ADD CONSTRAINT movies_rating_check CHECK (rating BETWEEN 0 AND 10);
+
+-- gender was free text; spellings of male and female are normalized and
+-- anything else is cleared
+UPDATE actors SET gender = CASE
+        WHEN lower(trim(gender)) IN ('male', 'm') THEN 'male'
+        WHEN lower(trim(gender)) IN ('female', 'f') THEN 'female'
+    END
+WHERE gender NOT IN ('male', 'female');
+ALTER TABLE actors ADD CONSTRAINT actors_gender_check CHECK (gender IN ('male', 'female'));
@@ -0,0 +1,9 @@
+DROP TRIGGER IF EXISTS actor_movie_version ON actor_movie;
+DROP TRIGGER IF EXISTS actors_version ON actors;
+DROP TRIGGER IF EXISTS movies_version ON movies;
+
+DROP FUNCTION IF EXISTS actor_movie_version_trigger();
+DROP FUNCTION IF EXISTS bump_version_trigger();
+
+ALTER TABLE actors DROP COLUMN IF EXISTS version;
+ALTER TABLE movies DROP COLUMN IF EXISTS version;
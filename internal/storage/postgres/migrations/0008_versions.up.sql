@@ -0,0 +1,38 @@
+ALTER TABLE movies ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE actors ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- every update of a row, whatever the statement, moves it to the next version
+CREATE FUNCTION bump_version_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.version := OLD.version + 1;
+    RETURN NEW;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movies_version
+    BEFORE UPDATE ON movies
+    FOR EACH ROW EXECUTE FUNCTION bump_version_trigger();
+
+CREATE TRIGGER actors_version
+    BEFORE UPDATE ON actors
+    FOR EACH ROW EXECUTE FUNCTION bump_version_trigger();
+
+-- a cast change is a change of both the movie and the actor. The movie row
+-- is already updated by actor_movie_search_vector; touching the actor row
+-- here bumps its version.
+CREATE FUNCTION actor_movie_version_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        UPDATE actors SET version = version WHERE actor_id = OLD.actor_id;
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        UPDATE actors SET version = version WHERE actor_id = NEW.actor_id;
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER actor_movie_version
+    AFTER INSERT OR UPDATE OR DELETE ON actor_movie
+    FOR EACH ROW EXECUTE FUNCTION actor_movie_version_trigger();
@@ -0,0 +1,14 @@
+DELETE FROM role_permission WHERE permission_id IN (SELECT permission_id FROM permissions WHERE permission_name = 'audit:read');
+DELETE FROM permissions WHERE permission_name = 'audit:read';
+
+DROP TRIGGER IF EXISTS user_role_audit ON user_role;
+DROP TRIGGER IF EXISTS users_audit ON users;
+DROP TRIGGER IF EXISTS actor_movie_audit ON actor_movie;
+DROP TRIGGER IF EXISTS actors_audit ON actors;
+DROP TRIGGER IF EXISTS movies_audit ON movies;
+
+DROP FUNCTION IF EXISTS audit_trigger();
+
+DROP TABLE IF EXISTS audit_events;
+
+DROP FUNCTION IF EXISTS audit_events_append_only_trigger();
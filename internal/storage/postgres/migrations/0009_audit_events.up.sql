@@ -0,0 +1,133 @@
+-- append-only log of catalogue and user changes. user_id and request_id
+-- come from the audit.user_id and audit.request_id settings, which storage
+-- sets for its transaction; there are no foreign keys so that events
+-- outlive what they describe.
+CREATE TABLE audit_events(
+    event_id BIGSERIAL PRIMARY KEY,
+    occurred_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    user_id INTEGER,
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id INTEGER NOT NULL,
+    before JSONB,
+    after JSONB,
+    request_id VARCHAR(255));
+
+CREATE INDEX audit_events_entity_idx ON audit_events(entity_type, entity_id, event_id);
+CREATE INDEX audit_events_user_idx ON audit_events(user_id, event_id);
+CREATE INDEX audit_events_occurred_at_idx ON audit_events(occurred_at);
+
+CREATE FUNCTION audit_events_append_only_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_events is append-only';
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_events_append_only
+    BEFORE UPDATE OR DELETE ON audit_events
+    FOR EACH ROW EXECUTE FUNCTION audit_events_append_only_trigger();
+
+CREATE TRIGGER audit_events_no_truncate
+    BEFORE TRUNCATE ON audit_events
+    FOR EACH STATEMENT EXECUTE FUNCTION audit_events_append_only_trigger();
+
+-- audit_trigger(entity_type, id_column, insert_action, delete_action)
+-- records a row change as an event of the entity whose id is in
+-- id_column. Updates keep only the changed columns in before and after and
+-- are skipped when nothing but derived columns changed, e.g. the version
+-- bump of a cast change. Password hashes are never copied.
+CREATE FUNCTION audit_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    before_row JSONB;
+    after_row JSONB;
+    entity_row JSONB;
+    role_json JSONB;
+    key TEXT;
+    verb TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD) - 'search_vector' - 'version' - 'password';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW) - 'search_vector' - 'version' - 'password';
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        before_row := '{}';
+        after_row := '{}';
+        FOR key IN SELECT jsonb_object_keys(new_row) LOOP
+            IF old_row -> key IS DISTINCT FROM new_row -> key THEN
+                before_row := before_row || jsonb_build_object(key, old_row -> key);
+                after_row := after_row || jsonb_build_object(key, new_row -> key);
+            END IF;
+        END LOOP;
+
+        IF to_jsonb(OLD) -> 'password' IS DISTINCT FROM to_jsonb(NEW) -> 'password' THEN
+            after_row := after_row || '{"password": "changed"}';
+        END IF;
+
+        IF after_row = '{}' THEN
+            RETURN NULL;
+        END IF;
+
+        verb := 'update';
+    ELSIF TG_OP = 'INSERT' THEN
+        after_row := new_row;
+        verb := TG_ARGV[2];
+    ELSE
+        before_row := old_row;
+        verb := TG_ARGV[3];
+    END IF;
+
+    entity_row := COALESCE(new_row, old_row);
+
+    -- a role id means nothing to a reader of the log, a missing side stays null
+    IF TG_TABLE_NAME = 'user_role' THEN
+        role_json := jsonb_build_object('role',
+            (SELECT role_name FROM roles WHERE role_id = (entity_row ->> 'role_id')::int));
+        before_row := before_row || role_json;
+        after_row := after_row || role_json;
+    END IF;
+
+    INSERT INTO audit_events(user_id, action, entity_type, entity_id, before, after, request_id)
+    VALUES (NULLIF(current_setting('audit.user_id', true), '')::int,
+            TG_ARGV[0] || '.' || verb,
+            TG_ARGV[0],
+            (entity_row ->> TG_ARGV[1])::int,
+            before_row,
+            after_row,
+            NULLIF(current_setting('audit.request_id', true), ''));
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movies_audit
+    AFTER INSERT OR UPDATE OR DELETE ON movies
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'create', 'delete');
+
+CREATE TRIGGER actors_audit
+    AFTER INSERT OR UPDATE OR DELETE ON actors
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('actor', 'actor_id', 'create', 'delete');
+
+CREATE TRIGGER actor_movie_audit
+    AFTER INSERT OR DELETE ON actor_movie
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'cast_add', 'cast_remove');
+
+CREATE TRIGGER users_audit
+    AFTER INSERT OR UPDATE OR DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('user', 'user_id', 'create', 'delete');
+
+CREATE TRIGGER user_role_audit
+    AFTER INSERT OR DELETE ON user_role
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('user', 'user_id', 'role_grant', 'role_revoke');
+
+INSERT INTO permissions(permission_name) VALUES ('audit:read');
+
+INSERT INTO role_permission(role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r, permissions p
+WHERE r.role_name = 'admin' AND p.permission_name = 'audit:read'
+ON CONFLICT DO NOTHING;
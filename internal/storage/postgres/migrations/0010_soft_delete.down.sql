@@ -0,0 +1,110 @@
+DELETE FROM role_permission WHERE permission_id IN (SELECT permission_id FROM permissions WHERE permission_name = 'trash:manage');
+DELETE FROM permissions WHERE permission_name = 'trash:manage';
+
+-- the trash can not be kept without deleted_at
+DELETE FROM actor_movie WHERE movie_id IN (SELECT movie_id FROM movies WHERE deleted_at IS NOT NULL)
+    OR actor_id IN (SELECT actor_id FROM actors WHERE deleted_at IS NOT NULL);
+DELETE FROM movies WHERE deleted_at IS NOT NULL;
+DELETE FROM actors WHERE deleted_at IS NOT NULL;
+
+DROP TRIGGER IF EXISTS movies_audit ON movies;
+DROP TRIGGER IF EXISTS actors_audit ON actors;
+
+CREATE OR REPLACE FUNCTION audit_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    before_row JSONB;
+    after_row JSONB;
+    entity_row JSONB;
+    role_json JSONB;
+    key TEXT;
+    verb TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD) - 'search_vector' - 'version' - 'password';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW) - 'search_vector' - 'version' - 'password';
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        before_row := '{}';
+        after_row := '{}';
+        FOR key IN SELECT jsonb_object_keys(new_row) LOOP
+            IF old_row -> key IS DISTINCT FROM new_row -> key THEN
+                before_row := before_row || jsonb_build_object(key, old_row -> key);
+                after_row := after_row || jsonb_build_object(key, new_row -> key);
+            END IF;
+        END LOOP;
+
+        IF to_jsonb(OLD) -> 'password' IS DISTINCT FROM to_jsonb(NEW) -> 'password' THEN
+            after_row := after_row || '{"password": "changed"}';
+        END IF;
+
+        IF after_row = '{}' THEN
+            RETURN NULL;
+        END IF;
+
+        verb := 'update';
+    ELSIF TG_OP = 'INSERT' THEN
+        after_row := new_row;
+        verb := TG_ARGV[2];
+    ELSE
+        before_row := old_row;
+        verb := TG_ARGV[3];
+    END IF;
+
+    entity_row := COALESCE(new_row, old_row);
+
+    -- a role id means nothing to a reader of the log, a missing side stays null
+    IF TG_TABLE_NAME = 'user_role' THEN
+        role_json := jsonb_build_object('role',
+            (SELECT role_name FROM roles WHERE role_id = (entity_row ->> 'role_id')::int));
+        before_row := before_row || role_json;
+        after_row := after_row || role_json;
+    END IF;
+
+    INSERT INTO audit_events(user_id, action, entity_type, entity_id, before, after, request_id)
+    VALUES (NULLIF(current_setting('audit.user_id', true), '')::int,
+            TG_ARGV[0] || '.' || verb,
+            TG_ARGV[0],
+            (entity_row ->> TG_ARGV[1])::int,
+            before_row,
+            after_row,
+            NULLIF(current_setting('audit.request_id', true), ''));
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER movies_audit
+    AFTER INSERT OR UPDATE OR DELETE ON movies
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'create', 'delete');
+
+CREATE TRIGGER actors_audit
+    AFTER INSERT OR UPDATE OR DELETE ON actors
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('actor', 'actor_id', 'create', 'delete');
+
+DROP TRIGGER IF EXISTS actors_search_vector ON actors;
+
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name ON actors
+    FOR EACH ROW EXECUTE FUNCTION actors_search_vector_trigger();
+
+CREATE OR REPLACE FUNCTION movie_search_document(p_movie_id INTEGER, p_title TEXT, p_description TEXT) RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector(s.config, coalesce(p_title, '')), 'A')
+        || setweight(to_tsvector(s.config, coalesce((
+            SELECT string_agg(a.name, ' ')
+            FROM actor_movie am
+            JOIN actors a ON a.actor_id = am.actor_id
+            WHERE am.movie_id = p_movie_id), '')), 'B')
+        || setweight(to_tsvector(s.config, coalesce(p_description, '')), 'C')
+    FROM search_settings s
+$$ LANGUAGE sql STABLE;
+
+DROP INDEX IF EXISTS actors_deleted_at_idx;
+DROP INDEX IF EXISTS movies_deleted_at_idx;
+
+ALTER TABLE actors DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE movies DROP COLUMN IF EXISTS deleted_at;
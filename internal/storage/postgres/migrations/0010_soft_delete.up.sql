@@ -0,0 +1,117 @@
+-- deleted movies and actors stay in the trash, cast included, until they
+-- are restored or purged
+ALTER TABLE movies ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE actors ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX movies_deleted_at_idx ON movies(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX actors_deleted_at_idx ON actors(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- actors in the trash are not searchable by name
+CREATE OR REPLACE FUNCTION movie_search_document(p_movie_id INTEGER, p_title TEXT, p_description TEXT) RETURNS TSVECTOR AS $$
+    SELECT setweight(to_tsvector(s.config, coalesce(p_title, '')), 'A')
+        || setweight(to_tsvector(s.config, coalesce((
+            SELECT string_agg(a.name, ' ')
+            FROM actor_movie am
+            JOIN actors a ON a.actor_id = am.actor_id
+            WHERE am.movie_id = p_movie_id AND a.deleted_at IS NULL), '')), 'B')
+        || setweight(to_tsvector(s.config, coalesce(p_description, '')), 'C')
+    FROM search_settings s
+$$ LANGUAGE sql STABLE;
+
+DROP TRIGGER actors_search_vector ON actors;
+
+CREATE TRIGGER actors_search_vector
+    AFTER UPDATE OF name, deleted_at ON actors
+    FOR EACH ROW EXECUTE FUNCTION actors_search_vector_trigger();
+
+-- moving to and out of the trash is recorded as delete and restore, removing
+-- for good as purge
+CREATE OR REPLACE FUNCTION audit_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    before_row JSONB;
+    after_row JSONB;
+    entity_row JSONB;
+    role_json JSONB;
+    key TEXT;
+    verb TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD) - 'search_vector' - 'version' - 'password';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW) - 'search_vector' - 'version' - 'password';
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        before_row := '{}';
+        after_row := '{}';
+        FOR key IN SELECT jsonb_object_keys(new_row) LOOP
+            IF old_row -> key IS DISTINCT FROM new_row -> key THEN
+                before_row := before_row || jsonb_build_object(key, old_row -> key);
+                after_row := after_row || jsonb_build_object(key, new_row -> key);
+            END IF;
+        END LOOP;
+
+        IF to_jsonb(OLD) -> 'password' IS DISTINCT FROM to_jsonb(NEW) -> 'password' THEN
+            after_row := after_row || '{"password": "changed"}';
+        END IF;
+
+        IF after_row = '{}' THEN
+            RETURN NULL;
+        END IF;
+
+        verb := 'update';
+        IF after_row ? 'deleted_at' THEN
+            verb := CASE WHEN after_row -> 'deleted_at' = 'null' THEN 'restore' ELSE 'delete' END;
+        END IF;
+    ELSIF TG_OP = 'INSERT' THEN
+        after_row := new_row;
+        verb := TG_ARGV[2];
+    ELSE
+        before_row := old_row;
+        verb := TG_ARGV[3];
+    END IF;
+
+    entity_row := COALESCE(new_row, old_row);
+
+    -- a role id means nothing to a reader of the log, a missing side stays null
+    IF TG_TABLE_NAME = 'user_role' THEN
+        role_json := jsonb_build_object('role',
+            (SELECT role_name FROM roles WHERE role_id = (entity_row ->> 'role_id')::int));
+        before_row := before_row || role_json;
+        after_row := after_row || role_json;
+    END IF;
+
+    INSERT INTO audit_events(user_id, action, entity_type, entity_id, before, after, request_id)
+    VALUES (NULLIF(current_setting('audit.user_id', true), '')::int,
+            TG_ARGV[0] || '.' || verb,
+            TG_ARGV[0],
+            (entity_row ->> TG_ARGV[1])::int,
+            before_row,
+            after_row,
+            NULLIF(current_setting('audit.request_id', true), ''));
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER movies_audit ON movies;
+DROP TRIGGER actors_audit ON actors;
+
+CREATE TRIGGER movies_audit
+    AFTER INSERT OR UPDATE OR DELETE ON movies
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'create', 'purge');
+
+CREATE TRIGGER actors_audit
+    AFTER INSERT OR UPDATE OR DELETE ON actors
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('actor', 'actor_id', 'create', 'purge');
+
+INSERT INTO permissions(permission_name) VALUES ('trash:manage');
+
+INSERT INTO role_permission(role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r, permissions p
+WHERE r.role_name = 'admin' AND p.permission_name = 'trash:manage'
+ON CONFLICT DO NOTHING;
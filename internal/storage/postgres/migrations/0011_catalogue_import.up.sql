@@ -0,0 +1,7 @@
+INSERT INTO permissions(permission_name) VALUES ('catalogue:import');
+
+INSERT INTO role_permission(role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r, permissions p
+WHERE r.role_name = 'admin' AND p.permission_name = 'catalogue:import'
+ON CONFLICT DO NOTHING;
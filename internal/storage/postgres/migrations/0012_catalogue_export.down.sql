@@ -0,0 +1,2 @@
+DELETE FROM role_permission WHERE permission_id IN (SELECT permission_id FROM permissions WHERE permission_name = 'catalogue:export');
+DELETE FROM permissions WHERE permission_name = 'catalogue:export';
@@ -0,0 +1,14 @@
+DROP TABLE movie_tag;
+DROP TABLE movie_country;
+DROP TABLE movie_genre;
+
+ALTER TABLE movies
+    DROP COLUMN runtime,
+    DROP COLUMN original_language,
+    DROP COLUMN certification;
+
+DROP TABLE tags;
+DROP TABLE certifications;
+DROP TABLE languages;
+DROP TABLE countries;
+DROP TABLE genres;
@@ -0,0 +1,87 @@
+DROP TRIGGER actor_movie_audit ON actor_movie;
+
+CREATE TRIGGER actor_movie_audit
+    AFTER INSERT OR DELETE ON actor_movie
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'cast_add', 'cast_remove');
+
+CREATE OR REPLACE FUNCTION audit_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    before_row JSONB;
+    after_row JSONB;
+    entity_row JSONB;
+    role_json JSONB;
+    key TEXT;
+    verb TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD) - 'search_vector' - 'version' - 'password';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW) - 'search_vector' - 'version' - 'password';
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        before_row := '{}';
+        after_row := '{}';
+        FOR key IN SELECT jsonb_object_keys(new_row) LOOP
+            IF old_row -> key IS DISTINCT FROM new_row -> key THEN
+                before_row := before_row || jsonb_build_object(key, old_row -> key);
+                after_row := after_row || jsonb_build_object(key, new_row -> key);
+            END IF;
+        END LOOP;
+
+        IF to_jsonb(OLD) -> 'password' IS DISTINCT FROM to_jsonb(NEW) -> 'password' THEN
+            after_row := after_row || '{"password": "changed"}';
+        END IF;
+
+        IF after_row = '{}' THEN
+            RETURN NULL;
+        END IF;
+
+        verb := 'update';
+        IF after_row ? 'deleted_at' THEN
+            verb := CASE WHEN after_row -> 'deleted_at' = 'null' THEN 'restore' ELSE 'delete' END;
+        END IF;
+    ELSIF TG_OP = 'INSERT' THEN
+        after_row := new_row;
+        verb := TG_ARGV[2];
+    ELSE
+        before_row := old_row;
+        verb := TG_ARGV[3];
+    END IF;
+
+    entity_row := COALESCE(new_row, old_row);
+
+    -- a role id means nothing to a reader of the log, a missing side stays null
+    IF TG_TABLE_NAME = 'user_role' THEN
+        role_json := jsonb_build_object('role',
+            (SELECT role_name FROM roles WHERE role_id = (entity_row ->> 'role_id')::int));
+        before_row := before_row || role_json;
+        after_row := after_row || role_json;
+    END IF;
+
+    INSERT INTO audit_events(user_id, action, entity_type, entity_id, before, after, request_id)
+    VALUES (NULLIF(current_setting('audit.user_id', true), '')::int,
+            TG_ARGV[0] || '.' || verb,
+            TG_ARGV[0],
+            (entity_row ->> TG_ARGV[1])::int,
+            before_row,
+            after_row,
+            NULLIF(current_setting('audit.request_id', true), ''));
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+-- only the acting credits fit the old key
+DELETE FROM actor_movie WHERE credit_type <> 'actor';
+
+ALTER TABLE actor_movie DROP CONSTRAINT actor_movie_pkey;
+ALTER TABLE actor_movie ADD CONSTRAINT actor_movie_pkey PRIMARY KEY (movie_id, actor_id);
+
+ALTER TABLE actor_movie
+    DROP COLUMN credit_type,
+    DROP COLUMN character_name,
+    DROP COLUMN billing_order;
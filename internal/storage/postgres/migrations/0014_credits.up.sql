@@ -0,0 +1,101 @@
+-- a person can have several credits in a movie, e.g. direct it and play in
+-- it, so the credit type is part of the key. Existing links are actors.
+ALTER TABLE actor_movie
+    ADD COLUMN credit_type VARCHAR(20) NOT NULL DEFAULT 'actor',
+    ADD COLUMN character_name VARCHAR(150),
+    ADD COLUMN billing_order INTEGER;
+
+ALTER TABLE actor_movie
+    ADD CONSTRAINT actor_movie_credit_type_check
+        CHECK (credit_type IN ('actor', 'director', 'writer', 'producer', 'composer')),
+    ADD CONSTRAINT actor_movie_character_name_check CHECK (character_name IS NULL OR credit_type = 'actor'),
+    ADD CONSTRAINT actor_movie_billing_order_check CHECK (billing_order BETWEEN 1 AND 10000);
+
+ALTER TABLE actor_movie DROP CONSTRAINT actor_movie_pkey;
+ALTER TABLE actor_movie ADD CONSTRAINT actor_movie_pkey PRIMARY KEY (movie_id, actor_id, credit_type);
+
+-- audit_trigger(entity_type, id_column, insert_action, delete_action[, update_action])
+CREATE OR REPLACE FUNCTION audit_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    old_row JSONB;
+    new_row JSONB;
+    before_row JSONB;
+    after_row JSONB;
+    entity_row JSONB;
+    role_json JSONB;
+    credit_json JSONB;
+    key TEXT;
+    verb TEXT;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD) - 'search_vector' - 'version' - 'password';
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW) - 'search_vector' - 'version' - 'password';
+    END IF;
+
+    IF TG_OP = 'UPDATE' THEN
+        before_row := '{}';
+        after_row := '{}';
+        FOR key IN SELECT jsonb_object_keys(new_row) LOOP
+            IF old_row -> key IS DISTINCT FROM new_row -> key THEN
+                before_row := before_row || jsonb_build_object(key, old_row -> key);
+                after_row := after_row || jsonb_build_object(key, new_row -> key);
+            END IF;
+        END LOOP;
+
+        IF to_jsonb(OLD) -> 'password' IS DISTINCT FROM to_jsonb(NEW) -> 'password' THEN
+            after_row := after_row || '{"password": "changed"}';
+        END IF;
+
+        IF after_row = '{}' THEN
+            RETURN NULL;
+        END IF;
+
+        verb := COALESCE(TG_ARGV[4], 'update');
+        IF after_row ? 'deleted_at' THEN
+            verb := CASE WHEN after_row -> 'deleted_at' = 'null' THEN 'restore' ELSE 'delete' END;
+        END IF;
+    ELSIF TG_OP = 'INSERT' THEN
+        after_row := new_row;
+        verb := TG_ARGV[2];
+    ELSE
+        before_row := old_row;
+        verb := TG_ARGV[3];
+    END IF;
+
+    entity_row := COALESCE(new_row, old_row);
+
+    -- a role id means nothing to a reader of the log, a missing side stays null
+    IF TG_TABLE_NAME = 'user_role' THEN
+        role_json := jsonb_build_object('role',
+            (SELECT role_name FROM roles WHERE role_id = (entity_row ->> 'role_id')::int));
+        before_row := before_row || role_json;
+        after_row := after_row || role_json;
+    END IF;
+
+    -- a changed credit names whose credit it is
+    IF TG_TABLE_NAME = 'actor_movie' AND TG_OP = 'UPDATE' THEN
+        credit_json := jsonb_build_object('actor_id', entity_row -> 'actor_id', 'credit_type', entity_row -> 'credit_type');
+        before_row := before_row || credit_json;
+        after_row := after_row || credit_json;
+    END IF;
+
+    INSERT INTO audit_events(user_id, action, entity_type, entity_id, before, after, request_id)
+    VALUES (NULLIF(current_setting('audit.user_id', true), '')::int,
+            TG_ARGV[0] || '.' || verb,
+            TG_ARGV[0],
+            (entity_row ->> TG_ARGV[1])::int,
+            before_row,
+            after_row,
+            NULLIF(current_setting('audit.request_id', true), ''));
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER actor_movie_audit ON actor_movie;
+
+CREATE TRIGGER actor_movie_audit
+    AFTER INSERT OR UPDATE OR DELETE ON actor_movie
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'cast_add', 'cast_remove', 'cast_update');
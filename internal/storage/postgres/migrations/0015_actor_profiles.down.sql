@@ -0,0 +1,18 @@
+DROP TABLE actor_external_ids;
+DROP TABLE actor_aliases;
+
+ALTER TABLE actors
+    DROP COLUMN biography,
+    DROP COLUMN birthplace,
+    DROP COLUMN nationality,
+    DROP COLUMN death_date;
+
+ALTER TABLE actors DROP CONSTRAINT actors_gender_check;
+ALTER TABLE actors
+    ALTER COLUMN gender DROP NOT NULL,
+    ALTER COLUMN gender DROP DEFAULT;
+
+-- the old check only knows male and female
+UPDATE actors SET gender = NULL WHERE gender NOT IN ('male', 'female');
+
+ALTER TABLE actors ADD CONSTRAINT actors_gender_check CHECK (gender IN ('male', 'female'));
@@ -0,0 +1,39 @@
+-- gender is required; actors saved without one are unspecified
+UPDATE actors SET gender = 'unspecified' WHERE gender IS NULL;
+
+ALTER TABLE actors DROP CONSTRAINT actors_gender_check;
+ALTER TABLE actors
+    ALTER COLUMN gender SET DEFAULT 'unspecified',
+    ALTER COLUMN gender SET NOT NULL,
+    ADD CONSTRAINT actors_gender_check CHECK (gender IN ('male', 'female', 'other', 'unspecified'));
+
+ALTER TABLE actors
+    ADD COLUMN biography VARCHAR(5000),
+    ADD COLUMN birthplace VARCHAR(255),
+    ADD COLUMN nationality CHAR(2) CONSTRAINT actors_nationality_fkey REFERENCES countries(country_code),
+    ADD COLUMN death_date DATE CONSTRAINT actors_death_date_check CHECK (death_date >= birthdate);
+
+CREATE TABLE actor_aliases(
+    actor_id INTEGER NOT NULL CONSTRAINT actor_aliases_actor_id_fkey REFERENCES actors(actor_id) ON DELETE CASCADE,
+    alias VARCHAR(255) NOT NULL,
+    PRIMARY KEY (actor_id, alias));
+
+-- aliases are found by movie search like names
+CREATE INDEX actor_aliases_alias_trgm_idx ON actor_aliases USING GIN (alias gin_trgm_ops);
+
+-- ids of the actor in other databases, one per source, each naming a
+-- single actor
+CREATE TABLE actor_external_ids(
+    actor_id INTEGER NOT NULL CONSTRAINT actor_external_ids_actor_id_fkey REFERENCES actors(actor_id) ON DELETE CASCADE,
+    source VARCHAR(20) NOT NULL CONSTRAINT actor_external_ids_source_check CHECK (source IN ('imdb', 'wikidata', 'tmdb')),
+    external_id VARCHAR(50) NOT NULL,
+    PRIMARY KEY (actor_id, source),
+    CONSTRAINT actor_external_ids_source_external_id_key UNIQUE (source, external_id));
+
+CREATE TRIGGER actor_aliases_audit
+    AFTER INSERT OR DELETE ON actor_aliases
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('actor', 'actor_id', 'alias_add', 'alias_remove');
+
+CREATE TRIGGER actor_external_ids_audit
+    AFTER INSERT OR DELETE ON actor_external_ids
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('actor', 'actor_id', 'external_id_add', 'external_id_remove');
@@ -0,0 +1,23 @@
+DELETE FROM role_permission WHERE permission_id IN
+    (SELECT permission_id FROM permissions WHERE permission_name IN ('review:write', 'review:moderate'));
+DELETE FROM permissions WHERE permission_name IN ('review:write', 'review:moderate');
+
+DROP TABLE reviews;
+DROP FUNCTION movie_score_trigger();
+
+DROP TRIGGER movies_audit_update ON movies;
+DROP TRIGGER movies_audit ON movies;
+DROP TRIGGER movies_version ON movies;
+
+CREATE TRIGGER movies_version
+    BEFORE UPDATE ON movies
+    FOR EACH ROW EXECUTE FUNCTION bump_version_trigger();
+
+CREATE TRIGGER movies_audit
+    AFTER INSERT OR UPDATE OR DELETE ON movies
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'create', 'purge');
+
+DROP INDEX movies_community_score_idx;
+ALTER TABLE movies
+    DROP COLUMN community_score,
+    DROP COLUMN vote_count;
@@ -0,0 +1,81 @@
+-- a user scores a movie once, with an optional text. Hidden reviews are
+-- taken down by a moderator and count for nothing.
+CREATE TABLE reviews(
+    review_id SERIAL PRIMARY KEY,
+    movie_id INTEGER NOT NULL CONSTRAINT reviews_movie_id_fkey REFERENCES movies(movie_id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL CONSTRAINT reviews_user_id_fkey REFERENCES users(user_id) ON DELETE CASCADE,
+    score SMALLINT NOT NULL CONSTRAINT reviews_score_check CHECK (score BETWEEN 1 AND 10),
+    body VARCHAR(5000),
+    status VARCHAR(10) NOT NULL DEFAULT 'published' CONSTRAINT reviews_status_check CHECK (status IN ('published', 'hidden')),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT reviews_movie_id_user_id_key UNIQUE (movie_id, user_id));
+
+CREATE INDEX reviews_movie_idx ON reviews(movie_id, review_id) WHERE status = 'published';
+CREATE INDEX reviews_user_id_idx ON reviews(user_id);
+
+-- the average score of the published reviews and their count
+ALTER TABLE movies
+    ADD COLUMN community_score NUMERIC(4, 2),
+    ADD COLUMN vote_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX movies_community_score_idx ON movies((COALESCE(community_score, 0)), vote_count, movie_id) WHERE deleted_at IS NULL;
+
+CREATE FUNCTION movie_score_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    id INTEGER;
+BEGIN
+    FOR id IN SELECT DISTINCT m FROM unnest(ARRAY[
+        CASE WHEN TG_OP <> 'INSERT' THEN OLD.movie_id END,
+        CASE WHEN TG_OP <> 'DELETE' THEN NEW.movie_id END]) AS m WHERE m IS NOT NULL
+    LOOP
+        UPDATE movies m SET community_score = s.score, vote_count = s.votes
+        FROM (SELECT round(avg(score), 2) AS score, count(*) AS votes
+              FROM reviews WHERE movie_id = id AND status = 'published') s
+        WHERE m.movie_id = id
+          AND (m.community_score IS DISTINCT FROM s.score OR m.vote_count <> s.votes);
+    END LOOP;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reviews_score
+    AFTER INSERT OR DELETE OR UPDATE OF movie_id, score, status ON reviews
+    FOR EACH ROW EXECUTE FUNCTION movie_score_trigger();
+
+-- a vote is not a change of the movie: it keeps its version, so that it
+-- does not fail the If-Match of an editor, and is not audited as a movie
+-- update
+DROP TRIGGER movies_version ON movies;
+DROP TRIGGER movies_audit ON movies;
+
+CREATE TRIGGER movies_version
+    BEFORE UPDATE ON movies
+    FOR EACH ROW
+    WHEN (OLD.community_score IS NOT DISTINCT FROM NEW.community_score AND OLD.vote_count = NEW.vote_count)
+    EXECUTE FUNCTION bump_version_trigger();
+
+CREATE TRIGGER movies_audit
+    AFTER INSERT OR DELETE ON movies
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'create', 'purge');
+
+CREATE TRIGGER movies_audit_update
+    AFTER UPDATE ON movies
+    FOR EACH ROW
+    WHEN (OLD.community_score IS NOT DISTINCT FROM NEW.community_score AND OLD.vote_count = NEW.vote_count)
+    EXECUTE FUNCTION audit_trigger('movie', 'movie_id', 'create', 'purge');
+
+CREATE TRIGGER reviews_audit
+    AFTER INSERT OR UPDATE OR DELETE ON reviews
+    FOR EACH ROW EXECUTE FUNCTION audit_trigger('review', 'review_id', 'create', 'delete');
+
+INSERT INTO permissions(permission_name) VALUES ('review:write'), ('review:moderate');
+
+-- every role reviews, only admins moderate
+INSERT INTO role_permission(role_id, permission_id)
+SELECT r.role_id, p.permission_id
+FROM roles r, permissions p
+WHERE (r.role_name IN ('user', 'editor', 'admin') AND p.permission_name = 'review:write')
+    OR (r.role_name = 'admin' AND p.permission_name = 'review:moderate')
+ON CONFLICT DO NOTHING;
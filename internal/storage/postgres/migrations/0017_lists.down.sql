@@ -0,0 +1,5 @@
+DROP TRIGGER users_lists ON users;
+DROP FUNCTION user_lists_trigger();
+
+DROP TABLE list_items;
+DROP TABLE user_lists;
@@ -0,0 +1,47 @@
+-- personal movie lists. Every user has a watchlist, favourites and watched
+-- list of their own and any number of named custom lists. A list shared by
+-- its owner is readable by anyone who knows its share_token.
+CREATE TABLE user_lists(
+    list_id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL CONSTRAINT user_lists_user_id_fkey REFERENCES users(user_id) ON DELETE CASCADE,
+    kind VARCHAR(10) NOT NULL DEFAULT 'custom'
+        CONSTRAINT user_lists_kind_check CHECK (kind IN ('watchlist', 'favourites', 'watched', 'custom')),
+    name VARCHAR(100) NOT NULL CONSTRAINT user_lists_name_check CHECK (btrim(name) <> ''),
+    share_token VARCHAR(64) CONSTRAINT user_lists_share_token_key UNIQUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT user_lists_user_id_name_key UNIQUE (user_id, name));
+
+CREATE UNIQUE INDEX user_lists_user_id_kind_idx ON user_lists(user_id, kind) WHERE kind <> 'custom';
+
+-- position orders the items of a list, watched_at is set on the watched
+-- list only
+CREATE TABLE list_items(
+    list_id INTEGER NOT NULL CONSTRAINT list_items_list_id_fkey REFERENCES user_lists(list_id) ON DELETE CASCADE,
+    movie_id INTEGER NOT NULL CONSTRAINT list_items_movie_id_fkey REFERENCES movies(movie_id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    watched_at DATE,
+    added_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    CONSTRAINT list_items_pkey PRIMARY KEY (list_id, movie_id));
+
+CREATE INDEX list_items_list_id_position_idx ON list_items(list_id, position);
+CREATE INDEX list_items_movie_id_idx ON list_items(movie_id);
+
+CREATE FUNCTION user_lists_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    INSERT INTO user_lists(user_id, kind, name)
+    VALUES (NEW.user_id, 'watchlist', 'Watchlist'),
+           (NEW.user_id, 'favourites', 'Favourites'),
+           (NEW.user_id, 'watched', 'Watched');
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_lists
+    AFTER INSERT ON users
+    FOR EACH ROW EXECUTE FUNCTION user_lists_trigger();
+
+INSERT INTO user_lists(user_id, kind, name)
+SELECT u.user_id, l.kind, l.name
+FROM users u,
+     (VALUES ('watchlist', 'Watchlist'), ('favourites', 'Favourites'), ('watched', 'Watched')) AS l(kind, name);
@@ -0,0 +1,5 @@
+-- access tokens of a user issued before revoked_before are no longer
+-- accepted, e.g. once the user is disabled or loses a role
+CREATE TABLE user_token_revocations(
+    user_id INTEGER PRIMARY KEY CONSTRAINT user_token_revocations_user_id_fkey REFERENCES users(user_id) ON DELETE CASCADE,
+    revoked_before TIMESTAMPTZ NOT NULL);
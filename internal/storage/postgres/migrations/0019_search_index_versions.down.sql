@@ -0,0 +1,38 @@
+CREATE OR REPLACE FUNCTION actors_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE movies SET search_vector = movie_search_document(movie_id, title, description)
+    WHERE movie_id IN (SELECT movie_id FROM actor_movie WHERE actor_id = NEW.actor_id);
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION actor_movie_search_vector_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    changed INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD.movie_id;
+    ELSE
+        changed := NEW.movie_id;
+    END IF;
+
+    UPDATE movies SET search_vector = movie_search_document(movie_id, title, description)
+    WHERE movie_id = changed;
+
+    IF TG_OP = 'UPDATE' AND OLD.movie_id <> NEW.movie_id THEN
+        UPDATE movies SET search_vector = movie_search_document(movie_id, title, description)
+        WHERE movie_id = OLD.movie_id;
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER movies_version ON movies;
+
+CREATE TRIGGER movies_version
+    BEFORE UPDATE ON movies
+    FOR EACH ROW
+    WHEN (OLD.community_score IS NOT DISTINCT FROM NEW.community_score AND OLD.vote_count = NEW.vote_count)
+    EXECUTE FUNCTION bump_version_trigger();
@@ -0,0 +1,46 @@
+-- rebuilding the search index is not a change of the movie: an update that
+-- only refreshes search_vector keeps the version, so that it does not fail
+-- the If-Match of an editor. A new title or description changes
+-- search_vector too and still moves the movie on.
+DROP TRIGGER movies_version ON movies;
+
+CREATE TRIGGER movies_version
+    BEFORE UPDATE ON movies
+    FOR EACH ROW
+    WHEN (OLD.community_score IS NOT DISTINCT FROM NEW.community_score AND OLD.vote_count = NEW.vote_count
+        AND NOT (OLD.search_vector IS DISTINCT FROM NEW.search_vector
+            AND OLD.title = NEW.title AND OLD.description IS NOT DISTINCT FROM NEW.description))
+    EXECUTE FUNCTION bump_version_trigger();
+
+-- a cast change or a renamed actor still changes the movie, so these
+-- refreshes move it to the next version themselves
+CREATE OR REPLACE FUNCTION actor_movie_search_vector_trigger() RETURNS TRIGGER AS $$
+DECLARE
+    changed INTEGER;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed := OLD.movie_id;
+    ELSE
+        changed := NEW.movie_id;
+    END IF;
+
+    UPDATE movies SET search_vector = movie_search_document(movie_id, title, description), version = version + 1
+    WHERE movie_id = changed;
+
+    IF TG_OP = 'UPDATE' AND OLD.movie_id <> NEW.movie_id THEN
+        UPDATE movies SET search_vector = movie_search_document(movie_id, title, description), version = version + 1
+        WHERE movie_id = OLD.movie_id;
+    END IF;
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION actors_search_vector_trigger() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE movies SET search_vector = movie_search_document(movie_id, title, description), version = version + 1
+    WHERE movie_id IN (SELECT movie_id FROM actor_movie WHERE actor_id = NEW.actor_id);
+
+    RETURN NULL;
+END
+$$ LANGUAGE plpgsql;
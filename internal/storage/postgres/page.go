@@ -0,0 +1,143 @@
+package postgres
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"film_library/internal/storage"
+	"fmt"
+	"strings"
+)
+
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+// Page selects a slice of a listing. Cursor is the NextCursor of the
+// previous page, empty for the first one.
+type Page struct {
+	Limit     int
+	Cursor    string
+	WithTotal bool
+}
+
+type PageInfo struct {
+	NextCursor string `json:"next_cursor,omitempty"`
+	Total      *int   `json:"total,omitempty"`
+}
+
+func (p Page) limit() int {
+	if p.Limit <= 0 {
+		return DefaultPageLimit
+	}
+	if p.Limit > MaxPageLimit {
+		return MaxPageLimit
+	}
+	return p.Limit
+}
+
+// sortKey is one column of a keyset ordering. The last key of an ordering
+// must be unique so that every row has a distinct position.
+type sortKey struct {
+	column string
+	desc   bool
+	// param wraps the placeholder, for values that are sent as text and
+	// cast on the server, e.g. "%s::text::date".
+	param string
+}
+
+type keyset []sortKey
+
+func (k keyset) orderBy() string {
+	parts := make([]string, len(k))
+	for i, key := range k {
+		dir := "ASC"
+		if key.desc {
+			dir = "DESC"
+		}
+		parts[i] = key.column + " " + dir
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// after returns a condition matching rows that come after the row whose
+// key values are bound to $firstArg, $firstArg+1, ... It is spelled as
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ... so that keys may mix sort
+// directions.
+func (k keyset) after(firstArg int) string {
+	placeholder := func(i int) string {
+		p := fmt.Sprintf("$%d", firstArg+i)
+		if k[i].param != "" {
+			p = fmt.Sprintf(k[i].param, p)
+		}
+		return p
+	}
+
+	var or []string
+	for i, key := range k {
+		var and []string
+		for j := 0; j < i; j++ {
+			and = append(and, fmt.Sprintf("%s = %s", k[j].column, placeholder(j)))
+		}
+
+		op := ">"
+		if key.desc {
+			op = "<"
+		}
+		and = append(and, fmt.Sprintf("%s %s %s", key.column, op, placeholder(i)))
+
+		or = append(or, "("+strings.Join(and, " AND ")+")")
+	}
+
+	return "(" + strings.Join(or, " OR ") + ")"
+}
+
+type cursor struct {
+	Sort   string        `json:"s"`
+	Values []interface{} `json:"v"`
+}
+
+// encodeCursor returns an opaque cursor positioned after a row with the
+// given key values. sort names the ordering so a cursor cannot be replayed
+// against a different one.
+func encodeCursor(sort string, values ...interface{}) (string, error) {
+	b, err := json.Marshal(cursor{Sort: sort, Values: values})
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func decodeCursor(s string, sort string, keys int) ([]interface{}, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, storage.ErrInvalidCursor
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+
+	var c cursor
+	if err = dec.Decode(&c); err != nil || c.Sort != sort || len(c.Values) != keys {
+		return nil, storage.ErrInvalidCursor
+	}
+
+	for i, v := range c.Values {
+		switch v := v.(type) {
+		case json.Number:
+			n, err := v.Int64()
+			if err != nil {
+				return nil, storage.ErrInvalidCursor
+			}
+			c.Values[i] = n
+		case string:
+		default:
+			return nil, storage.ErrInvalidCursor
+		}
+	}
+
+	return c.Values, nil
+}
@@ -0,0 +1,85 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+)
+
+func TestKeysetAfter(t *testing.T) {
+	cases := []struct {
+		name    string
+		keys    keyset
+		first   int
+		where   string
+		orderBy string
+	}{
+		{
+			name:    "Single key",
+			keys:    keyset{{column: "actor_id"}},
+			first:   1,
+			where:   "((actor_id > $1))",
+			orderBy: "actor_id ASC",
+		},
+		{
+			name:    "Descending with tiebreaker",
+			keys:    keyset{{column: "rating", desc: true}, {column: "movie_id", desc: true}},
+			first:   2,
+			where:   "((rating < $2) OR (rating = $2 AND movie_id < $3))",
+			orderBy: "rating DESC, movie_id DESC",
+		},
+		{
+			name: "Mixed directions with cast",
+			keys: keyset{
+				{column: "release_date", param: "%s::text::date"},
+				{column: "title", desc: true},
+				{column: "movie_id"},
+			},
+			first: 1,
+			where: "((release_date > $1::text::date)" +
+				" OR (release_date = $1::text::date AND title < $2)" +
+				" OR (release_date = $1::text::date AND title = $2 AND movie_id > $3))",
+			orderBy: "release_date ASC, title DESC, movie_id ASC",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			require.Equal(t, tc.where, tc.keys.after(tc.first))
+			require.Equal(t, tc.orderBy, tc.keys.orderBy())
+		})
+	}
+}
+
+func TestCursor(t *testing.T) {
+	encoded, err := encodeCursor(OrderByTitleAsc, "Heat", 42)
+	require.NoError(t, err)
+
+	values, err := decodeCursor(encoded, OrderByTitleAsc, 2)
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{"Heat", int64(42)}, values)
+
+	_, err = decodeCursor(encoded, OrderByRatingDesc, 2)
+	require.ErrorIs(t, err, storage.ErrInvalidCursor, "cursor of another ordering")
+
+	_, err = decodeCursor(encoded, OrderByTitleAsc, 1)
+	require.ErrorIs(t, err, storage.ErrInvalidCursor, "wrong number of keys")
+
+	_, err = decodeCursor("not a cursor", OrderByTitleAsc, 2)
+	require.ErrorIs(t, err, storage.ErrInvalidCursor)
+
+	fractional, err := encodeCursor(OrderByRatingAsc, 1.5, 1)
+	require.NoError(t, err)
+	_, err = decodeCursor(fractional, OrderByRatingAsc, 2)
+	require.ErrorIs(t, err, storage.ErrInvalidCursor)
+}
+
+func TestPageLimit(t *testing.T) {
+	require.Equal(t, DefaultPageLimit, Page{}.limit())
+	require.Equal(t, 10, Page{Limit: 10}.limit())
+	require.Equal(t, MaxPageLimit, Page{Limit: MaxPageLimit + 1}.limit())
+}
@@ -1,10 +1,17 @@
 package postgres
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"film_library/internal/lib/password"
 	"film_library/internal/storage"
+	"film_library/internal/storage/migrator"
+	"film_library/internal/storage/postgres/migrations"
 	"fmt"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"strconv"
+	"strings"
 )
 
 type Storage struct {
@@ -18,6 +25,16 @@ type Movie struct {
 	ReleaseDate string `json:"release_date"`
 	Rating      int    `json:"rating"`
 	Actors      []int  `json:"actors"`
+	Version     int    `json:"version"`
+	// CommunityScore is the average score of the users' reviews, nil
+	// until the first one
+	CommunityScore *float64 `json:"community_score"`
+	VoteCount      int      `json:"vote_count"`
+	MovieDetails
+	Cast []Credit `json:"cast,omitempty"`
+	Crew []Credit `json:"crew,omitempty"`
+	// Lists is set for a signed-in caller only
+	Lists *ListFlags `json:"lists,omitempty"`
 }
 
 type Actor struct {
@@ -26,8 +43,46 @@ type Actor struct {
 	Gender    string `json:"gender"`
 	Birthdate string `json:"birthdate"`
 	Movies    []int  `json:"movies"`
+	Version   int    `json:"version"`
+	ActorProfile
+	Age *int `json:"age"`
 }
 
+// MoviePatch holds the movie fields to change, nil fields are kept. Zero
+// details clear them, so do empty lists, which replace the whole list.
+type MoviePatch struct {
+	Title            *string
+	Description      *string
+	ReleaseDate      *string
+	Rating           *int
+	Runtime          *int
+	OriginalLanguage *string
+	Certification    *string
+	Genres           []string
+	Countries        []string
+	Tags             []string
+}
+
+// ActorPatch holds the actor fields to change, nil fields are kept. Empty
+// profile fields clear them, so do empty lists and maps, which replace the
+// whole list or map.
+type ActorPatch struct {
+	Name        *string
+	Gender      *string
+	Birthdate   *string
+	Biography   *string
+	Birthplace  *string
+	Nationality *string
+	DeathDate   *string
+	Aliases     []string
+	ExternalIds map[string]string
+}
+
+const (
+	RoleUser  = "user"
+	RoleAdmin = "admin"
+)
+
 const (
 	OrderByTitleAsc        = "title_asc"
 	OrderByTitleDesc       = "title_desc"
@@ -35,6 +90,9 @@ const (
 	OrderByReleaseDateDesc = "release_date_desc"
 	OrderByRatingAsc       = "rating_asc"
 	OrderByRatingDesc      = "rating_desc"
+
+	OrderByCommunityScoreAsc  = "community_score_asc"
+	OrderByCommunityScoreDesc = "community_score_desc"
 )
 
 func New(dbUrl string) (*Storage, error) {
@@ -45,179 +103,159 @@ func New(dbUrl string) (*Storage, error) {
 		return nil, fmt.Errorf("%s : %w", op, err)
 	}
 
-	stmt, err := db.Prepare(`
-	CREATE TABLE IF NOT EXISTS actors(
-	    actor_id SERIAL PRIMARY KEY,
-	    name VARCHAR(255) NOT NULL,
-	    gender VARCHAR(10),
-		birthdate DATE);
-	`)
-	if err != nil {
-		return nil, fmt.Errorf("%s : %w", op, err)
-	}
-
-	_, err = stmt.Exec()
+	m, err := migrator.New(db, migrations.FS)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	stmt, err = db.Prepare(`
-	CREATE TABLE IF NOT EXISTS movies(
-	    movie_id SERIAL PRIMARY KEY,
-	    title VARCHAR(150) NOT NULL,
-	    description VARCHAR(1000) NOT NULL,
-	    release_date DATE,
-	    rating SMALLINT);
-	`)
-	if err != nil {
-		return nil, fmt.Errorf("%s : %w", op, err)
-	}
-
-	_, err = stmt.Exec()
+	_, err = m.Up()
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	stmt, err = db.Prepare(`
-	CREATE TABLE IF NOT EXISTS actor_movie(
-	    movie_id INTEGER REFERENCES movies(movie_id),
-	    actor_id INTEGER REFERENCES actors(actor_id));
-	`)
-	if err != nil {
-		return nil, fmt.Errorf("%s : %w", op, err)
-	}
-
-	_, err = stmt.Exec()
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+	return &Storage{Db: db}, nil
+}
 
-	stmt, err = db.Prepare(`
-	CREATE TABLE IF NOT EXISTS users(
-	    user_id SERIAL PRIMARY KEY,
-	    username VARCHAR(255) NOT NULL,
-	    password VARCHAR(255) NOT NULL);
-	`)
-	if err != nil {
-		return nil, fmt.Errorf("%s : %w", op, err)
-	}
+// SaveUser creates a user with the user role. A taken username is reported
+// as storage.ErrUserExists by the unique constraint on users.username.
+func (s *Storage) SaveUser(ctx context.Context, username string, plainPassword string) error {
+	const op = "storage.postgres.SaveUser"
 
-	_, err = stmt.Exec()
+	hash, err := password.Hash(plainPassword)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	stmt, err = db.Prepare(`
-	CREATE TABLE IF NOT EXISTS roles(
-	    role_id SERIAL PRIMARY KEY,
-	    role_name VARCHAR(255) NOT NULL);
-	`)
-	if err != nil {
-		return nil, fmt.Errorf("%s : %w", op, err)
-	}
+	err = s.WithTx(ctx, func(tx *sql.Tx) error {
+		var userId int
+		err := tx.QueryRow("INSERT INTO users(username, password) VALUES ($1, $2) RETURNING user_id",
+			username, hash).Scan(&userId)
+		if err != nil {
+			return mapError(err)
+		}
 
-	_, err = stmt.Exec()
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
-	}
+		res, err := tx.Exec("INSERT INTO user_role(user_id, role_id) SELECT $1, role_id FROM roles WHERE role_name=$2",
+			userId, RoleUser)
+		if err != nil {
+			return err
+		}
 
-	stmt, err = db.Prepare(`
-	CREATE TABLE IF NOT EXISTS user_role(
-	    user_id INTEGER REFERENCES users(user_id),
-	    role_id INTEGER REFERENCES roles(role_id));
-	`)
-	if err != nil {
-		return nil, fmt.Errorf("%s : %w", op, err)
-	}
+		if n, err := res.RowsAffected(); err != nil || n == 0 {
+			return fmt.Errorf("role %q: %w", RoleUser, storage.ErrRoleNotFound)
+		}
 
-	_, err = stmt.Exec()
+		return nil
+	})
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return fmt.Errorf("%s: %w", op, err)
 	}
 
-	return &Storage{Db: db}, nil
+	return nil
 }
 
-func (s *Storage) SaveUser(username string, password string) error {
-	const op = "storage.postgres.SaveUser"
+// GetUserRoles returns the role names of a user and the union of the
+// permissions granted to those roles.
+func (s *Storage) GetUserRoles(userId int) ([]string, []string, error) {
+	const op = "storage.postgres.GetUserRoles"
 
-	rows, err := s.Db.Query("SELECT username FROM users WHERE username=$1 GROUP BY username", username)
+	rows, err := s.Db.Query(`SELECT r.role_name FROM roles r
+								   JOIN user_role ur ON ur.role_id = r.role_id
+								   WHERE ur.user_id=$1
+								   ORDER BY r.role_name`, userId)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
-	if rows.Next() {
-		return fmt.Errorf("%s: %w", op, storage.ErrUserExists)
+	roles := []string{}
+	for rows.Next() {
+		var role string
+		err = rows.Scan(&role)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
+		}
+		roles = append(roles, role)
 	}
-
-	var userId int
-	err = s.Db.QueryRow("INSERT INTO users(username, password) VALUES ($1, $2) RETURNING user_id",
-		username, password).Scan(&userId)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var roleUserId int
-	rows, err = s.Db.Query("SELECT role_id FROM roles WHERE role_name=$1", "user")
+	rows, err = s.Db.Query(`SELECT DISTINCT p.permission_name FROM permissions p
+								  JOIN role_permission rp ON rp.permission_id = p.permission_id
+								  JOIN user_role ur ON ur.role_id = rp.role_id
+								  WHERE ur.user_id=$1
+								  ORDER BY p.permission_name`, userId)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
+	permissions := []string{}
 	for rows.Next() {
-		err = rows.Scan(&roleUserId)
+		var permission string
+		err = rows.Scan(&permission)
 		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
+			return nil, nil, fmt.Errorf("%s: %w", op, err)
 		}
+		permissions = append(permissions, permission)
 	}
-	_, err = s.Db.Exec("INSERT INTO user_role(user_id, role_id) VALUES ($1, $2)", userId, roleUserId)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+	if err = rows.Err(); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return roles, permissions, nil
 }
 
-func (s *Storage) IsAdmin(userId int) (bool, error) {
-	const op = "storage.postgres.isAdmin"
+func (s *Storage) GetUser(username string, plainPassword string) (int, error) {
+	const op = "storage.postgres.GetUser"
 
-	rows, err := s.Db.Query("SELECT role_name FROM roles WHERE role_id IN (SELECT role_id FROM user_role WHERE user_id=$1)", userId)
+	var userId int
+	var stored string
+	var disabled, resetRequired bool
+	err := s.Db.QueryRow(`SELECT user_id, password, disabled_at IS NOT NULL, password_reset_required
+								FROM users WHERE username=$1`, username).
+		Scan(&userId, &stored, &disabled, &resetRequired)
+	if errors.Is(err, sql.ErrNoRows) {
+		password.VerifyDummy(plainPassword)
+		return -1, fmt.Errorf("%s: %w", op, storage.ErrInvalidCredentials)
+	}
 	if err != nil {
-		return false, fmt.Errorf("%s: %w", op, err)
+		return -1, fmt.Errorf("%s: %w", op, err)
 	}
 
-	var role string
-	for rows.Next() {
-		err = rows.Scan(&role)
-		if err != nil {
-			return false, fmt.Errorf("%s: %w", op, err)
-		}
-		if role == "admin" {
-			return true, nil
-		}
+	ok, needsRehash := password.Verify(stored, plainPassword)
+	if !ok {
+		return -1, fmt.Errorf("%s: %w", op, storage.ErrInvalidCredentials)
 	}
 
-	return false, nil
-}
+	if needsRehash {
+		// Upgrading the stored value is best effort: the credentials are
+		// valid, and a failed upgrade is retried on the next signin.
+		if hash, err := password.Hash(plainPassword); err == nil {
+			_, _ = s.Db.Exec("UPDATE users SET password=$1 WHERE user_id=$2 AND password=$3", hash, userId, stored)
+		}
+	}
 
-func (s *Storage) GetUser(username string, password string) (int, error) {
-	const op = "storage.postgres.GetUser"
+	if disabled {
+		return -1, fmt.Errorf("%s: %w", op, storage.ErrUserDisabled)
+	}
 
-	var userId int
-	err := s.Db.QueryRow("SELECT user_id FROM users WHERE username=$1 AND password=$2", username, password).Scan(&userId)
-	if err != nil {
-		return -1, fmt.Errorf("%s: %w", op, err)
+	if resetRequired {
+		return -1, fmt.Errorf("%s: %w", op, storage.ErrPasswordResetRequired)
 	}
 
 	return userId, nil
 }
 
-func (s *Storage) SaveActor(name string, gender string, birthdate string) (int, error) {
+func (s *Storage) SaveActor(ctx context.Context, name string, gender string, birthdate string, profile ActorProfile) (int, error) {
 	const op = "storage.postgres.SaveActor"
 
 	var actorId int
-	err := s.Db.QueryRow("INSERT INTO actors(name, gender, birthdate) VALUES ($1, $2, $3) RETURNING actor_id",
-		name, gender, birthdate).Scan(&actorId)
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		actorId, err = insertActor(tx, name, gender, birthdate, profile)
+		return err
+	})
 	if err != nil {
 		return -1, fmt.Errorf("%s: %w", op, err)
 	}
@@ -225,43 +263,34 @@ func (s *Storage) SaveActor(name string, gender string, birthdate string) (int,
 	return actorId, nil
 }
 
-func (s *Storage) SaveMovie(title string, description string, releaseDate string, rating int, actorsIds []int) (int, error) {
+func (s *Storage) SaveMovie(ctx context.Context, title string, description string, releaseDate string, rating int, actorsIds []int, details MovieDetails) (int, error) {
 	const op = "storage.postgres.SaveMovie"
 
 	var movieId int
-	err := s.Db.QueryRow("INSERT INTO movies(title, description, release_date, rating) VALUES ($1, $2, $3, $4) RETURNING movie_id",
-		title, description, releaseDate, rating).Scan(&movieId)
-	if err != nil {
-		return -1, fmt.Errorf("%s: %w", op, err)
-	}
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		var err error
+		movieId, err = insertMovie(tx, title, description, releaseDate, rating, details)
+		if err != nil {
+			return err
+		}
 
-	err = s.SaveActorMovie(movieId, actorsIds)
+		return saveCredits(tx, movieId, actorCredits(actorsIds))
+	})
 	if err != nil {
-		if s.DeleteMovie(movieId) != nil {
-			return -1, fmt.Errorf("%s: %w", op, err)
-		}
 		return -1, fmt.Errorf("%s: %w", op, err)
 	}
 
 	return movieId, nil
 }
 
-func (s *Storage) SaveActorMovie(movieId int, actorsIds []int) error {
+// SaveActorMovie adds credits to a movie. A credit the movie already has
+// keeps its character and billing order unless new ones are given.
+func (s *Storage) SaveActorMovie(ctx context.Context, movieId int, credits []Credit) error {
 	const op = "storage.postgres.SaveActorMovie"
 
-	stmt, err := s.Db.Prepare("INSERT INTO actor_movie(movie_id, actor_id) VALUES ($1, $2)")
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
-	for _, actorId := range actorsIds {
-		_, err := stmt.Exec(movieId, actorId)
-		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
-		}
-	}
-
-	err = stmt.Close()
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		return saveCredits(tx, movieId, credits)
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -269,91 +298,109 @@ func (s *Storage) SaveActorMovie(movieId int, actorsIds []int) error {
 	return nil
 }
 
-func (s *Storage) UpdateActorName(actorId int, name string) error {
-	const op = "storage.postgres.UpdateActorName"
-
-	_, err := s.Db.Exec("UPDATE actors SET name=$1 WHERE actor_id=$2", name, actorId)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
-	return nil
-}
-
-func (s *Storage) UpdateActorGender(actorId int, gender string) error {
-	const op = "storage.postgres.UpdateActorGender"
-
-	_, err := s.Db.Exec("UPDATE actors SET gender=$1 WHERE actor_id=$2", gender, actorId)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
-	return nil
-}
-
-func (s *Storage) UpdateActorBirthdate(actorId int, birthdate string) error {
-	const op = "storage.postgres.UpdateActorBirthdate"
-
-	_, err := s.Db.Exec("UPDATE actors SET birthdate=$1 WHERE actor_id=$2", birthdate, actorId)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
-	return nil
-}
-
-func (s *Storage) UpdateMovieTitle(movieId int, title string) error {
-	const op = "storage.postgres.UpdateMovieTitle"
-
-	_, err := s.Db.Exec("UPDATE movies SET title=$1 WHERE movie_id=$2", title, movieId)
+func insertMovie(tx *sql.Tx, title string, description string, releaseDate string, rating int, details MovieDetails) (int, error) {
+	var movieId int
+	err := tx.QueryRow(`INSERT INTO movies(title, description, release_date, rating, runtime, original_language, certification)
+							   VALUES ($1, $2, $3, $4, NULLIF($5, 0), NULLIF($6, ''), NULLIF($7, ''))
+							   RETURNING movie_id`,
+		title, description, releaseDate, rating, details.Runtime, details.OriginalLanguage, details.Certification).Scan(&movieId)
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return -1, mapError(err)
 	}
 
-	return nil
+	_, err = addMovieDetails(tx, movieId, details)
+	return movieId, err
 }
 
-func (s *Storage) UpdateMovieDescription(movieId int, description string) error {
-	const op = "storage.postgres.UpdateMovieDescription"
+// UpdateActor applies the non-nil fields of patch to an actor in a single
+// statement if it is at version, or at any version for AnyVersion, and
+// returns its new version.
+func (s *Storage) UpdateActor(ctx context.Context, actorId int, patch ActorPatch, version int) (int, error) {
+	const op = "storage.postgres.UpdateActor"
+
+	var newVersion int
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRow(`UPDATE actors SET
+								  name = COALESCE($2, name),
+								  gender = COALESCE($3, gender),
+								  birthdate = COALESCE($4::date, birthdate),
+								  biography = CASE WHEN $6::text IS NULL THEN biography ELSE NULLIF($6, '') END,
+								  birthplace = CASE WHEN $7::text IS NULL THEN birthplace ELSE NULLIF($7, '') END,
+								  nationality = CASE WHEN $8::text IS NULL THEN nationality ELSE NULLIF($8, '') END,
+								  death_date = CASE WHEN $9::text IS NULL THEN death_date ELSE NULLIF($9, '')::date END
+								  WHERE actor_id=$1 AND deleted_at IS NULL AND ($5 = 0 OR version = $5)
+								  RETURNING version`, actorId, patch.Name, patch.Gender, patch.Birthdate, version,
+			patch.Biography, patch.Birthplace, patch.Nationality, patch.DeathDate).
+			Scan(&newVersion)
+		if errors.Is(err, sql.ErrNoRows) {
+			return missingOrStale(tx, "actors", "actor_id", actorId)
+		}
+		if err != nil {
+			return mapError(err)
+		}
 
-	_, err := s.Db.Exec("UPDATE movies SET description=$1 WHERE movie_id=$2", description, movieId)
+		return replaceActorProfile(tx, actorId, patch.Aliases, patch.ExternalIds)
+	})
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return -1, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return newVersion, nil
 }
 
-func (s *Storage) UpdateMovieReleaseDate(movieId int, releaseDate string) error {
-	const op = "storage.postgres.UpdateMovieReleaseDate"
+// UpdateMovie applies the non-nil fields of patch to a movie in a single
+// statement if it is at version, or at any version for AnyVersion, and
+// returns its new version.
+func (s *Storage) UpdateMovie(ctx context.Context, movieId int, patch MoviePatch, version int) (int, error) {
+	const op = "storage.postgres.UpdateMovie"
+
+	var newVersion int
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRow(`UPDATE movies SET
+								  title = COALESCE($2, title),
+								  description = COALESCE($3, description),
+								  release_date = COALESCE($4::date, release_date),
+								  rating = COALESCE($5, rating),
+								  runtime = CASE WHEN $7::int IS NULL THEN runtime ELSE NULLIF($7, 0) END,
+								  original_language = CASE WHEN $8::text IS NULL THEN original_language ELSE NULLIF($8, '') END,
+								  certification = CASE WHEN $9::text IS NULL THEN certification ELSE NULLIF($9, '') END
+								  WHERE movie_id=$1 AND deleted_at IS NULL AND ($6 = 0 OR version = $6)
+								  RETURNING version`, movieId, patch.Title, patch.Description, patch.ReleaseDate, patch.Rating, version,
+			patch.Runtime, patch.OriginalLanguage, patch.Certification).
+			Scan(&newVersion)
+		if errors.Is(err, sql.ErrNoRows) {
+			return missingOrStale(tx, "movies", "movie_id", movieId)
+		}
+		if err != nil {
+			return mapError(err)
+		}
 
-	_, err := s.Db.Exec("UPDATE movies SET release_date=$1 WHERE movie_id=$2", releaseDate, movieId)
+		// the update above has already moved the movie to its new version
+		return replaceMovieDetails(tx, movieId, patch.Genres, patch.Countries, patch.Tags)
+	})
 	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
+		return -1, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return nil
+	return newVersion, nil
 }
 
-func (s *Storage) UpdateMovieRating(movieId int, rating int) error {
-	const op = "storage.postgres.UpdateMovieRating"
-
-	_, err := s.Db.Exec("UPDATE movies SET rating=$1 WHERE movie_id=$2", rating, movieId)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
+// DeleteActor moves an actor at version, or at any version for AnyVersion,
+// to the trash. They disappear from every cast until RestoreActor.
+func (s *Storage) DeleteActor(ctx context.Context, actorId int, version int) error {
+	const op = "storage.postgres.DeleteActor"
 
-	return nil
-}
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := lockVersion(tx, "actors", "actor_id", actorId, version); err != nil {
+			return err
+		}
 
-func (s *Storage) DeleteActor(actorId int) error {
-	const op = "storage.postgres.DeleteActor"
+		if _, err := tx.Exec("UPDATE actors SET deleted_at=now() WHERE actor_id=$1", actorId); err != nil {
+			return err
+		}
 
-	_, err := s.Db.Exec("DELETE FROM actor_movie WHERE actor_id=$1", actorId)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-	_, err = s.Db.Exec("DELETE FROM actors WHERE actor_id=$1", actorId)
+		return touchCredited(tx, "actors", actorId)
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -361,15 +408,22 @@ func (s *Storage) DeleteActor(actorId int) error {
 	return nil
 }
 
-func (s *Storage) DeleteMovie(movieId int) error {
+// DeleteMovie moves a movie at version, or at any version for AnyVersion,
+// to the trash. Its cast is kept for RestoreMovie.
+func (s *Storage) DeleteMovie(ctx context.Context, movieId int, version int) error {
 	const op = "storage.postgres.DeleteMovie"
 
-	_, err := s.Db.Exec("DELETE FROM actor_movie WHERE movie_id=$1", movieId)
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		if err := lockVersion(tx, "movies", "movie_id", movieId, version); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec("UPDATE movies SET deleted_at=now() WHERE movie_id=$1", movieId); err != nil {
+			return err
+		}
 
-	_, err = s.Db.Exec("DELETE FROM movies WHERE movie_id=$1", movieId)
+		return touchCredited(tx, "movies", movieId)
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -377,22 +431,24 @@ func (s *Storage) DeleteMovie(movieId int) error {
 	return nil
 }
 
-func (s *Storage) DeleteActorMovie(movieId int, actorsIds []int) error {
+func (s *Storage) DeleteActorMovie(ctx context.Context, movieId int, actorsIds []int) error {
 	const op = "storage.postgres.DeleteActorMovie"
 
-	stmt, err := s.Db.Prepare("DELETE FROM actor_movie WHERE movie_id=$1 AND actor_id=$2")
-	if err != nil {
-		return fmt.Errorf("%s: %w", op, err)
-	}
-
-	for _, actorId := range actorsIds {
-		_, err := stmt.Exec(movieId, actorId)
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		stmt, err := tx.Prepare("DELETE FROM actor_movie WHERE movie_id=$1 AND actor_id=$2")
 		if err != nil {
-			return fmt.Errorf("%s: %w", op, err)
+			return err
 		}
-	}
+		defer stmt.Close()
 
-	err = stmt.Close()
+		for _, actorId := range actorsIds {
+			if _, err = stmt.Exec(movieId, actorId); err != nil {
+				return mapError(err)
+			}
+		}
+
+		return nil
+	})
 	if err != nil {
 		return fmt.Errorf("%s: %w", op, err)
 	}
@@ -403,10 +459,11 @@ func (s *Storage) DeleteActorMovie(movieId int, actorsIds []int) error {
 func (s *Storage) GetActor(actorId int) (Actor, error) {
 	const op = "storage.postgres.GetActor"
 
-	var actor Actor
-	err := s.Db.QueryRow(`SELECT actor_id, name, gender, to_char(birthdate, 'YYYY-MM-DD') 
-								FROM actors WHERE actor_id=$1`, actorId).
-		Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.Birthdate)
+	actor, err := scanActor(s.Db.QueryRow(`SELECT `+actorColumns+`
+												 FROM actors WHERE actor_id=$1 AND deleted_at IS NULL`, actorId))
+	if errors.Is(err, sql.ErrNoRows) {
+		return Actor{}, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+	}
 	if err != nil {
 		return Actor{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -417,6 +474,10 @@ func (s *Storage) GetActor(actorId int) (Actor, error) {
 	}
 	actor.Movies = movies
 
+	if err = s.loadProfiles([]*Actor{&actor}); err != nil {
+		return Actor{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return actor, nil
 }
 
@@ -424,9 +485,16 @@ func (s *Storage) GetMovie(movieId int) (Movie, error) {
 	const op = "storage.postgres.GetMovie"
 
 	var movie Movie
-	err := s.Db.QueryRow(`SELECT movie_id, title, description, to_char(release_date, 'YYYY-MM-DD'), rating
-								FROM movies WHERE movie_id=$1`, movieId).
-		Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating)
+	err := s.Db.QueryRow(`SELECT movie_id, title, description, to_char(release_date, 'YYYY-MM-DD'), rating, version,
+								community_score::float8, vote_count,
+								COALESCE(runtime, 0), COALESCE(original_language, ''), COALESCE(certification, '')
+								FROM movies WHERE movie_id=$1 AND deleted_at IS NULL`, movieId).
+		Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Version,
+			&movie.CommunityScore, &movie.VoteCount,
+			&movie.Runtime, &movie.OriginalLanguage, &movie.Certification)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Movie{}, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+	}
 	if err != nil {
 		return Movie{}, fmt.Errorf("%s: %w", op, err)
 	}
@@ -437,16 +505,27 @@ func (s *Storage) GetMovie(movieId int) (Movie, error) {
 	}
 	movie.Actors = actors
 
+	if movie.Cast, movie.Crew, err = s.GetCredits(movieId); err != nil {
+		return Movie{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.loadMetadata([]*Movie{&movie}); err != nil {
+		return Movie{}, fmt.Errorf("%s: %w", op, err)
+	}
+
 	return movie, nil
 }
 
 func (s *Storage) GetActorsByMovie(movieId int) ([]int, error) {
 	const op = "storage.postgres.GetActorsByMovie"
 
-	rows, err := s.Db.Query("SELECT actor_id FROM actor_movie WHERE movie_id=$1", movieId)
+	rows, err := s.Db.Query(`SELECT DISTINCT am.actor_id FROM actor_movie am
+								   JOIN actors a ON a.actor_id = am.actor_id
+								   WHERE am.movie_id=$1 AND am.credit_type = 'actor' AND a.deleted_at IS NULL`, movieId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
 	var actors []int
 	for rows.Next() {
@@ -458,6 +537,9 @@ func (s *Storage) GetActorsByMovie(movieId int) ([]int, error) {
 
 		actors = append(actors, actorId)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	return actors, nil
 }
@@ -465,10 +547,13 @@ func (s *Storage) GetActorsByMovie(movieId int) ([]int, error) {
 func (s *Storage) GetMoviesByActor(actorId int) ([]int, error) {
 	const op = "storage.postgres.GetMoviesByActor"
 
-	rows, err := s.Db.Query("SELECT movie_id FROM actor_movie WHERE actor_id=$1", actorId)
+	rows, err := s.Db.Query(`SELECT DISTINCT am.movie_id FROM actor_movie am
+								   JOIN movies m ON m.movie_id = am.movie_id
+								   WHERE am.actor_id=$1 AND m.deleted_at IS NULL`, actorId)
 	if err != nil {
 		return nil, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
 	var movies []int
 	for rows.Next() {
@@ -480,115 +565,506 @@ func (s *Storage) GetMoviesByActor(actorId int) ([]int, error) {
 
 		movies = append(movies, movieId)
 	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
 
 	return movies, nil
 }
 
-func (s *Storage) GetMovies(sortBy string) ([]Movie, error) {
+// SortKey is a key of a movie listing order. In a sort parameter it is
+// spelled as the field, prefixed with - for descending, e.g. -rating.
+type SortKey struct {
+	Field string
+	Desc  bool
+}
+
+const (
+	SortTitle          = "title"
+	SortReleaseDate    = "release_date"
+	SortRating         = "rating"
+	SortCommunityScore = "community_score"
+	SortRuntime        = "runtime"
+)
+
+// movieSortFields are the columns behind the sort fields, in ascending
+// order, with the cursor values of a movie for them.
+var movieSortFields = map[string]struct {
+	keys   keyset
+	values func(m Movie) []interface{}
+}{
+	SortTitle: {
+		keyset{{column: "title"}},
+		func(m Movie) []interface{} { return []interface{}{m.Title} },
+	},
+	SortReleaseDate: {
+		keyset{{column: "release_date", param: "%s::text::date"}},
+		func(m Movie) []interface{} { return []interface{}{m.ReleaseDate} },
+	},
+	SortRating: {
+		keyset{{column: "rating"}},
+		func(m Movie) []interface{} { return []interface{}{m.Rating} },
+	},
+	// unscored movies rank as 0, then by the number of votes
+	SortCommunityScore: {
+		keyset{{column: "COALESCE(community_score, 0)", param: "%s::text::numeric"}, {column: "vote_count"}},
+		func(m Movie) []interface{} { return []interface{}{communityScore(m), m.VoteCount} },
+	},
+	SortRuntime: {
+		keyset{{column: "COALESCE(runtime, 0)"}},
+		func(m Movie) []interface{} { return []interface{}{m.Runtime} },
+	},
+}
+
+var defaultMovieSort = []SortKey{{Field: SortRating, Desc: true}}
+
+// orderByKeys are the sort keys of the OrderBy* sort_by modes.
+var orderByKeys = map[string]SortKey{
+	OrderByTitleAsc:           {Field: SortTitle},
+	OrderByTitleDesc:          {Field: SortTitle, Desc: true},
+	OrderByReleaseDateAsc:     {Field: SortReleaseDate},
+	OrderByReleaseDateDesc:    {Field: SortReleaseDate, Desc: true},
+	OrderByRatingAsc:          {Field: SortRating},
+	OrderByRatingDesc:         {Field: SortRating, Desc: true},
+	OrderByCommunityScoreAsc:  {Field: SortCommunityScore},
+	OrderByCommunityScoreDesc: {Field: SortCommunityScore, Desc: true},
+}
+
+// SortByKeys returns the sort keys of a sort_by mode.
+func SortByKeys(sortBy string) ([]SortKey, bool) {
+	key, ok := orderByKeys[sortBy]
+	if !ok {
+		return nil, false
+	}
+
+	return []SortKey{key}, true
+}
+
+// ParseMovieSort parses a comma-separated list of sort keys such as
+// "-rating,title". Unknown and repeated fields are storage.ErrInvalidValue.
+func ParseMovieSort(s string) ([]SortKey, error) {
+	return parseSort(s, func(field string) bool {
+		_, ok := movieSortFields[field]
+		return ok
+	})
+}
+
+// parseSort parses a comma-separated list of sort keys of the known fields.
+func parseSort(s string, known func(field string) bool) ([]SortKey, error) {
+	var keys []SortKey
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+
+		var key SortKey
+		key.Field, key.Desc = strings.CutPrefix(part, "-")
+		if !known(key.Field) || seen[key.Field] {
+			return nil, storage.ErrInvalidValue
+		}
+		seen[key.Field] = true
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// formatSort spells keys back as parseSort reads them.
+func formatSort(keys []SortKey) string {
+	parts := make([]string, len(keys))
+	for i, key := range keys {
+		parts[i] = key.Field
+		if key.Desc {
+			parts[i] = "-" + key.Field
+		}
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// movieOrdering returns the keyset of the sort keys and the cursor values
+// of a movie for it. It ends with movie_id, in the direction of the last
+// key, so that movies with equal values keep a stable order across pages.
+func movieOrdering(sort []SortKey) (keyset, func(m Movie) []interface{}) {
+	var keys keyset
+	var fields []func(m Movie) []interface{}
+	for _, key := range sort {
+		field := movieSortFields[key.Field]
+		for _, k := range field.keys {
+			k.desc = key.Desc
+			keys = append(keys, k)
+		}
+		fields = append(fields, field.values)
+	}
+	keys = append(keys, sortKey{column: "movie_id", desc: sort[len(sort)-1].Desc})
+
+	return keys, func(m Movie) []interface{} {
+		var values []interface{}
+		for _, field := range fields {
+			values = append(values, field(m)...)
+		}
+		return append(values, m.Id)
+	}
+}
+
+// communityScore is the cursor value of the community score of m, exact as
+// scores have two decimals.
+func communityScore(m Movie) string {
+	if m.CommunityScore == nil {
+		return "0"
+	}
+	return strconv.FormatFloat(*m.CommunityScore, 'f', 2, 64)
+}
+
+// GetMovies returns a page of the movies matching filter in the sort
+// order, rating descending by default. Keys of unknown fields are ignored.
+func (s *Storage) GetMovies(sort []SortKey, filter MovieFilter, page Page) ([]Movie, PageInfo, error) {
 	const op = "storage.postgres.GetMovies"
 
-	var movies []Movie
-	var orderBy string
-
-	switch sortBy {
-	case OrderByTitleAsc:
-		orderBy = "title ASC"
-	case OrderByTitleDesc:
-		orderBy = "title DESC"
-	case OrderByReleaseDateAsc:
-		orderBy = "release_date ASC"
-	case OrderByReleaseDateDesc:
-		orderBy = "release_date DESC"
-	case OrderByRatingAsc:
-		orderBy = "rating ASC"
-	case OrderByRatingDesc:
-		orderBy = "rating DESC"
-	default:
-		orderBy = "rating DESC"
-	}
-
-	query := fmt.Sprintf(`SELECT movie_id, title, description, to_char(release_date, 'YYYY-MM-DD') as release_date_f, rating 
-								 FROM movies
-								 ORDER BY %s`, orderBy)
-
-	rows, err := s.Db.Query(query)
+	var known []SortKey
+	for _, key := range sort {
+		if _, ok := movieSortFields[key.Field]; ok {
+			known = append(known, key)
+		}
+	}
+	if len(known) == 0 {
+		known = defaultMovieSort
+	}
+	sortName := formatSort(known)
+	keys, values := movieOrdering(known)
+
+	var b queryBuilder
+	b.where("deleted_at IS NULL")
+	filter.conditions(&b)
+	count := b.snapshot()
+
+	if page.Cursor != "" {
+		cursor, err := decodeCursor(page.Cursor, sortName, len(keys))
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		b.after(keys, cursor)
+	}
+
+	limit := b.arg(page.limit() + 1)
+
+	query := `SELECT movie_id, title, description, to_char(release_date, 'YYYY-MM-DD') as release_date_f, rating, version,
+					 community_score::float8, vote_count, COALESCE(runtime, 0), COALESCE(original_language, ''), COALESCE(certification, '')
+					 FROM movies ` + b.whereClause() + `
+					 ORDER BY ` + keys.orderBy() + `
+					 LIMIT ` + limit
+
+	rows, err := s.Db.Query(query, b.args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
+	movies := []Movie{}
 	for rows.Next() {
 		var movie Movie
-		err = rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating)
+		err = rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Version,
+			&movie.CommunityScore, &movie.VoteCount, &movie.Runtime, &movie.OriginalLanguage, &movie.Certification)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
 		}
-		actors, err := s.GetActorsByMovie(movie.Id)
+		movies = append(movies, movie)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var info PageInfo
+	if len(movies) > page.limit() {
+		movies = movies[:page.limit()]
+		info.NextCursor, err = encodeCursor(sortName, values(movies[len(movies)-1])...)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if page.WithTotal {
+		info.Total, err = s.count("SELECT count(*) FROM movies "+count.whereClause(), count.args...)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
 		}
-		movie.Actors = actors
+	}
 
-		movies = append(movies, movie)
+	if err = s.loadActors(movies); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
 	}
 
-	return movies, nil
+	ptrs := make([]*Movie, len(movies))
+	for i := range movies {
+		ptrs[i] = &movies[i]
+	}
+	if err = s.loadMetadata(ptrs); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return movies, info, nil
 }
 
-func (s *Storage) GetActors() ([]Actor, error) {
-	const op = "storage.postgres.GetActors"
+const actorsOrdering = "actor_id"
 
-	var actors []Actor
+const (
+	SortName       = "name"
+	SortBirthdate  = "birthdate"
+	SortMovieCount = "movie_count"
+	SortRelevance  = "relevance"
+)
 
-	rows, err := s.Db.Query("SELECT actor_id, name, gender, to_char(birthdate, 'YYYY-MM-DD') FROM actors")
-	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+// ActorFilter narrows a list of actors down to those of the gender, born in
+// the years from BornFrom to BornTo and, when HasMovies is set, credited in
+// a movie or not. Name keeps the actors whose name or an alias starts with
+// it or is similar to it. Zero fields do not filter.
+type ActorFilter struct {
+	Gender    string
+	BornFrom  int
+	BornTo    int
+	HasMovies *bool
+	Name      string
+}
+
+// actorRow is a listed actor with the computed values it may be sorted by.
+type actorRow struct {
+	Actor
+	movieCount int
+	relevance  float64
+}
+
+// actorSortFields are the columns behind the actor sort fields, in
+// ascending order, with the cursor values of an actor for them.
+var actorSortFields = map[string]struct {
+	keys   keyset
+	values func(a actorRow) []interface{}
+}{
+	SortName: {
+		keyset{{column: "name"}},
+		func(a actorRow) []interface{} { return []interface{}{a.Name} },
+	},
+	SortBirthdate: {
+		keyset{{column: "birthdate", param: "%s::text::date"}},
+		func(a actorRow) []interface{} { return []interface{}{a.Birthdate} },
+	},
+	SortMovieCount: {
+		keyset{{column: "movie_count"}},
+		func(a actorRow) []interface{} { return []interface{}{a.movieCount} },
+	},
+	SortRelevance: {
+		keyset{{column: "relevance", param: "%s::text::float8"}},
+		func(a actorRow) []interface{} { return []interface{}{strconv.FormatFloat(a.relevance, 'g', -1, 64)} },
+	},
+}
+
+func sortsBy(sort []SortKey, field string) bool {
+	for _, key := range sort {
+		if key.Field == field {
+			return true
+		}
 	}
+	return false
+}
 
-	for rows.Next() {
-		var actor Actor
-		err = rows.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.Birthdate)
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+// ParseActorSort parses a comma-separated list of actor sort keys such as
+// "-movie_count,name", like ParseMovieSort.
+func ParseActorSort(s string) ([]SortKey, error) {
+	return parseSort(s, func(field string) bool {
+		_, ok := actorSortFields[field]
+		return ok
+	})
+}
+
+// actorOrdering returns the keyset of the sort keys and the cursor values
+// of an actor for it, ending with actor_id like movieOrdering.
+func actorOrdering(sort []SortKey) (keyset, func(a actorRow) []interface{}) {
+	var keys keyset
+	var fields []func(a actorRow) []interface{}
+	for _, key := range sort {
+		field := actorSortFields[key.Field]
+		for _, k := range field.keys {
+			k.desc = key.Desc
+			keys = append(keys, k)
 		}
-		movies, err := s.GetMoviesByActor(actor.Id)
-		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+		fields = append(fields, field.values)
+	}
+	keys = append(keys, sortKey{column: "actor_id", desc: sort[len(sort)-1].Desc})
+
+	return keys, func(a actorRow) []interface{} {
+		var values []interface{}
+		for _, field := range fields {
+			values = append(values, field(a)...)
 		}
-		actor.Movies = movies
-		actors = append(actors, actor)
+		return append(values, a.Id)
 	}
+}
 
-	return actors, nil
+// actorRelevance ranks an actor a for the name search %[1]s, the name,
+// and %[2]s, its prefix pattern. Names and aliases starting with the search
+// come first, then the most similar ones.
+const actorRelevance = `(CASE WHEN a.name ILIKE %[2]s
+			OR EXISTS(SELECT 1 FROM actor_aliases al WHERE al.actor_id = a.actor_id AND al.alias ILIKE %[2]s)
+			THEN 1 ELSE 0 END
+		+ greatest(word_similarity(%[1]s, a.name), COALESCE((
+			SELECT max(word_similarity(%[1]s, al.alias)) FROM actor_aliases al WHERE al.actor_id = a.actor_id), 0)))::float8`
+
+// actorMovieCount joins the number of movies out of the trash of an actor
+// a as mc.movie_count.
+const actorMovieCount = `
+				CROSS JOIN LATERAL (SELECT count(DISTINCT am.movie_id)::int AS movie_count FROM actor_movie am
+					JOIN movies m ON m.movie_id = am.movie_id
+					WHERE am.actor_id = a.actor_id AND m.deleted_at IS NULL) mc`
+
+// actorNameMatch keeps the actors matching the name search like
+// actorRelevance. It is spelled on the columns so that the trigram indexes
+// of names and aliases serve it.
+const actorNameMatch = `(name ILIKE %[2]s OR %[1]s <%% name
+		OR EXISTS(SELECT 1 FROM actor_aliases al
+			WHERE al.actor_id = actors.actor_id AND (al.alias ILIKE %[2]s OR %[1]s <%% al.alias)))`
+
+// conditions adds the WHERE conditions of f but Name on the actors of
+// GetActors to b.
+func (f ActorFilter) conditions(b *queryBuilder) {
+	if f.Gender != "" {
+		b.where("gender = ?", f.Gender)
+	}
+	if f.BornFrom != 0 {
+		b.where("birthdate >= make_date(?::int, 1, 1)", f.BornFrom)
+	}
+	if f.BornTo != 0 {
+		b.where("birthdate < make_date(?::int + 1, 1, 1)", f.BornTo)
+	}
+	if f.HasMovies != nil {
+		if *f.HasMovies {
+			b.where("movie_count > 0")
+		} else {
+			b.where("movie_count = 0")
+		}
+	}
 }
 
-func (s *Storage) GetMoviesBySearchRequest(searchRequest string) ([]Movie, error) {
-	const op = "storage.postgres.GetMovieBySearchRequest"
+// GetActors returns a page of the actors matching filter in the sort
+// order. It is by relevance to filter.Name when set and by actor_id
+// otherwise. Keys of unknown fields are ignored, as is relevance without a
+// name search.
+func (s *Storage) GetActors(sort []SortKey, filter ActorFilter, page Page) ([]Actor, PageInfo, error) {
+	const op = "storage.postgres.GetActors"
 
-	var movies []Movie
+	var known []SortKey
+	for _, key := range sort {
+		if _, ok := actorSortFields[key.Field]; ok && (key.Field != SortRelevance || filter.Name != "") {
+			known = append(known, key)
+		}
+	}
+	if len(known) == 0 && filter.Name != "" {
+		known = []SortKey{{Field: SortRelevance, Desc: true}}
+	}
+
+	sortName := actorsOrdering
+	keys := keyset{{column: "actor_id"}}
+	values := func(a actorRow) []interface{} { return []interface{}{a.Id} }
+	if len(known) != 0 {
+		sortName = formatSort(known)
+		keys, values = actorOrdering(known)
+	}
+
+	// the movie count and the relevance are computed only when sorted or
+	// filtered by, so a plain listing does not count for every actor
+	var b queryBuilder
+	relevance := "0::float8"
+	if filter.Name != "" {
+		name, prefix := b.arg(filter.Name), b.arg(likeEscaper.Replace(filter.Name)+"%")
+		if sortsBy(known, SortRelevance) {
+			relevance = fmt.Sprintf(actorRelevance, name, prefix)
+		}
+		b.where(fmt.Sprintf(actorNameMatch, name, prefix))
+	}
+	movieCount, counts := "0", ""
+	if filter.HasMovies != nil || sortsBy(known, SortMovieCount) {
+		movieCount, counts = "mc.movie_count", actorMovieCount
+	}
+	from := fmt.Sprintf(`(SELECT a.*, %s AS movie_count, %s AS relevance FROM actors a%s) actors`,
+		movieCount, relevance, counts)
+
+	b.where("deleted_at IS NULL")
+	filter.conditions(&b)
+	count := b.snapshot()
 
-	rows, err := s.Db.Query(`SELECT DISTINCT m.movie_id, m.title, m.description, to_char(m.release_date, 'YYYY-MM-DD'), m.rating 
-								   FROM movies m 
-    							   JOIN actor_movie am ON m.movie_id = am.movie_id 
-								   JOIN actors a ON am.actor_id = a.actor_id
-								   WHERE m.title LIKE $1 or a.name LIKE $1`, "%"+searchRequest+"%")
+	if page.Cursor != "" {
+		cursor, err := decodeCursor(page.Cursor, sortName, len(keys))
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		b.after(keys, cursor)
+	}
+
+	limit := b.arg(page.limit() + 1)
+
+	rows, err := s.Db.Query(`SELECT `+actorColumns+`, movie_count, relevance
+								   FROM `+from+` `+b.whereClause()+`
+								   ORDER BY `+keys.orderBy()+`
+								   LIMIT `+limit, b.args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s: %w", op, err)
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
 	}
+	defer rows.Close()
 
+	var found []actorRow
 	for rows.Next() {
-		var movie Movie
-		err = rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating)
+		var row actorRow
+		err = rows.Scan(&row.Id, &row.Name, &row.Gender, &row.Birthdate, &row.Version,
+			&row.Biography, &row.Birthplace, &row.Nationality, &row.DeathDate, &row.Age,
+			&row.movieCount, &row.relevance)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		found = append(found, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var info PageInfo
+	if len(found) > page.limit() {
+		found = found[:page.limit()]
+		info.NextCursor, err = encodeCursor(sortName, values(found[len(found)-1])...)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
 		}
-		actors, err := s.GetActorsByMovie(movie.Id)
+	}
+
+	if page.WithTotal {
+		info.Total, err = s.count("SELECT count(*) FROM "+from+" "+count.whereClause(), count.args...)
 		if err != nil {
-			return nil, fmt.Errorf("%s: %w", op, err)
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
 		}
-		movie.Actors = actors
+	}
 
-		movies = append(movies, movie)
+	actors := make([]Actor, len(found))
+	for i := range found {
+		actors[i] = found[i].Actor
 	}
 
-	return movies, nil
+	if err = s.loadMovies(actors); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	ptrs := make([]*Actor, len(actors))
+	for i := range actors {
+		ptrs[i] = &actors[i]
+	}
+	if err = s.loadProfiles(ptrs); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return actors, info, nil
+}
+
+func (s *Storage) count(query string, args ...interface{}) (*int, error) {
+	var total int
+	if err := s.Db.QueryRow(query, args...).Scan(&total); err != nil {
+		return nil, err
+	}
+
+	return &total, nil
 }
@@ -0,0 +1,125 @@
+package postgres_test
+
+import (
+	"testing"
+
+	"film_library/internal/storage/postgres"
+)
+
+// The benchmarks need a real database, see newTestStorage. Run them with
+//
+//	FILM_LIBRARY_TEST_DSN=postgres://... go test -run '^$' -bench . ./internal/storage/postgres
+//
+// The PerRow variants list the rows with one plain query and then load the
+// associations one query per row, the way the listings did before, as a
+// baseline for the batched queries.
+
+const (
+	benchMovies   = 2000
+	benchActors   = 500
+	benchCastSize = 5
+	benchPage     = 100
+)
+
+func BenchmarkGetMovies(b *testing.B) {
+	s := newTestStorage(b)
+	seedCatalogue(b, s, benchMovies, benchActors, benchCastSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := s.GetMovies(titleAsc, postgres.MovieFilter{}, postgres.Page{Limit: benchPage}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetMoviesPerRow(b *testing.B) {
+	s := newTestStorage(b)
+	seedCatalogue(b, s, benchMovies, benchActors, benchCastSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		movies := listPerRow(b, s, `SELECT movie_id, title, description, to_char(release_date, 'YYYY-MM-DD'), rating
+									FROM movies WHERE deleted_at IS NULL
+									ORDER BY title, movie_id LIMIT $1`)
+		for _, movieId := range movies {
+			if _, err := s.GetActorsByMovie(movieId); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func BenchmarkGetActors(b *testing.B) {
+	s := newTestStorage(b)
+	seedCatalogue(b, s, benchMovies, benchActors, benchCastSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := s.GetActors(nil, postgres.ActorFilter{}, postgres.Page{Limit: benchPage}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkGetActorsPerRow(b *testing.B) {
+	s := newTestStorage(b)
+	seedCatalogue(b, s, benchMovies, benchActors, benchCastSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		actors := listPerRow(b, s, `SELECT actor_id, name, gender, to_char(birthdate, 'YYYY-MM-DD')
+									FROM actors WHERE deleted_at IS NULL
+									ORDER BY actor_id LIMIT $1`)
+		for _, actorId := range actors {
+			if _, err := s.GetMoviesByActor(actorId); err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+// listPerRow runs a page query, id column first, scanning every column
+// but loading no association, and returns the ids.
+func listPerRow(b *testing.B, s *postgres.Storage, query string) []int {
+	rows, err := s.Db.Query(query, benchPage)
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	var ids []int
+	for rows.Next() {
+		var id int
+		dest := []interface{}{&id}
+		for range columns[1:] {
+			dest = append(dest, new(interface{}))
+		}
+		if err = rows.Scan(dest...); err != nil {
+			b.Fatal(err)
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		b.Fatal(err)
+	}
+
+	return ids
+}
+
+func BenchmarkGetMoviesBySearchRequest(b *testing.B) {
+	s := newTestStorage(b)
+	seedCatalogue(b, s, benchMovies, benchActors, benchCastSize)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, _, err := s.GetMoviesBySearchRequest("Movie 1", postgres.Page{Limit: benchPage}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
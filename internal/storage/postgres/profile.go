@@ -0,0 +1,190 @@
+package postgres
+
+import (
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	GenderMale        = "male"
+	GenderFemale      = "female"
+	GenderOther       = "other"
+	GenderUnspecified = "unspecified"
+)
+
+var Genders = []string{GenderMale, GenderFemale, GenderOther, GenderUnspecified}
+
+// Sources of ExternalIds.
+const (
+	SourceIMDb     = "imdb"
+	SourceWikidata = "wikidata"
+	SourceTMDB     = "tmdb"
+)
+
+// ActorProfile is the biography of an actor. Nationality is an ISO 3166-1
+// alpha-2 code, ExternalIds maps a source to the id of the actor there.
+// Zero values stand for unknown.
+type ActorProfile struct {
+	Biography   string            `json:"biography,omitempty"`
+	Birthplace  string            `json:"birthplace,omitempty"`
+	Nationality string            `json:"nationality,omitempty"`
+	DeathDate   string            `json:"death_date,omitempty"`
+	Aliases     []string          `json:"aliases"`
+	ExternalIds map[string]string `json:"external_ids"`
+}
+
+// actorColumns are the columns of actors read by scanActor. Age is at the
+// death date for the dead.
+const actorColumns = `actor_id, name, gender, to_char(birthdate, 'YYYY-MM-DD'), version,
+	COALESCE(biography, ''), COALESCE(birthplace, ''), COALESCE(nationality, ''),
+	COALESCE(to_char(death_date, 'YYYY-MM-DD'), ''),
+	date_part('year', age(COALESCE(death_date, current_date), birthdate))::int`
+
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanActor(row scanner) (Actor, error) {
+	var actor Actor
+	err := row.Scan(&actor.Id, &actor.Name, &actor.Gender, &actor.Birthdate, &actor.Version,
+		&actor.Biography, &actor.Birthplace, &actor.Nationality, &actor.DeathDate, &actor.Age)
+
+	return actor, err
+}
+
+func insertActor(tx *sql.Tx, name string, gender string, birthdate string, profile ActorProfile) (int, error) {
+	var actorId int
+	err := tx.QueryRow(`INSERT INTO actors(name, gender, birthdate, biography, birthplace, nationality, death_date)
+							   VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), NULLIF($7, '')::date)
+							   RETURNING actor_id`,
+		name, gender, birthdate, profile.Biography, profile.Birthplace, profile.Nationality, profile.DeathDate).Scan(&actorId)
+	if err != nil {
+		return -1, mapError(err)
+	}
+
+	_, err = addActorProfile(tx, actorId, profile)
+	return actorId, err
+}
+
+// addActorProfile adds the aliases and the external ids of sources the
+// actor has none for, and reports whether any was new.
+func addActorProfile(tx *sql.Tx, actorId int, profile ActorProfile) (bool, error) {
+	var added int64
+	if len(profile.Aliases) > 0 {
+		res, err := tx.Exec(`INSERT INTO actor_aliases(actor_id, alias)
+									SELECT $1, unnest($2::text[])
+									ON CONFLICT DO NOTHING`, actorId, profile.Aliases)
+		if err != nil {
+			return false, mapError(err)
+		}
+		if added, err = res.RowsAffected(); err != nil {
+			return false, err
+		}
+	}
+
+	if len(profile.ExternalIds) > 0 {
+		sources, ids := externalIds(profile.ExternalIds)
+		res, err := tx.Exec(`INSERT INTO actor_external_ids(actor_id, source, external_id)
+									SELECT $1, t.source, t.external_id FROM unnest($2::text[], $3::text[]) AS t(source, external_id)
+									ON CONFLICT (actor_id, source) DO NOTHING`, actorId, sources, ids)
+		if err != nil {
+			return false, mapError(err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return false, err
+		}
+		added += n
+	}
+
+	return added > 0, nil
+}
+
+// replaceActorProfile replaces the aliases and the external ids of an
+// actor that are not nil.
+func replaceActorProfile(tx *sql.Tx, actorId int, aliases []string, external map[string]string) error {
+	if aliases != nil {
+		_, err := tx.Exec("DELETE FROM actor_aliases WHERE actor_id=$1 AND alias <> ALL($2::text[])", actorId, aliases)
+		if err != nil {
+			return err
+		}
+	}
+
+	if external != nil {
+		sources, ids := externalIds(external)
+		// a changed id is removed here and added back below
+		_, err := tx.Exec(`DELETE FROM actor_external_ids
+								  WHERE actor_id=$1 AND (source, external_id) NOT IN
+								  (SELECT * FROM unnest($2::text[], $3::text[]))`, actorId, sources, ids)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err := addActorProfile(tx, actorId, ActorProfile{Aliases: aliases, ExternalIds: external})
+	return err
+}
+
+// externalIds splits ids into sources and ids, ordered by source. Both are
+// empty rather than nil for no ids, as nil arrays would be NULL.
+func externalIds(external map[string]string) ([]string, []string) {
+	sources := make([]string, 0, len(external))
+	for source := range external {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
+	ids := make([]string, len(sources))
+	for i, source := range sources {
+		ids[i] = external[source]
+	}
+
+	return sources, ids
+}
+
+// loadProfiles fills Aliases and ExternalIds of every actor.
+func (s *Storage) loadProfiles(actors []*Actor) error {
+	ids := make([]int, len(actors))
+	for i, actor := range actors {
+		ids[i] = actor.Id
+	}
+
+	aliases, err := associations[string](s.Db, `SELECT actor_id, alias FROM actor_aliases
+												 WHERE actor_id = ANY($1)
+												 ORDER BY actor_id, alias`, ids)
+	if err != nil {
+		return fmt.Errorf("load aliases: %w", err)
+	}
+
+	external, err := associations[string](s.Db, `SELECT actor_id, source || ':' || external_id FROM actor_external_ids
+												  WHERE actor_id = ANY($1)
+												  ORDER BY actor_id, source`, ids)
+	if err != nil {
+		return fmt.Errorf("load external ids: %w", err)
+	}
+
+	for _, actor := range actors {
+		actor.Aliases = aliases[actor.Id]
+		actor.ExternalIds = parseExternalIds(external[actor.Id])
+	}
+
+	return nil
+}
+
+// parseExternalIds reads external ids selected as source:id, nil for none.
+func parseExternalIds(values []string) map[string]string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	external := make(map[string]string, len(values))
+	for _, value := range values {
+		// sources have no colon, ids may
+		source, id, _ := strings.Cut(value, ":")
+		external[source] = id
+	}
+
+	return external
+}
@@ -0,0 +1,142 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestActorProfile(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.SetSearchLanguage("english"))
+	ctx := context.Background()
+
+	actorId, err := s.SaveActor(ctx, "Marcello Mastroianni", "male", "1924-09-28", postgres.ActorProfile{
+		Biography:   "Italian actor",
+		Nationality: "IT",
+		DeathDate:   "1996-12-19",
+		Aliases:     []string{"Snaporaz"},
+		ExternalIds: map[string]string{"imdb": "nm0000052"},
+	})
+	require.NoError(t, err)
+
+	actor, err := s.GetActor(actorId)
+	require.NoError(t, err)
+	require.Equal(t, "IT", actor.Nationality)
+	require.Equal(t, []string{"Snaporaz"}, actor.Aliases)
+	require.Equal(t, map[string]string{"imdb": "nm0000052"}, actor.ExternalIds)
+	// age at death
+	require.Equal(t, 72, *actor.Age)
+
+	movieId, err := s.SaveMovie(ctx, "8 1/2", "", "1963-02-14", 8, []int{actorId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	movies, _, err := s.GetMoviesBySearchRequest("snaporaz", postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, movies, 1)
+	require.Equal(t, movieId, movies[0].Id)
+
+	noNationality := ""
+	_, err = s.UpdateActor(ctx, actorId, postgres.ActorPatch{
+		Nationality: &noNationality,
+		Aliases:     []string{},
+		ExternalIds: map[string]string{"imdb": "nm0000052", "wikidata": "Q104049"},
+	}, postgres.AnyVersion)
+	require.NoError(t, err)
+
+	actor, err = s.GetActor(actorId)
+	require.NoError(t, err)
+	require.Empty(t, actor.Nationality)
+	require.Equal(t, "Italian actor", actor.Biography)
+	require.Empty(t, actor.Aliases)
+	require.Equal(t, map[string]string{"imdb": "nm0000052", "wikidata": "Q104049"}, actor.ExternalIds)
+
+	// an id belongs to one actor only
+	_, err = s.SaveActor(ctx, "Someone Else", "unspecified", "1950-01-01", postgres.ActorProfile{
+		ExternalIds: map[string]string{"imdb": "nm0000052"},
+	})
+	require.ErrorIs(t, err, storage.ErrConflict)
+
+	deathDate := "1900-01-01"
+	_, err = s.UpdateActor(ctx, actorId, postgres.ActorPatch{DeathDate: &deathDate}, postgres.AnyVersion)
+	var constraintErr *storage.ConstraintError
+	require.ErrorAs(t, err, &constraintErr)
+	require.Equal(t, "death_date", constraintErr.Field)
+}
+
+func TestGetActors(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	streep, err := s.SaveActor(ctx, "Meryl Streep", "female", "1949-06-22", postgres.ActorProfile{})
+	require.NoError(t, err)
+	keaton, err := s.SaveActor(ctx, "Diane Keaton", "female", "1946-01-05", postgres.ActorProfile{Aliases: []string{"Diane Hall"}})
+	require.NoError(t, err)
+	pacino, err := s.SaveActor(ctx, "Al Pacino", "male", "1940-04-25", postgres.ActorProfile{})
+	require.NoError(t, err)
+	newcomer, err := s.SaveActor(ctx, "Merle Newcomer", "female", "2001-02-03", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	_, err = s.SaveMovie(ctx, "The Godfather", "", "1972-03-24", 9, []int{keaton, pacino}, postgres.MovieDetails{})
+	require.NoError(t, err)
+	_, err = s.SaveMovie(ctx, "Annie Hall", "", "1977-04-20", 8, []int{keaton}, postgres.MovieDetails{})
+	require.NoError(t, err)
+	_, err = s.SaveMovie(ctx, "Sophie's Choice", "", "1982-12-10", 8, []int{streep}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	ids := func(actors []postgres.Actor) []int {
+		var ids []int
+		for _, a := range actors {
+			ids = append(ids, a.Id)
+		}
+		return ids
+	}
+
+	actors, _, err := s.GetActors(nil, postgres.ActorFilter{}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{streep, keaton, pacino, newcomer}, ids(actors))
+
+	byCount := []postgres.SortKey{{Field: postgres.SortMovieCount, Desc: true}, {Field: postgres.SortName}}
+	actors, info, err := s.GetActors(byCount, postgres.ActorFilter{}, postgres.Page{Limit: 2, WithTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, []int{keaton, pacino}, ids(actors))
+	require.Equal(t, 4, *info.Total)
+
+	actors, _, err = s.GetActors(byCount, postgres.ActorFilter{}, postgres.Page{Cursor: info.NextCursor})
+	require.NoError(t, err)
+	require.Equal(t, []int{streep, newcomer}, ids(actors))
+
+	hasMovies := false
+	actors, _, err = s.GetActors(nil, postgres.ActorFilter{Gender: "female", HasMovies: &hasMovies}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{newcomer}, ids(actors))
+
+	actors, _, err = s.GetActors([]postgres.SortKey{{Field: postgres.SortBirthdate}}, postgres.ActorFilter{BornFrom: 1945, BornTo: 1949}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{keaton, streep}, ids(actors))
+
+	// prefix matches rank first, misspellings and aliases are found too
+	actors, _, err = s.GetActors(nil, postgres.ActorFilter{Name: "mer"}, postgres.Page{})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []int{streep, newcomer}, ids(actors))
+
+	actors, _, err = s.GetActors(nil, postgres.ActorFilter{Name: "Meryl Strep"}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, streep, actors[0].Id)
+
+	actors, _, err = s.GetActors([]postgres.SortKey{{Field: postgres.SortName}}, postgres.ActorFilter{Name: "mer"}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{newcomer, streep}, ids(actors))
+
+	actors, _, err = s.GetActors(nil, postgres.ActorFilter{Name: "diane hall"}, postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{keaton}, ids(actors))
+
+	// a cursor only fits the order it was issued for
+	_, _, err = s.GetActors(nil, postgres.ActorFilter{}, postgres.Page{Cursor: info.NextCursor})
+	require.ErrorIs(t, err, storage.ErrInvalidCursor)
+}
@@ -0,0 +1,66 @@
+package postgres
+
+import (
+	"fmt"
+	"strings"
+)
+
+// queryBuilder collects the WHERE conditions of a query and their
+// arguments. Conditions are fixed SQL fragments of this package that spell
+// every value as ?; values are always bound as parameters, never spliced
+// into the SQL, and the placeholders are numbered in the order they are
+// added.
+type queryBuilder struct {
+	conds []string
+	args  []interface{}
+}
+
+// where adds a condition, binding values to its ? in order.
+func (b *queryBuilder) where(fragment string, values ...interface{}) {
+	var sb strings.Builder
+	for _, v := range values {
+		i := strings.IndexByte(fragment, '?')
+		if i < 0 {
+			panic("postgres: more values than placeholders in " + fragment)
+		}
+		sb.WriteString(fragment[:i])
+		sb.WriteString(b.arg(v))
+		fragment = fragment[i+1:]
+	}
+	sb.WriteString(fragment)
+
+	b.conds = append(b.conds, sb.String())
+}
+
+// after adds the condition of the rows after the cursor values in the
+// keys order.
+func (b *queryBuilder) after(keys keyset, values []interface{}) {
+	b.conds = append(b.conds, keys.after(len(b.args)+1))
+	b.args = append(b.args, values...)
+}
+
+// arg binds v and returns its placeholder.
+func (b *queryBuilder) arg(v interface{}) string {
+	b.args = append(b.args, v)
+
+	return fmt.Sprintf("$%d", len(b.args))
+}
+
+// whereClause returns the WHERE clause of the conditions, empty without
+// any.
+func (b *queryBuilder) whereClause() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+
+	return "WHERE " + strings.Join(b.conds, " AND ")
+}
+
+// snapshot returns a copy of b as it is, for the count query of a listing
+// that leaves out the conditions of the page.
+func (b *queryBuilder) snapshot() queryBuilder {
+	return queryBuilder{
+		conds: append([]string(nil), b.conds...),
+		args:  append([]interface{}(nil), b.args...),
+	}
+}
@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+)
+
+func TestQueryBuilder(t *testing.T) {
+	var b queryBuilder
+	b.where("deleted_at IS NULL")
+	b.where("(title ILIKE ? OR description ILIKE ?)", "%a%", "%a%")
+
+	count := b.snapshot()
+	b.after(keyset{{column: "rating", desc: true}, {column: "movie_id", desc: true}}, []interface{}{int64(8), int64(3)})
+	limit := b.arg(11)
+
+	require.Equal(t, "WHERE deleted_at IS NULL AND (title ILIKE $1 OR description ILIKE $2)", count.whereClause())
+	require.Len(t, count.args, 2)
+
+	require.Equal(t, "WHERE deleted_at IS NULL AND (title ILIKE $1 OR description ILIKE $2)"+
+		" AND ((rating < $3) OR (rating = $3 AND movie_id < $4))", b.whereClause())
+	require.Equal(t, "$5", limit)
+	require.Equal(t, []interface{}{"%a%", "%a%", int64(8), int64(3), 11}, b.args)
+
+	require.Panics(t, func() { b.where("rating = ?", 1, 2) })
+}
+
+func TestParseActorSort(t *testing.T) {
+	keys, err := ParseActorSort("-movie_count,name")
+	require.NoError(t, err)
+	require.Equal(t, []SortKey{{Field: SortMovieCount, Desc: true}, {Field: SortName}}, keys)
+
+	_, err = ParseActorSort("title")
+	require.ErrorIs(t, err, storage.ErrInvalidValue)
+}
+
+func TestParseMovieSort(t *testing.T) {
+	cases := []struct {
+		name  string
+		sort  string
+		keys  []SortKey
+		valid bool
+	}{
+		{
+			name:  "Single",
+			sort:  "title",
+			keys:  []SortKey{{Field: SortTitle}},
+			valid: true,
+		},
+		{
+			name:  "Multiple",
+			sort:  "-rating, title",
+			keys:  []SortKey{{Field: SortRating, Desc: true}, {Field: SortTitle}},
+			valid: true,
+		},
+		{
+			name: "Unknown field",
+			sort: "-budget",
+		},
+		{
+			name: "Repeated field",
+			sort: "rating,-rating",
+		},
+		{
+			name: "Empty key",
+			sort: "rating,",
+		},
+		{
+			name: "SQL",
+			sort: "rating; DROP TABLE movies",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			keys, err := ParseMovieSort(tc.sort)
+			if !tc.valid {
+				require.ErrorIs(t, err, storage.ErrInvalidValue)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.keys, keys)
+
+			again, err := ParseMovieSort(formatSort(keys))
+			require.NoError(t, err)
+			require.Equal(t, keys, again)
+		})
+	}
+}
@@ -0,0 +1,220 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"film_library/internal/storage"
+	"fmt"
+	"time"
+)
+
+const (
+	ReviewPublished = "published"
+	ReviewHidden    = "hidden"
+)
+
+var ReviewStatuses = []string{ReviewPublished, ReviewHidden}
+
+// Review is the score from 1 to 10 a user gives a movie, with an optional
+// text. Only published reviews count towards the community score.
+type Review struct {
+	Id        int       `json:"review_id"`
+	MovieId   int       `json:"movie_id"`
+	UserId    int       `json:"user_id"`
+	Username  string    `json:"username"`
+	Score     int       `json:"score"`
+	Body      string    `json:"body,omitempty"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ReviewPatch holds the review fields to change, nil fields are kept. An
+// empty body removes the text.
+type ReviewPatch struct {
+	Score *int
+	Body  *string
+}
+
+// ReviewFilter selects reviews. Zero fields match every review.
+type ReviewFilter struct {
+	MovieId int
+	UserId  int
+	Status  string
+}
+
+// SaveReview adds the review of a user to a movie. A user reviews a movie
+// once, a second review is a storage.ErrConflict.
+func (s *Storage) SaveReview(ctx context.Context, movieId int, userId int, score int, body string) (int, error) {
+	const op = "storage.postgres.SaveReview"
+
+	var reviewId int
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		err := tx.QueryRow(`INSERT INTO reviews(movie_id, user_id, score, body)
+								  SELECT movie_id, $2, $3, NULLIF($4, '') FROM movies
+								  WHERE movie_id=$1 AND deleted_at IS NULL
+								  RETURNING review_id`, movieId, userId, score, body).Scan(&reviewId)
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrNotFound
+		}
+
+		return mapError(err)
+	})
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return reviewId, nil
+}
+
+// UpdateReview changes a review of userId. Reviews of other users and of
+// movies in the trash are storage.ErrNotFound.
+func (s *Storage) UpdateReview(ctx context.Context, reviewId int, userId int, patch ReviewPatch) error {
+	const op = "storage.postgres.UpdateReview"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.Exec(`UPDATE reviews SET
+									score = COALESCE($3::smallint, score),
+									body = CASE WHEN $4::text IS NULL THEN body ELSE NULLIF($4, '') END,
+									updated_at = now()
+									WHERE review_id=$1 AND user_id=$2
+									AND movie_id IN (SELECT movie_id FROM movies WHERE deleted_at IS NULL)`,
+			reviewId, userId, patch.Score, patch.Body)
+		if err != nil {
+			return mapError(err)
+		}
+
+		return expectAffected(res, storage.ErrNotFound)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// DeleteReview removes a review of userId for good.
+func (s *Storage) DeleteReview(ctx context.Context, reviewId int, userId int) error {
+	const op = "storage.postgres.DeleteReview"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.Exec("DELETE FROM reviews WHERE review_id=$1 AND user_id=$2", reviewId, userId)
+		if err != nil {
+			return err
+		}
+
+		return expectAffected(res, storage.ErrNotFound)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ModerateReview hides a review of any user or publishes it again.
+func (s *Storage) ModerateReview(ctx context.Context, reviewId int, status string) error {
+	const op = "storage.postgres.ModerateReview"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.Exec("UPDATE reviews SET status=$2 WHERE review_id=$1", reviewId, status)
+		if err != nil {
+			return mapError(err)
+		}
+
+		return expectAffected(res, storage.ErrNotFound)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+const reviewsOrdering = "review_id_desc"
+
+// GetReviews returns a page of the reviews matching filter, most recent
+// first. A movie of the filter that does not exist or is in the trash is
+// storage.ErrNotFound.
+func (s *Storage) GetReviews(filter ReviewFilter, page Page) ([]Review, PageInfo, error) {
+	const op = "storage.postgres.GetReviews"
+
+	if filter.MovieId != 0 {
+		var exists bool
+		err := s.Db.QueryRow("SELECT EXISTS(SELECT 1 FROM movies WHERE movie_id=$1 AND deleted_at IS NULL)", filter.MovieId).
+			Scan(&exists)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		if !exists {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, storage.ErrNotFound)
+		}
+	}
+
+	var b queryBuilder
+	if filter.MovieId != 0 {
+		b.where("r.movie_id = ?", filter.MovieId)
+	}
+	if filter.UserId != 0 {
+		b.where("r.user_id = ?", filter.UserId)
+	}
+	if filter.Status != "" {
+		b.where("r.status = ?", filter.Status)
+	}
+
+	count := b.snapshot()
+
+	if page.Cursor != "" {
+		values, err := decodeCursor(page.Cursor, reviewsOrdering, 1)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		b.where("r.review_id < ?", values[0])
+	}
+	limit := b.arg(page.limit() + 1)
+
+	rows, err := s.Db.Query(`SELECT r.review_id, r.movie_id, r.user_id, u.username, r.score, COALESCE(r.body, ''), r.status,
+								   r.created_at, r.updated_at
+								   FROM reviews r
+								   JOIN users u ON u.user_id = r.user_id
+								   `+b.whereClause()+`
+								   ORDER BY r.review_id DESC
+								   LIMIT `+limit, b.args...)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	reviews := []Review{}
+	for rows.Next() {
+		var review Review
+		err = rows.Scan(&review.Id, &review.MovieId, &review.UserId, &review.Username, &review.Score, &review.Body,
+			&review.Status, &review.CreatedAt, &review.UpdatedAt)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		reviews = append(reviews, review)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var info PageInfo
+	if len(reviews) > page.limit() {
+		reviews = reviews[:page.limit()]
+		info.NextCursor, err = encodeCursor(reviewsOrdering, reviews[len(reviews)-1].Id)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if page.WithTotal {
+		info.Total, err = s.count("SELECT count(*) FROM reviews r "+count.whereClause(), count.args...)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return reviews, info, nil
+}
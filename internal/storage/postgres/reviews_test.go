@@ -0,0 +1,92 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestReviews(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.EnsureDefaultRoles())
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveUser(ctx, "alice", "password"))
+	require.NoError(t, s.SaveUser(ctx, "bob", "password"))
+	alice, err := s.GetUser("alice", "password")
+	require.NoError(t, err)
+	bob, err := s.GetUser("bob", "password")
+	require.NoError(t, err)
+
+	reviewed, err := s.SaveMovie(ctx, "Stalker", "", "1979-05-25", 8, nil, postgres.MovieDetails{})
+	require.NoError(t, err)
+	unreviewed, err := s.SaveMovie(ctx, "Solaris", "", "1972-03-20", 8, nil, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	aliceReview, err := s.SaveReview(ctx, reviewed, alice, 9, "slow and beautiful")
+	require.NoError(t, err)
+	bobReview, err := s.SaveReview(ctx, reviewed, bob, 6, "")
+	require.NoError(t, err)
+
+	_, err = s.SaveReview(ctx, reviewed, alice, 10, "")
+	require.ErrorIs(t, err, storage.ErrConflict)
+	_, err = s.SaveReview(ctx, reviewed+unreviewed, alice, 10, "")
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	movie, err := s.GetMovie(reviewed)
+	require.NoError(t, err)
+	require.Equal(t, 2, movie.VoteCount)
+	require.InDelta(t, 7.5, *movie.CommunityScore, 0.001)
+	// votes do not bump the version
+	require.Equal(t, 1, movie.Version)
+
+	// only the author may change a review
+	score := 3
+	require.ErrorIs(t, s.UpdateReview(ctx, aliceReview, bob, postgres.ReviewPatch{Score: &score}), storage.ErrNotFound)
+	require.NoError(t, s.UpdateReview(ctx, bobReview, bob, postgres.ReviewPatch{Score: &score}))
+
+	movie, err = s.GetMovie(reviewed)
+	require.NoError(t, err)
+	require.InDelta(t, 6, *movie.CommunityScore, 0.001)
+
+	// hidden reviews leave the aggregate and the public list
+	require.NoError(t, s.ModerateReview(ctx, bobReview, postgres.ReviewHidden))
+
+	movie, err = s.GetMovie(reviewed)
+	require.NoError(t, err)
+	require.Equal(t, 1, movie.VoteCount)
+	require.InDelta(t, 9, *movie.CommunityScore, 0.001)
+
+	reviews, _, err := s.GetReviews(postgres.ReviewFilter{MovieId: reviewed, Status: postgres.ReviewPublished}, postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	require.Equal(t, "alice", reviews[0].Username)
+	require.Equal(t, "slow and beautiful", reviews[0].Body)
+
+	reviews, _, err = s.GetReviews(postgres.ReviewFilter{UserId: bob}, postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, reviews, 1)
+	require.Equal(t, postgres.ReviewHidden, reviews[0].Status)
+
+	_, _, err = s.GetReviews(postgres.ReviewFilter{MovieId: reviewed + unreviewed}, postgres.Page{})
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	movies, _, err := s.GetMovies([]postgres.SortKey{{Field: postgres.SortCommunityScore, Desc: true}}, postgres.MovieFilter{}, postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, movies, 2)
+	require.Equal(t, reviewed, movies[0].Id)
+	require.Nil(t, movies[1].CommunityScore)
+	require.Zero(t, movies[1].VoteCount)
+
+	require.ErrorIs(t, s.DeleteReview(ctx, aliceReview, bob), storage.ErrNotFound)
+	require.NoError(t, s.DeleteReview(ctx, aliceReview, alice))
+
+	movie, err = s.GetMovie(reviewed)
+	require.NoError(t, err)
+	require.Zero(t, movie.VoteCount)
+	require.Nil(t, movie.CommunityScore)
+}
@@ -0,0 +1,170 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strconv"
+)
+
+// MovieSearchResult is a movie matched by GetMoviesBySearchRequest. Headline
+// is an excerpt of the title and description with the matched words wrapped
+// in <b></b>.
+type MovieSearchResult struct {
+	Movie
+	Rank     float64 `json:"rank"`
+	Headline string  `json:"headline"`
+}
+
+const searchOrdering = "rank"
+
+var searchKeys = keyset{
+	{column: "rank", desc: true, param: "%s::text::float8"},
+	{column: "movie_id"},
+}
+
+// searchCandidates selects movie_id of the movies matching $1 together with
+// their rank. A movie matches when its search_vector, built from the title,
+// the actors' names and the description, matches the query, or when the
+// title or an actor's name or alias is similar to it, which catches typos.
+const searchCandidates = `
+	WITH q AS (
+		SELECT s.config, websearch_to_tsquery(s.config, $1) AS query FROM search_settings s
+	), actor_names AS (
+		SELECT actor_id, name FROM actors WHERE $1 <% name
+		UNION ALL
+		SELECT actor_id, alias FROM actor_aliases WHERE $1 <% alias
+	), actor_hits AS (
+		SELECT am.movie_id, max(word_similarity($1, n.name)) AS similarity
+		FROM actor_names n
+		JOIN actors a ON a.actor_id = n.actor_id
+		JOIN actor_movie am ON am.actor_id = a.actor_id
+		JOIN movies m ON m.movie_id = am.movie_id
+		WHERE a.deleted_at IS NULL AND m.deleted_at IS NULL
+		GROUP BY am.movie_id
+	), candidates AS (
+		SELECT m.movie_id FROM movies m, q WHERE m.search_vector @@ q.query AND m.deleted_at IS NULL
+		UNION
+		SELECT movie_id FROM movies WHERE $1 <% title AND deleted_at IS NULL
+		UNION
+		SELECT movie_id FROM actor_hits
+	), ranked AS (
+		SELECT m.movie_id,
+			(ts_rank_cd(m.search_vector, q.query)
+				+ greatest(word_similarity($1, m.title), coalesce(ah.similarity, 0)))::float8 AS rank
+		FROM candidates c
+		JOIN movies m ON m.movie_id = c.movie_id
+		CROSS JOIN q
+		LEFT JOIN actor_hits ah ON ah.movie_id = m.movie_id
+	)`
+
+// GetMoviesBySearchRequest returns a page of movies matching searchRequest,
+// most relevant first. searchRequest is parsed like a web search query, so
+// quoted phrases, "or" and "-word" are supported.
+func (s *Storage) GetMoviesBySearchRequest(searchRequest string, page Page) ([]MovieSearchResult, PageInfo, error) {
+	const op = "storage.postgres.GetMovieBySearchRequest"
+
+	// searchCandidates takes the search request as $1
+	var b queryBuilder
+	b.arg(searchRequest)
+	if page.Cursor != "" {
+		values, err := decodeCursor(page.Cursor, searchOrdering, len(searchKeys))
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		b.after(searchKeys, values)
+	}
+	limit := b.arg(page.limit() + 1)
+
+	rows, err := s.Db.Query(searchCandidates+`
+								SELECT m.movie_id, m.title, m.description, to_char(m.release_date, 'YYYY-MM-DD'), m.rating, m.version,
+									m.community_score::float8, m.vote_count,
+									COALESCE(m.runtime, 0), COALESCE(m.original_language, ''), COALESCE(m.certification, ''), r.rank,
+									ts_headline(q.config, m.title || ' ' || m.description, q.query, 'MaxFragments=2, MinWords=5, MaxWords=20')
+								FROM (SELECT movie_id, rank FROM ranked `+b.whereClause()+` ORDER BY `+searchKeys.orderBy()+` LIMIT `+limit+`) r
+								JOIN movies m ON m.movie_id = r.movie_id
+								CROSS JOIN q
+								ORDER BY r.rank DESC, r.movie_id`, b.args...)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	movies := []MovieSearchResult{}
+	for rows.Next() {
+		var movie MovieSearchResult
+		err = rows.Scan(&movie.Id, &movie.Title, &movie.Description, &movie.ReleaseDate, &movie.Rating, &movie.Version,
+			&movie.CommunityScore, &movie.VoteCount, &movie.Runtime, &movie.OriginalLanguage, &movie.Certification, &movie.Rank, &movie.Headline)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		movies = append(movies, movie)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var info PageInfo
+	if len(movies) > page.limit() {
+		movies = movies[:page.limit()]
+		last := movies[len(movies)-1]
+		info.NextCursor, err = encodeCursor(searchOrdering, strconv.FormatFloat(last.Rank, 'g', -1, 64), last.Id)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if page.WithTotal {
+		info.Total, err = s.count(searchCandidates+" SELECT count(*) FROM candidates", searchRequest)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	ids := make([]int, len(movies))
+	for i, movie := range movies {
+		ids[i] = movie.Id
+	}
+	actors, err := s.actorIds(ids)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+	ptrs := make([]*Movie, len(movies))
+	for i := range movies {
+		movies[i].Actors = actors[movies[i].Id]
+		ptrs[i] = &movies[i].Movie
+	}
+	if err = s.loadMetadata(ptrs); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return movies, info, nil
+}
+
+// SetSearchLanguage sets the text search configuration, e.g. "english",
+// used to stem movie titles, descriptions and search queries, and rebuilds
+// the search index if it changed.
+func (s *Storage) SetSearchLanguage(language string) error {
+	const op = "storage.postgres.SetSearchLanguage"
+
+	err := s.WithTx(context.Background(), func(tx *sql.Tx) error {
+		res, err := tx.Exec("UPDATE search_settings SET config = $1::text::regconfig WHERE config <> $1::text::regconfig", language)
+		if err != nil {
+			return err
+		}
+
+		changed, err := res.RowsAffected()
+		if err != nil || changed == 0 {
+			return err
+		}
+
+		_, err = tx.Exec("UPDATE movies SET search_vector = movie_search_document(movie_id, title, description)")
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage/postgres"
+)
+
+func TestGetMoviesBySearchRequest(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.SetSearchLanguage("english"))
+
+	actorId, err := s.SaveActor(context.Background(), "Marlon Brando", "male", "1924-04-03", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	godfather, err := s.SaveMovie(context.Background(), "The Godfather", "The aging patriarch of an organized crime dynasty transfers control to his son.",
+		"1972-03-24", 9, []int{actorId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	// no actors, which the old inner join never found
+	runners, err := s.SaveMovie(context.Background(), "Running Man", "A wrongly convicted man runs for his life in a televised game show.",
+		"1987-11-13", 6, nil, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name  string
+		query string
+		want  []int
+	}{
+		{name: "Title", query: "godfather", want: []int{godfather}},
+		{name: "Stemming", query: "runs", want: []int{runners}},
+		{name: "Description", query: "patriarch", want: []int{godfather}},
+		{name: "Actor", query: "brando", want: []int{godfather}},
+		{name: "Typo", query: "Godfater", want: []int{godfather}},
+		{name: "Actor typo", query: "Marlon Brandon", want: []int{godfather}},
+		{name: "No match", query: "spaceship", want: []int{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			movies, _, err := s.GetMoviesBySearchRequest(tc.query, postgres.Page{})
+			require.NoError(t, err)
+
+			got := []int{}
+			for _, movie := range movies {
+				got = append(got, movie.Id)
+			}
+			require.Equal(t, tc.want, got)
+		})
+	}
+
+	movies, _, err := s.GetMoviesBySearchRequest("godfather", postgres.Page{})
+	require.NoError(t, err)
+	require.Equal(t, []int{actorId}, movies[0].Actors)
+	require.Contains(t, movies[0].Headline, "<b>Godfather</b>")
+}
+
+func TestGetMoviesBySearchRequestPages(t *testing.T) {
+	s := newTestStorage(t)
+	seedCatalogue(t, s, 25, 5, 2)
+
+	var (
+		seen   []int
+		cursor string
+	)
+	for {
+		movies, info, err := s.GetMoviesBySearchRequest("movie", postgres.Page{Limit: 10, Cursor: cursor, WithTotal: true})
+		require.NoError(t, err)
+		require.Equal(t, 25, *info.Total)
+
+		for i, movie := range movies {
+			if i > 0 {
+				require.GreaterOrEqual(t, movies[i-1].Rank, movie.Rank)
+			}
+			seen = append(seen, movie.Id)
+		}
+
+		if info.NextCursor == "" {
+			break
+		}
+		cursor = info.NextCursor
+	}
+
+	require.Len(t, seen, 25)
+	require.ElementsMatch(t, seen, uniq(seen))
+}
+
+func uniq(ids []int) []int {
+	set := map[int]struct{}{}
+	res := []int{}
+	for _, id := range ids {
+		if _, ok := set[id]; !ok {
+			set[id] = struct{}{}
+			res = append(res, id)
+		}
+	}
+	return res
+}
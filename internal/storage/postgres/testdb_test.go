@@ -0,0 +1,81 @@
+package postgres_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage/postgres"
+)
+
+// dsnEnv names the variable holding the DSN of a Postgres database that
+// tests may create and drop schemas in. Tests needing a database are
+// skipped when it is not set.
+const dsnEnv = "FILM_LIBRARY_TEST_DSN"
+
+// titleAsc sorts movie listings by title.
+var titleAsc = []postgres.SortKey{{Field: postgres.SortTitle}}
+
+// newTestStorage returns a Storage on a fresh schema of the test database,
+// migrated to the latest version. The schema is dropped at cleanup.
+func newTestStorage(tb testing.TB) *postgres.Storage {
+	tb.Helper()
+
+	dsn := os.Getenv(dsnEnv)
+	if dsn == "" {
+		tb.Skipf("%s is not set", dsnEnv)
+	}
+
+	admin, err := sql.Open("pgx", dsn)
+	require.NoError(tb, err)
+	tb.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("test_%d", time.Now().UnixNano())
+	_, err = admin.Exec("CREATE SCHEMA " + schema)
+	require.NoError(tb, err)
+	tb.Cleanup(func() {
+		_, _ = admin.Exec("DROP SCHEMA " + schema + " CASCADE")
+	})
+
+	u, err := url.Parse(dsn)
+	require.NoError(tb, err)
+	q := u.Query()
+	q.Set("search_path", schema)
+	u.RawQuery = q.Encode()
+
+	s, err := postgres.New(u.String())
+	require.NoError(tb, err)
+	tb.Cleanup(func() { s.Db.Close() })
+
+	return s
+}
+
+// seedCatalogue inserts movies and actors with castSize actors per movie.
+func seedCatalogue(tb testing.TB, s *postgres.Storage, movies int, actors int, castSize int) {
+	tb.Helper()
+
+	_, err := s.Db.Exec(`INSERT INTO actors(name, gender, birthdate)
+								SELECT 'Actor ' || i, 'male', DATE '1970-01-01' + i
+								FROM generate_series(1, $1) AS i`, actors)
+	require.NoError(tb, err)
+
+	_, err = s.Db.Exec(`INSERT INTO movies(title, description, release_date, rating)
+								SELECT 'Movie ' || i, 'Description ' || i, DATE '2000-01-01' + i, i % 11
+								FROM generate_series(1, $1) AS i`, movies)
+	require.NoError(tb, err)
+
+	_, err = s.Db.Exec(`INSERT INTO actor_movie(movie_id, actor_id)
+								SELECT m.movie_id, a.actor_id
+								FROM movies m
+								CROSS JOIN LATERAL (
+									SELECT actor_id FROM actors
+									ORDER BY (actor_id * 7919 + m.movie_id) % $2
+									LIMIT $1
+								) a`, castSize, actors)
+	require.NoError(tb, err)
+}
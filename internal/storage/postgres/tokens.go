@@ -0,0 +1,152 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"film_library/internal/storage"
+	"fmt"
+	"time"
+)
+
+// SaveRefreshToken stores the first token of a new refresh token family.
+func (s *Storage) SaveRefreshToken(userId int, tokenHash string, expiresAt time.Time) error {
+	const op = "storage.postgres.SaveRefreshToken"
+
+	_, err := s.Db.Exec(`INSERT INTO refresh_tokens(family_id, user_id, token_hash, expires_at)
+								VALUES (gen_random_uuid(), $1, $2, $3)`, userId, tokenHash, expiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RotateRefreshToken exchanges a refresh token for a new one of the same
+// family. Presenting a token that was already rotated or revoked means it
+// leaked, so the whole family is revoked and ErrTokenReused is returned.
+func (s *Storage) RotateRefreshToken(tokenHash string, newTokenHash string, expiresAt time.Time) (int, error) {
+	const op = "storage.postgres.RotateRefreshToken"
+
+	var userId int
+	// reuse revokes the whole family, which must be committed even though
+	// the rotation itself fails
+	reused := false
+	err := s.WithTx(context.Background(), func(tx *sql.Tx) error {
+		var tokenId int64
+		var familyId string
+		var tokenExpiresAt time.Time
+		var revokedAt sql.NullTime
+		err := tx.QueryRow(`SELECT token_id, user_id, family_id, expires_at, revoked_at
+								  FROM refresh_tokens WHERE token_hash=$1 FOR UPDATE`, tokenHash).
+			Scan(&tokenId, &userId, &familyId, &tokenExpiresAt, &revokedAt)
+		if errors.Is(err, sql.ErrNoRows) {
+			return storage.ErrTokenNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if revokedAt.Valid {
+			reused = true
+			_, err = tx.Exec("UPDATE refresh_tokens SET revoked_at=now() WHERE family_id=$1 AND revoked_at IS NULL", familyId)
+
+			return err
+		}
+
+		if time.Now().After(tokenExpiresAt) {
+			return storage.ErrTokenExpired
+		}
+
+		_, err = tx.Exec("UPDATE refresh_tokens SET revoked_at=now() WHERE token_id=$1", tokenId)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO refresh_tokens(family_id, user_id, token_hash, expires_at)
+								VALUES ($1, $2, $3, $4)`, familyId, userId, newTokenHash, expiresAt)
+
+		return err
+	})
+	if err != nil {
+		return -1, fmt.Errorf("%s: %w", op, err)
+	}
+
+	if reused {
+		return -1, fmt.Errorf("%s: %w", op, storage.ErrTokenReused)
+	}
+
+	return userId, nil
+}
+
+// RevokeRefreshTokenFamily revokes every token rotated from the same signin
+// as tokenHash. Only the owner of the token may revoke it.
+func (s *Storage) RevokeRefreshTokenFamily(userId int, tokenHash string) error {
+	const op = "storage.postgres.RevokeRefreshTokenFamily"
+
+	res, err := s.Db.Exec(`UPDATE refresh_tokens SET revoked_at=now()
+								 WHERE family_id=(SELECT family_id FROM refresh_tokens WHERE token_hash=$1 AND user_id=$2)
+								 AND revoked_at IS NULL`, tokenHash, userId)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if n > 0 {
+		return nil
+	}
+
+	// Nothing was updated: either the family is already revoked, which is
+	// fine, or the token does not belong to this user.
+	var exists bool
+	err = s.Db.QueryRow("SELECT EXISTS(SELECT 1 FROM refresh_tokens WHERE token_hash=$1 AND user_id=$2)",
+		tokenHash, userId).Scan(&exists)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+	if !exists {
+		return fmt.Errorf("%s: %w", op, storage.ErrTokenNotFound)
+	}
+
+	return nil
+}
+
+// RevokeAccessToken puts an access token on the denylist until it expires.
+// Entries whose token has expired anyway are dropped on the way.
+func (s *Storage) RevokeAccessToken(jti string, expiresAt time.Time) error {
+	const op = "storage.postgres.RevokeAccessToken"
+
+	_, err := s.Db.Exec(`INSERT INTO revoked_access_tokens(jti, expires_at) VALUES ($1, $2)
+								ON CONFLICT (jti) DO NOTHING`, jti, expiresAt)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	_, err = s.Db.Exec("DELETE FROM revoked_access_tokens WHERE expires_at < now()")
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// IsAccessTokenRevoked tells whether the access token jti of userId, issued
+// at issuedAt, was signed out or issued before all of the user's tokens
+// were revoked. Token times are whole seconds, so a token issued within the
+// second of a revocation counts as revoked too.
+func (s *Storage) IsAccessTokenRevoked(jti string, userId int, issuedAt time.Time) (bool, error) {
+	const op = "storage.postgres.IsAccessTokenRevoked"
+
+	var revoked bool
+	err := s.Db.QueryRow(`SELECT EXISTS(SELECT 1 FROM revoked_access_tokens WHERE jti=$1)
+								 OR EXISTS(SELECT 1 FROM user_token_revocations WHERE user_id=$2 AND $3 < revoked_before)`,
+		jti, userId, issuedAt).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return revoked, nil
+}
@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"film_library/internal/storage"
+	"fmt"
+	"time"
+)
+
+const (
+	EntityMovie = "movie"
+	EntityActor = "actor"
+)
+
+// TrashItem is a movie or an actor in the trash. Name is the title of a
+// movie.
+type TrashItem struct {
+	EntityType string    `json:"entity_type"`
+	Id         int       `json:"entity_id"`
+	Name       string    `json:"name"`
+	DeletedAt  time.Time `json:"deleted_at"`
+}
+
+var trashKeys = keyset{
+	{column: "deleted_at", desc: true, param: "%s::text::timestamptz"},
+	{column: "entity_type"},
+	{column: "id"},
+}
+
+const trashOrdering = "deleted_at_desc"
+
+// trash selects the movies and actors in the trash, of type $1 or of both
+// types when $1 is empty.
+const trash = `
+	SELECT * FROM (
+		SELECT 'movie' AS entity_type, movie_id AS id, title AS name, deleted_at FROM movies WHERE deleted_at IS NOT NULL
+		UNION ALL
+		SELECT 'actor', actor_id, name, deleted_at FROM actors WHERE deleted_at IS NOT NULL
+	) t WHERE $1 = '' OR entity_type = $1`
+
+// GetTrash returns a page of the movies and actors in the trash, or of
+// those of entityType if it is not empty, most recently deleted first.
+func (s *Storage) GetTrash(entityType string, page Page) ([]TrashItem, PageInfo, error) {
+	const op = "storage.postgres.GetTrash"
+
+	// the trash query takes the entity type as $1
+	var b queryBuilder
+	b.arg(entityType)
+	if page.Cursor != "" {
+		values, err := decodeCursor(page.Cursor, trashOrdering, len(trashKeys))
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		b.after(trashKeys, values)
+	}
+	limit := b.arg(page.limit() + 1)
+
+	rows, err := s.Db.Query(`SELECT entity_type, id, name, deleted_at FROM (`+trash+`) trash
+								   `+b.whereClause()+`
+								   ORDER BY `+trashKeys.orderBy()+`
+								   LIMIT `+limit, b.args...)
+	if err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	items := []TrashItem{}
+	for rows.Next() {
+		var item TrashItem
+		if err = rows.Scan(&item.EntityType, &item.Id, &item.Name, &item.DeletedAt); err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+	}
+
+	var info PageInfo
+	if len(items) > page.limit() {
+		items = items[:page.limit()]
+		last := items[len(items)-1]
+		info.NextCursor, err = encodeCursor(trashOrdering, last.DeletedAt.Format(time.RFC3339Nano), last.EntityType, last.Id)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	if page.WithTotal {
+		info.Total, err = s.count("SELECT count(*) FROM ("+trash+") trash", entityType)
+		if err != nil {
+			return nil, PageInfo{}, fmt.Errorf("%s: %w", op, err)
+		}
+	}
+
+	return items, info, nil
+}
+
+// RestoreMovie takes a movie out of the trash together with its cast.
+func (s *Storage) RestoreMovie(ctx context.Context, movieId int) error {
+	const op = "storage.postgres.RestoreMovie"
+
+	if err := s.restore(ctx, "movies", "movie_id", movieId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// RestoreActor takes an actor out of the trash and back into the casts
+// they were in.
+func (s *Storage) RestoreActor(ctx context.Context, actorId int) error {
+	const op = "storage.postgres.RestoreActor"
+
+	if err := s.restore(ctx, "actors", "actor_id", actorId); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) restore(ctx context.Context, table string, idColumn string, id int) error {
+	return s.WithTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.Exec(fmt.Sprintf("UPDATE %s SET deleted_at=NULL WHERE %s=$1 AND deleted_at IS NOT NULL", table, idColumn), id)
+		if err != nil {
+			return err
+		}
+
+		if err = expectAffected(res, storage.ErrNotFound); err != nil {
+			return err
+		}
+
+		return touchCredited(tx, table, id)
+	})
+}
+
+// touchCredited moves the actors of a movie, or the movies of an actor, to
+// their next version when the row of table with the given id goes to or
+// comes back from the trash, since their filmography or cast changes.
+func touchCredited(tx *sql.Tx, table string, id int) error {
+	query := "UPDATE actors SET version = version WHERE actor_id IN (SELECT actor_id FROM actor_movie WHERE movie_id=$1)"
+	if table == "actors" {
+		query = "UPDATE movies SET version = version WHERE movie_id IN (SELECT movie_id FROM actor_movie WHERE actor_id=$1)"
+	}
+
+	_, err := tx.Exec(query, id)
+
+	return err
+}
+
+// PurgeDeleted removes for good the movies and actors that were moved to
+// the trash before the given time, with their casts, and returns how many
+// it removed.
+func (s *Storage) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	const op = "storage.postgres.PurgeDeleted"
+
+	var purged int64
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`DELETE FROM actor_movie
+								 WHERE movie_id IN (SELECT movie_id FROM movies WHERE deleted_at < $1)
+								 OR actor_id IN (SELECT actor_id FROM actors WHERE deleted_at < $1)`, before)
+		if err != nil {
+			return err
+		}
+
+		for _, table := range []string{"movies", "actors"} {
+			res, err := tx.Exec(fmt.Sprintf("DELETE FROM %s WHERE deleted_at < $1", table), before)
+			if err != nil {
+				return err
+			}
+
+			n, err := res.RowsAffected()
+			if err != nil {
+				return err
+			}
+			purged += n
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return int(purged), nil
+}
@@ -0,0 +1,106 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestTrash(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	actorId, err := s.SaveActor(ctx, "Marlon Brando", "male", "1924-04-03", postgres.ActorProfile{})
+	require.NoError(t, err)
+	movieId, err := s.SaveMovie(ctx, "The Godfather", "Crime saga", "1972-03-24", 9, []int{actorId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	before, err := s.GetActor(actorId)
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteMovie(ctx, movieId, postgres.AnyVersion))
+
+	_, err = s.GetMovie(movieId)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+
+	movies, _, err := s.GetMovies([]postgres.SortKey{{Field: postgres.SortRating, Desc: true}}, postgres.MovieFilter{}, postgres.Page{})
+	require.NoError(t, err)
+	require.Empty(t, movies)
+
+	found, _, err := s.GetMoviesBySearchRequest("godfather", postgres.Page{})
+	require.NoError(t, err)
+	require.Empty(t, found)
+
+	actor, err := s.GetActor(actorId)
+	require.NoError(t, err)
+	require.Empty(t, actor.Movies)
+	require.Greater(t, actor.Version, before.Version)
+
+	_, err = s.UpdateMovie(ctx, movieId, postgres.MoviePatch{}, postgres.AnyVersion)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+	require.ErrorIs(t, s.DeleteMovie(ctx, movieId, postgres.AnyVersion), storage.ErrNotFound)
+
+	require.NoError(t, s.DeleteActor(ctx, actorId, postgres.AnyVersion))
+
+	items, info, err := s.GetTrash("", postgres.Page{Limit: 1, WithTotal: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, *info.Total)
+	require.Equal(t, []postgres.TrashItem{{EntityType: postgres.EntityActor, Id: actorId, Name: "Marlon Brando", DeletedAt: items[0].DeletedAt}}, items)
+
+	items, _, err = s.GetTrash("", postgres.Page{Cursor: info.NextCursor})
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	require.Equal(t, movieId, items[0].Id)
+
+	// the cast comes back with the movie
+	require.NoError(t, s.RestoreActor(ctx, actorId))
+	before, err = s.GetActor(actorId)
+	require.NoError(t, err)
+	require.NoError(t, s.RestoreMovie(ctx, movieId))
+	require.ErrorIs(t, s.RestoreActor(ctx, actorId), storage.ErrNotFound)
+
+	actor, err = s.GetActor(actorId)
+	require.NoError(t, err)
+	require.Greater(t, actor.Version, before.Version)
+
+	movie, err := s.GetMovie(movieId)
+	require.NoError(t, err)
+	require.Equal(t, []int{actorId}, movie.Actors)
+
+	found, _, err = s.GetMoviesBySearchRequest("brando", postgres.Page{})
+	require.NoError(t, err)
+	require.Len(t, found, 1)
+}
+
+func TestPurgeDeleted(t *testing.T) {
+	s := newTestStorage(t)
+	ctx := context.Background()
+
+	actorId, err := s.SaveActor(ctx, "Actor", "female", "1980-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+	movieId, err := s.SaveMovie(ctx, "Movie", "Description", "2000-01-01", 5, []int{actorId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+	keptId, err := s.SaveMovie(ctx, "Kept", "Description", "2000-01-01", 5, []int{actorId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	require.NoError(t, s.DeleteMovie(ctx, movieId, postgres.AnyVersion))
+
+	purged, err := s.PurgeDeleted(ctx, time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, purged)
+
+	purged, err = s.PurgeDeleted(ctx, time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, purged)
+
+	require.ErrorIs(t, s.RestoreMovie(ctx, movieId), storage.ErrNotFound)
+
+	actor, err := s.GetActor(actorId)
+	require.NoError(t, err)
+	require.Equal(t, []int{keptId}, actor.Movies)
+}
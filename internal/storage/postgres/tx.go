@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"film_library/internal/lib/audit"
+	"strconv"
+)
+
+// WithTx runs fn in a transaction. The transaction is committed when fn
+// returns nil and rolled back when it returns an error or panics, so the
+// statements of fn take effect all together or not at all.
+//
+// When ctx carries an audit.Actor the changes made by fn are attributed to
+// it in audit_events.
+func (s *Storage) WithTx(ctx context.Context, fn func(tx *sql.Tx) error) error {
+	tx, err := s.Db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	// no-op once committed
+	defer tx.Rollback()
+
+	if actor, ok := audit.FromContext(ctx); ok {
+		if err = setActor(tx, actor); err != nil {
+			return err
+		}
+	}
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// setActor sets the settings read by audit_trigger for the rest of tx.
+func setActor(tx *sql.Tx, actor audit.Actor) error {
+	userId := ""
+	if actor.UserId != 0 {
+		userId = strconv.Itoa(actor.UserId)
+	}
+
+	_, err := tx.Exec("SELECT set_config('audit.user_id', $1, true), set_config('audit.request_id', $2, true)",
+		userId, actor.RequestId)
+
+	return err
+}
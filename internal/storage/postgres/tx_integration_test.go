@@ -0,0 +1,52 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestSaveMovieRollsBack(t *testing.T) {
+	s := newTestStorage(t)
+
+	actorId, err := s.SaveActor(context.Background(), "Actor", "female", "1980-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	_, err = s.SaveMovie(context.Background(), "Movie", "Description", "2000-01-01", 5, []int{actorId, actorId + 1}, postgres.MovieDetails{})
+	require.ErrorIs(t, err, storage.ErrForeignKey)
+
+	var movies, links int
+	require.NoError(t, s.Db.QueryRow("SELECT count(*) FROM movies").Scan(&movies))
+	require.NoError(t, s.Db.QueryRow("SELECT count(*) FROM actor_movie").Scan(&links))
+	require.Zero(t, movies)
+	require.Zero(t, links)
+}
+
+func TestPurgeDeletedRollsBack(t *testing.T) {
+	s := newTestStorage(t)
+
+	actorId, err := s.SaveActor(context.Background(), "Actor", "female", "1980-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+	_, err = s.SaveMovie(context.Background(), "Movie", "Description", "2000-01-01", 5, []int{actorId}, postgres.MovieDetails{})
+	require.NoError(t, err)
+	require.NoError(t, s.DeleteActor(context.Background(), actorId, postgres.AnyVersion))
+
+	// fail the last statement, after the cast has been deleted
+	_, err = s.Db.Exec(`CREATE FUNCTION fail() RETURNS TRIGGER AS $$
+							  BEGIN RAISE EXCEPTION 'injected failure'; END
+							  $$ LANGUAGE plpgsql;
+							  CREATE TRIGGER fail BEFORE DELETE ON actors FOR EACH ROW EXECUTE FUNCTION fail();`)
+	require.NoError(t, err)
+
+	_, err = s.PurgeDeleted(context.Background(), time.Now().Add(time.Hour))
+	require.Error(t, err)
+
+	var links int
+	require.NoError(t, s.Db.QueryRow("SELECT count(*) FROM actor_movie WHERE actor_id=$1", actorId).Scan(&links))
+	require.Equal(t, 1, links)
+}
@@ -0,0 +1,252 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/audit"
+)
+
+var errInjected = errors.New("injected failure")
+
+// fakeDB is a database/sql driver that accepts every statement, failing
+// the one containing failOn once skip matching statements have passed.
+// It records statements and transaction boundaries in log.
+type fakeDB struct {
+	mu     sync.Mutex
+	failOn string
+	skip   int
+	log    []string
+}
+
+func (d *fakeDB) record(entry string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.log = append(d.log, entry)
+}
+
+func (d *fakeDB) run(query string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.failOn != "" && strings.Contains(query, d.failOn) {
+		if d.skip == 0 {
+			d.log = append(d.log, "FAIL")
+			return errInjected
+		}
+		d.skip--
+	}
+	fields := strings.Fields(query)
+	if len(fields) > 3 {
+		fields = fields[:3]
+	}
+	d.log = append(d.log, strings.Join(fields, " "))
+
+	return nil
+}
+
+func (d *fakeDB) Connect(context.Context) (driver.Conn, error) { return fakeConn{d}, nil }
+func (d *fakeDB) Driver() driver.Driver                        { return nil }
+
+type fakeConn struct{ db *fakeDB }
+
+func (c fakeConn) Prepare(query string) (driver.Stmt, error) { return fakeStmt{c.db, query}, nil }
+func (c fakeConn) Close() error                              { return nil }
+
+func (c fakeConn) Begin() (driver.Tx, error) {
+	c.db.record("BEGIN")
+	return fakeTx{c.db}, nil
+}
+
+type fakeTx struct{ db *fakeDB }
+
+func (t fakeTx) Commit() error {
+	t.db.record("COMMIT")
+	return nil
+}
+
+func (t fakeTx) Rollback() error {
+	t.db.record("ROLLBACK")
+	return nil
+}
+
+type fakeStmt struct {
+	db    *fakeDB
+	query string
+}
+
+func (s fakeStmt) Close() error  { return nil }
+func (s fakeStmt) NumInput() int { return -1 }
+
+func (s fakeStmt) Exec([]driver.Value) (driver.Result, error) {
+	if err := s.db.run(s.query); err != nil {
+		return nil, err
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	if err := s.db.run(s.query); err != nil {
+		return nil, err
+	}
+	return &fakeRows{}, nil
+}
+
+// fakeRows is a single row with a single id column.
+type fakeRows struct{ done bool }
+
+func (r *fakeRows) Columns() []string { return []string{"id"} }
+func (r *fakeRows) Close() error      { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.done {
+		return io.EOF
+	}
+	r.done = true
+	dest[0] = int64(1)
+	return nil
+}
+
+func TestWithTx(t *testing.T) {
+	cases := []struct {
+		name   string
+		failOn string
+		skip   int
+		call   func(s *Storage) error
+		log    []string
+	}{
+		{
+			name: "SaveMovie",
+			call: func(s *Storage) error {
+				_, err := s.SaveMovie(context.Background(), "Title", "Description", "2000-01-01", 5, []int{1, 2}, MovieDetails{})
+				return err
+			},
+			log: []string{"BEGIN", "INSERT INTO movies(title,", "INSERT INTO actor_movie(movie_id,", "INSERT INTO actor_movie(movie_id,", "COMMIT"},
+		},
+		{
+			name:   "SaveMovie fails on cast",
+			failOn: "INSERT INTO actor_movie",
+			skip:   1,
+			call: func(s *Storage) error {
+				_, err := s.SaveMovie(context.Background(), "Title", "Description", "2000-01-01", 5, []int{1, 2}, MovieDetails{})
+				return err
+			},
+			log: []string{"BEGIN", "INSERT INTO movies(title,", "INSERT INTO actor_movie(movie_id,", "FAIL", "ROLLBACK"},
+		},
+		{
+			name:   "SaveActorMovie fails midway",
+			failOn: "INSERT INTO actor_movie",
+			skip:   2,
+			call: func(s *Storage) error {
+				return s.SaveActorMovie(context.Background(), 1, actorCredits([]int{1, 2, 3}))
+			},
+			log: []string{"BEGIN", "INSERT INTO actor_movie(movie_id,", "INSERT INTO actor_movie(movie_id,", "FAIL", "ROLLBACK"},
+		},
+		{
+			name:   "DeleteActorMovie fails midway",
+			failOn: "DELETE FROM actor_movie",
+			skip:   1,
+			call: func(s *Storage) error {
+				return s.DeleteActorMovie(context.Background(), 1, []int{1, 2})
+			},
+			log: []string{"BEGIN", "DELETE FROM actor_movie", "FAIL", "ROLLBACK"},
+		},
+		{
+			name:   "DeleteActor fails on actor",
+			failOn: "UPDATE actors",
+			call: func(s *Storage) error {
+				return s.DeleteActor(context.Background(), 1, AnyVersion)
+			},
+			log: []string{"BEGIN", "SELECT version FROM", "FAIL", "ROLLBACK"},
+		},
+		{
+			name:   "DeleteMovie fails on movie",
+			failOn: "UPDATE movies",
+			call: func(s *Storage) error {
+				return s.DeleteMovie(context.Background(), 1, AnyVersion)
+			},
+			log: []string{"BEGIN", "SELECT version FROM", "FAIL", "ROLLBACK"},
+		},
+		{
+			name:   "PurgeDeleted fails on actors",
+			failOn: "DELETE FROM actors",
+			call: func(s *Storage) error {
+				_, err := s.PurgeDeleted(context.Background(), time.Now())
+				return err
+			},
+			log: []string{"BEGIN", "DELETE FROM actor_movie", "DELETE FROM movies", "FAIL", "ROLLBACK"},
+		},
+		{
+			name:   "ImportBatch fails on an actor",
+			failOn: "UPDATE actors",
+			call: func(s *Storage) error {
+				_, err := s.ImportBatch(context.Background(), []ImportRow{
+					{Actor: &ImportActor{Name: "Name", Gender: "female", Birthdate: "2000-01-01"}},
+				})
+				return err
+			},
+			log: []string{"BEGIN", "SELECT pg_advisory_xact_lock($1)", "SAVEPOINT import_row", "SELECT actor_id FROM", "FAIL", "ROLLBACK"},
+		},
+		{
+			name:   "ForcePasswordReset fails on token revocation",
+			failOn: "UPDATE refresh_tokens",
+			call: func(s *Storage) error {
+				return s.ForcePasswordReset(context.Background(), 1)
+			},
+			log: []string{"BEGIN", "UPDATE users SET", "FAIL", "ROLLBACK"},
+		},
+		{
+			name: "UpdateMovie records the actor",
+			call: func(s *Storage) error {
+				ctx := audit.NewContext(context.Background(), audit.Actor{UserId: 1, RequestId: "host/1"})
+				title := "Title"
+				_, err := s.UpdateMovie(ctx, 1, MoviePatch{Title: &title}, AnyVersion)
+				return err
+			},
+			log: []string{"BEGIN", "SELECT set_config('audit.user_id', $1,", "UPDATE movies SET", "COMMIT"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			db := &fakeDB{failOn: tc.failOn, skip: tc.skip}
+			s := &Storage{Db: sql.OpenDB(db)}
+			defer s.Db.Close()
+
+			err := tc.call(s)
+			if tc.failOn == "" {
+				require.NoError(t, err)
+			} else {
+				require.ErrorIs(t, err, errInjected)
+			}
+
+			require.Equal(t, tc.log, db.log)
+		})
+	}
+}
+
+func TestWithTxPanic(t *testing.T) {
+	db := &fakeDB{}
+	s := &Storage{Db: sql.OpenDB(db)}
+	defer s.Db.Close()
+
+	require.Panics(t, func() {
+		_ = s.WithTx(context.Background(), func(tx *sql.Tx) error {
+			panic("boom")
+		})
+	})
+
+	require.Equal(t, []string{"BEGIN", "ROLLBACK"}, db.log)
+}
@@ -0,0 +1,106 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestUpdateMovie(t *testing.T) {
+	s := newTestStorage(t)
+
+	movieId, err := s.SaveMovie(context.Background(), "Movie", "Description", "2000-01-01", 5, nil, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	title, rating := "New title", 7
+	version, err := s.UpdateMovie(context.Background(), movieId, postgres.MoviePatch{Title: &title, Rating: &rating}, postgres.AnyVersion)
+	require.NoError(t, err)
+
+	movie, err := s.GetMovie(movieId)
+	require.NoError(t, err)
+	require.Equal(t, "New title", movie.Title)
+	require.Equal(t, "Description", movie.Description)
+	require.Equal(t, "2000-01-01", movie.ReleaseDate)
+	require.Equal(t, 7, movie.Rating)
+	require.Equal(t, version, movie.Version)
+
+	// a rejected field leaves the others unchanged too
+	title, rating = "Other title", 11
+	_, err = s.UpdateMovie(context.Background(), movieId, postgres.MoviePatch{Title: &title, Rating: &rating}, postgres.AnyVersion)
+	require.ErrorIs(t, err, storage.ErrInvalidValue)
+
+	movie, err = s.GetMovie(movieId)
+	require.NoError(t, err)
+	require.Equal(t, "New title", movie.Title)
+
+	_, err = s.UpdateMovie(context.Background(), movieId+1, postgres.MoviePatch{Title: &title}, postgres.AnyVersion)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestUpdateActor(t *testing.T) {
+	s := newTestStorage(t)
+
+	actorId, err := s.SaveActor(context.Background(), "Actor", "male", "1980-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+
+	birthdate := "1981-02-03"
+	_, err = s.UpdateActor(context.Background(), actorId, postgres.ActorPatch{Birthdate: &birthdate}, postgres.AnyVersion)
+	require.NoError(t, err)
+
+	actor, err := s.GetActor(actorId)
+	require.NoError(t, err)
+	require.Equal(t, "Actor", actor.Name)
+	require.Equal(t, "male", actor.Gender)
+	require.Equal(t, "1981-02-03", actor.Birthdate)
+
+	_, err = s.UpdateActor(context.Background(), actorId+1, postgres.ActorPatch{Birthdate: &birthdate}, postgres.AnyVersion)
+	require.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestVersions(t *testing.T) {
+	s := newTestStorage(t)
+
+	actorId, err := s.SaveActor(context.Background(), "Actor", "male", "1980-01-01", postgres.ActorProfile{})
+	require.NoError(t, err)
+	movieId, err := s.SaveMovie(context.Background(), "Movie", "Description", "2000-01-01", 5, nil, postgres.MovieDetails{})
+	require.NoError(t, err)
+
+	movie, err := s.GetMovie(movieId)
+	require.NoError(t, err)
+	actor, err := s.GetActor(actorId)
+	require.NoError(t, err)
+
+	// a cast change moves both the movie and the actor to a new version
+	require.NoError(t, s.SaveActorMovie(context.Background(), movieId, []postgres.Credit{{ActorId: actorId, CreditType: postgres.CreditActor}}))
+
+	changedMovie, err := s.GetMovie(movieId)
+	require.NoError(t, err)
+	require.Greater(t, changedMovie.Version, movie.Version)
+	changedActor, err := s.GetActor(actorId)
+	require.NoError(t, err)
+	require.Greater(t, changedActor.Version, actor.Version)
+
+	// rebuilding the search index is not a change of the movie
+	require.NoError(t, s.SetSearchLanguage("english"))
+	require.NoError(t, s.SetSearchLanguage("simple"))
+	reindexedMovie, err := s.GetMovie(movieId)
+	require.NoError(t, err)
+	require.Equal(t, changedMovie.Version, reindexedMovie.Version)
+
+	title := "New title"
+	_, err = s.UpdateMovie(context.Background(), movieId, postgres.MoviePatch{Title: &title}, movie.Version)
+	require.ErrorIs(t, err, storage.ErrVersionMismatch)
+	require.ErrorIs(t, s.DeleteMovie(context.Background(), movieId, movie.Version), storage.ErrVersionMismatch)
+	require.ErrorIs(t, s.DeleteActor(context.Background(), actorId, actor.Version), storage.ErrVersionMismatch)
+
+	version, err := s.UpdateMovie(context.Background(), movieId, postgres.MoviePatch{Title: &title}, changedMovie.Version)
+	require.NoError(t, err)
+	require.Equal(t, changedMovie.Version+1, version)
+
+	require.NoError(t, s.DeleteMovie(context.Background(), movieId, version))
+	require.ErrorIs(t, s.DeleteMovie(context.Background(), movieId, postgres.AnyVersion), storage.ErrNotFound)
+}
@@ -0,0 +1,364 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"film_library/internal/lib/password"
+	"film_library/internal/storage"
+	"fmt"
+	"github.com/jackc/pgx/v5/pgtype"
+	"strings"
+)
+
+type User struct {
+	Id                    int      `json:"user_id"`
+	Username              string   `json:"username"`
+	Roles                 []string `json:"roles"`
+	Disabled              bool     `json:"disabled"`
+	PasswordResetRequired bool     `json:"password_reset_required"`
+}
+
+// GetUsers returns users whose username contains query, case-insensitively.
+// An empty query returns every user.
+func (s *Storage) GetUsers(query string) ([]User, error) {
+	const op = "storage.postgres.GetUsers"
+
+	rows, err := s.Db.Query(`SELECT u.user_id, u.username, u.disabled_at IS NOT NULL, u.password_reset_required,
+								   COALESCE(array_agg(r.role_name ORDER BY r.role_name) FILTER (WHERE r.role_name IS NOT NULL), '{}')
+								   FROM users u
+								   LEFT JOIN user_role ur ON ur.user_id = u.user_id
+								   LEFT JOIN roles r ON r.role_id = ur.role_id
+								   WHERE u.username ILIKE $1
+								   GROUP BY u.user_id
+								   ORDER BY u.username`, containsPattern(query))
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+	defer rows.Close()
+
+	typeMap := pgtype.NewMap()
+
+	users := []User{}
+	for rows.Next() {
+		var user User
+		err = rows.Scan(&user.Id, &user.Username, &user.Disabled, &user.PasswordResetRequired,
+			typeMap.SQLScanner(&user.Roles))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", op, err)
+		}
+		users = append(users, user)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("%s: %w", op, err)
+	}
+
+	return users, nil
+}
+
+func (s *Storage) GrantRole(ctx context.Context, userId int, role string) error {
+	const op = "storage.postgres.GrantRole"
+
+	roleId, err := s.userAndRole(userId, role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT INTO user_role(user_id, role_id) SELECT $1, $2
+								 WHERE NOT EXISTS (SELECT 1 FROM user_role WHERE user_id=$1 AND role_id=$2)`, userId, roleId)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) RevokeRole(ctx context.Context, userId int, role string) error {
+	const op = "storage.postgres.RevokeRole"
+
+	roleId, err := s.userAndRole(userId, role)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.WithTx(ctx, func(tx *sql.Tx) error {
+		if role == RoleAdmin {
+			if err := keepAnAdmin(tx, userId); err != nil {
+				return err
+			}
+		}
+
+		if _, err := tx.Exec("DELETE FROM user_role WHERE user_id=$1 AND role_id=$2", userId, roleId); err != nil {
+			return err
+		}
+
+		// the roles of an access token are those it was issued with
+		return revokeUserAccessTokens(tx, userId)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// SetUserDisabled disables or re-enables an account. Disabling also revokes
+// all of the user's tokens, so existing sessions end at once. The last
+// enabled admin can not be disabled.
+func (s *Storage) SetUserDisabled(ctx context.Context, userId int, disabled bool) error {
+	const op = "storage.postgres.SetUserDisabled"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		if disabled {
+			if err := keepAnAdmin(tx, userId); err != nil {
+				return err
+			}
+		}
+
+		res, err := tx.Exec(`UPDATE users SET disabled_at = CASE WHEN $2 THEN COALESCE(disabled_at, now()) END
+								   WHERE user_id=$1`, userId, disabled)
+		if err != nil {
+			return err
+		}
+
+		if err = expectAffected(res, storage.ErrUserNotFound); err != nil {
+			return err
+		}
+
+		if !disabled {
+			return nil
+		}
+
+		if err = revokeUserRefreshTokens(tx, userId); err != nil {
+			return err
+		}
+
+		return revokeUserAccessTokens(tx, userId)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ForcePasswordReset makes the user choose a new password via
+// ChangePassword before the next signin and ends their current sessions at
+// once.
+func (s *Storage) ForcePasswordReset(ctx context.Context, userId int) error {
+	const op = "storage.postgres.ForcePasswordReset"
+
+	err := s.WithTx(ctx, func(tx *sql.Tx) error {
+		res, err := tx.Exec("UPDATE users SET password_reset_required=true WHERE user_id=$1", userId)
+		if err != nil {
+			return err
+		}
+
+		if err = expectAffected(res, storage.ErrUserNotFound); err != nil {
+			return err
+		}
+
+		if err = revokeUserRefreshTokens(tx, userId); err != nil {
+			return err
+		}
+
+		return revokeUserAccessTokens(tx, userId)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// ChangePassword replaces the password of a user who knows the current
+// one and clears a pending forced reset.
+func (s *Storage) ChangePassword(ctx context.Context, username string, oldPassword string, newPassword string) error {
+	const op = "storage.postgres.ChangePassword"
+
+	var userId int
+	var stored string
+	var disabled bool
+	err := s.Db.QueryRow("SELECT user_id, password, disabled_at IS NOT NULL FROM users WHERE username=$1", username).
+		Scan(&userId, &stored, &disabled)
+	if errors.Is(err, sql.ErrNoRows) {
+		password.VerifyDummy(oldPassword)
+		return fmt.Errorf("%s: %w", op, storage.ErrInvalidCredentials)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if ok, _ := password.Verify(stored, oldPassword); !ok {
+		return fmt.Errorf("%s: %w", op, storage.ErrInvalidCredentials)
+	}
+
+	if disabled {
+		return fmt.Errorf("%s: %w", op, storage.ErrUserDisabled)
+	}
+
+	hash, err := password.Hash(newPassword)
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	err = s.WithTx(ctx, func(tx *sql.Tx) error {
+		_, err := tx.Exec("UPDATE users SET password=$1, password_reset_required=false WHERE user_id=$2", hash, userId)
+		if err != nil {
+			return err
+		}
+
+		return revokeUserRefreshTokens(tx, userId)
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// EnsureDefaultRoles creates the user and admin roles if they are missing
+// and grants admin every known permission. It is safe to run on each start.
+func (s *Storage) EnsureDefaultRoles() error {
+	const op = "storage.postgres.EnsureDefaultRoles"
+
+	err := s.WithTx(context.Background(), func(tx *sql.Tx) error {
+		_, err := tx.Exec(`INSERT INTO roles(role_name) SELECT r.role_name FROM unnest($1::text[]) AS r(role_name)
+								 WHERE NOT EXISTS (SELECT 1 FROM roles WHERE roles.role_name = r.role_name)`,
+			[]string{RoleUser, RoleAdmin})
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(`INSERT INTO role_permission(role_id, permission_id)
+								SELECT r.role_id, p.permission_id FROM roles r, permissions p
+								WHERE r.role_name=$1
+								ON CONFLICT DO NOTHING`, RoleAdmin)
+
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+// EnsureAdmin makes sure username exists and has the admin role. The
+// password is only used when the user has to be created.
+func (s *Storage) EnsureAdmin(ctx context.Context, username string, plainPassword string) error {
+	const op = "storage.postgres.EnsureAdmin"
+
+	var userId int
+	err := s.Db.QueryRow("SELECT user_id FROM users WHERE username=$1", username).Scan(&userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		if err = s.SaveUser(ctx, username, plainPassword); err != nil {
+			return fmt.Errorf("%s: %w", op, err)
+		}
+
+		err = s.Db.QueryRow("SELECT user_id FROM users WHERE username=$1", username).Scan(&userId)
+	}
+	if err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	if err = s.GrantRole(ctx, userId, RoleAdmin); err != nil {
+		return fmt.Errorf("%s: %w", op, err)
+	}
+
+	return nil
+}
+
+func (s *Storage) userAndRole(userId int, role string) (int, error) {
+	var userExists bool
+	var roleId sql.NullInt64
+	err := s.Db.QueryRow(`SELECT EXISTS(SELECT 1 FROM users WHERE user_id=$1),
+								(SELECT role_id FROM roles WHERE role_name=$2 ORDER BY role_id LIMIT 1)`, userId, role).
+		Scan(&userExists, &roleId)
+	if err != nil {
+		return 0, err
+	}
+
+	if !userExists {
+		return 0, storage.ErrUserNotFound
+	}
+
+	if !roleId.Valid {
+		return 0, storage.ErrRoleNotFound
+	}
+
+	return int(roleId.Int64), nil
+}
+
+func revokeUserRefreshTokens(tx *sql.Tx, userId int) error {
+	_, err := tx.Exec("UPDATE refresh_tokens SET revoked_at=now() WHERE user_id=$1 AND revoked_at IS NULL", userId)
+
+	return err
+}
+
+// revokeUserAccessTokens makes IsAccessTokenRevoked reject the access
+// tokens the user was issued until now.
+func revokeUserAccessTokens(tx *sql.Tx, userId int) error {
+	_, err := tx.Exec(`INSERT INTO user_token_revocations(user_id, revoked_before) VALUES ($1, now())
+							 ON CONFLICT (user_id) DO UPDATE SET revoked_before = EXCLUDED.revoked_before`, userId)
+
+	return err
+}
+
+// keepAnAdmin returns ErrLastAdmin if userId is the only enabled admin. The
+// admins are locked until tx ends, so that concurrent changes to two admins
+// can not both pass.
+func keepAnAdmin(tx *sql.Tx, userId int) error {
+	rows, err := tx.Query(`SELECT u.user_id FROM users u
+								 JOIN user_role ur ON ur.user_id = u.user_id
+								 JOIN roles r ON r.role_id = ur.role_id
+								 WHERE r.role_name=$1 AND u.disabled_at IS NULL
+								 FOR UPDATE OF u`, RoleAdmin)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	admins := make(map[int]bool)
+	for rows.Next() {
+		var adminId int
+		if err = rows.Scan(&adminId); err != nil {
+			return err
+		}
+		admins[adminId] = true
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	if admins[userId] && len(admins) == 1 {
+		return storage.ErrLastAdmin
+	}
+
+	return nil
+}
+
+func expectAffected(res sql.Result, notFound error) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if n == 0 {
+		return notFound
+	}
+
+	return nil
+}
+
+// containsPattern builds an ILIKE pattern matching query anywhere, with
+// LIKE wildcards in query taken literally.
+func containsPattern(query string) string {
+	return "%" + likeEscaper.Replace(query) + "%"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
@@ -0,0 +1,62 @@
+package postgres_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/storage"
+	"film_library/internal/storage/postgres"
+)
+
+func TestUserTokenRevocation(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.EnsureDefaultRoles())
+	ctx := context.Background()
+
+	require.NoError(t, s.SaveUser(ctx, "alice", "password"))
+	alice, err := s.GetUser("alice", "password")
+	require.NoError(t, err)
+
+	issuedAt := time.Now().Add(-time.Minute).Truncate(time.Second)
+
+	revoked, err := s.IsAccessTokenRevoked("jti", alice, issuedAt)
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	// disabling ends the sessions at once, not when the access token expires
+	require.NoError(t, s.SetUserDisabled(ctx, alice, true))
+
+	revoked, err = s.IsAccessTokenRevoked("jti", alice, issuedAt)
+	require.NoError(t, err)
+	require.True(t, revoked)
+
+	revoked, err = s.IsAccessTokenRevoked("jti", alice, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	require.False(t, revoked)
+}
+
+func TestLastAdmin(t *testing.T) {
+	s := newTestStorage(t)
+	require.NoError(t, s.EnsureDefaultRoles())
+	ctx := context.Background()
+
+	require.NoError(t, s.EnsureAdmin(ctx, "root", "password"))
+	root, err := s.GetUser("root", "password")
+	require.NoError(t, err)
+
+	require.ErrorIs(t, s.SetUserDisabled(ctx, root, true), storage.ErrLastAdmin)
+	require.ErrorIs(t, s.RevokeRole(ctx, root, postgres.RoleAdmin), storage.ErrLastAdmin)
+
+	require.NoError(t, s.EnsureAdmin(ctx, "second", "password"))
+	require.NoError(t, s.RevokeRole(ctx, root, postgres.RoleAdmin))
+
+	second, err := s.GetUser("second", "password")
+	require.NoError(t, err)
+	require.ErrorIs(t, s.SetUserDisabled(ctx, second, true), storage.ErrLastAdmin)
+
+	// users that are not admins are not held back
+	require.NoError(t, s.SetUserDisabled(ctx, root, true))
+}
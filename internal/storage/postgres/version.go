@@ -0,0 +1,50 @@
+package postgres
+
+import (
+	"database/sql"
+	"errors"
+	"film_library/internal/storage"
+	"fmt"
+)
+
+// AnyVersion makes a conditional write apply whatever the current version
+// of the row is.
+const AnyVersion = 0
+
+// lockVersion locks the row of table with the given id until tx ends and
+// checks that it is at version. A row in the trash counts as missing.
+func lockVersion(tx *sql.Tx, table string, idColumn string, id int, version int) error {
+	var current int
+	err := tx.QueryRow(fmt.Sprintf("SELECT version FROM %s WHERE %s=$1 AND deleted_at IS NULL FOR UPDATE", table, idColumn), id).
+		Scan(&current)
+	if errors.Is(err, sql.ErrNoRows) {
+		return storage.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if version != AnyVersion && version != current {
+		return storage.ErrVersionMismatch
+	}
+
+	return nil
+}
+
+// missingOrStale tells why a conditional write in tx matched no row: the
+// row does not exist, is in the trash or it is at another version. It looks
+// on tx so that the answer agrees with what the write saw.
+func missingOrStale(tx *sql.Tx, table string, idColumn string, id int) error {
+	var exists bool
+	err := tx.QueryRow(fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE %s=$1 AND deleted_at IS NULL)", table, idColumn), id).
+		Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return storage.ErrNotFound
+	}
+
+	return storage.ErrVersionMismatch
+}
@@ -0,0 +1,38 @@
+package purger
+
+import (
+	"context"
+	"film_library/internal/lib/logger/sl"
+	"log/slog"
+	"time"
+)
+
+type Purger interface {
+	PurgeDeleted(ctx context.Context, before time.Time) (int, error)
+}
+
+// Run removes for good what has been in the trash for longer than
+// retention, once on start and then every interval, until ctx is done.
+func Run(ctx context.Context, log *slog.Logger, purger Purger, retention time.Duration, interval time.Duration) {
+	const op = "storage.purger.Run"
+
+	log = log.With(slog.String("op", op))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := purger.PurgeDeleted(ctx, time.Now().Add(-retention))
+		if err != nil {
+			log.Error("failed to purge trash", sl.Err(err))
+		} else if purged > 0 {
+			log.Info("trash purged", slog.Int("purged", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
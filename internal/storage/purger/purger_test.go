@@ -0,0 +1,50 @@
+package purger_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"film_library/internal/lib/logger/handlers/slogdiscard"
+	"film_library/internal/storage/purger"
+)
+
+type fakePurger struct {
+	mu      sync.Mutex
+	cutoffs []time.Time
+	cancel  context.CancelFunc
+}
+
+func (p *fakePurger) PurgeDeleted(ctx context.Context, before time.Time) (int, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.cutoffs = append(p.cutoffs, before)
+	if len(p.cutoffs) == 3 {
+		p.cancel()
+	}
+
+	// a failed run does not stop the next ones
+	if len(p.cutoffs) == 2 {
+		return 0, errors.New("unexpected error")
+	}
+
+	return 1, nil
+}
+
+func TestRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	p := &fakePurger{cancel: cancel}
+
+	start := time.Now()
+	purger.Run(ctx, slogdiscard.NewDiscardLogger(), p, 24*time.Hour, time.Millisecond)
+
+	require.GreaterOrEqual(t, len(p.cutoffs), 3)
+	require.WithinDuration(t, start.Add(-24*time.Hour), p.cutoffs[0], time.Second)
+}
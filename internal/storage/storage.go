@@ -2,4 +2,37 @@ package storage
 
 import "errors"
 
-var ErrUserExists = errors.New("username already exists")
+var (
+	ErrNotFound              = errors.New("not found")
+	ErrConflict              = errors.New("conflicts with existing data")
+	ErrForeignKey            = errors.New("references missing data")
+	ErrInvalidValue          = errors.New("value is not allowed")
+	ErrInvalidCursor         = errors.New("invalid cursor")
+	ErrVersionMismatch       = errors.New("version mismatch")
+	ErrAmbiguous             = errors.New("matches more than one record")
+	ErrUserExists            = errors.New("username already exists")
+	ErrInvalidCredentials    = errors.New("invalid username or password")
+	ErrUserNotFound          = errors.New("user not found")
+	ErrUserDisabled          = errors.New("user is disabled")
+	ErrRoleNotFound          = errors.New("role not found")
+	ErrLastAdmin             = errors.New("last enabled admin")
+	ErrPasswordResetRequired = errors.New("password reset required")
+	ErrTokenNotFound         = errors.New("refresh token not found")
+	ErrTokenExpired          = errors.New("refresh token expired")
+	ErrTokenReused           = errors.New("refresh token reuse detected")
+)
+
+// ConstraintError is a write rejected by a database constraint. Field names
+// the request field the constraint is about, Err is one of the errors above.
+type ConstraintError struct {
+	Field string
+	Err   error
+}
+
+func (e *ConstraintError) Error() string {
+	return e.Field + ": " + e.Err.Error()
+}
+
+func (e *ConstraintError) Unwrap() error {
+	return e.Err
+}